@@ -13,6 +13,7 @@ import (
 
 	"github.com/KOMKZ/go-yogan-framework/auth"
 	"github.com/KOMKZ/go-yogan-framework/breaker"
+	"github.com/KOMKZ/go-yogan-framework/cache"
 	"github.com/KOMKZ/go-yogan-framework/config"
 	"github.com/KOMKZ/go-yogan-framework/database"
 	"github.com/KOMKZ/go-yogan-framework/di"
@@ -22,6 +23,7 @@ import (
 	"github.com/KOMKZ/go-yogan-framework/limiter"
 	"github.com/KOMKZ/go-yogan-framework/logger"
 	"github.com/KOMKZ/go-yogan-framework/redis"
+	"github.com/KOMKZ/go-yogan-framework/retry"
 	"github.com/KOMKZ/go-yogan-framework/telemetry"
 	"github.com/samber/do/v2"
 	"go.uber.org/zap"
@@ -324,6 +326,39 @@ func (b *BaseApplication) registerComponentMetrics() {
 		}
 	}
 
+	// Register Retry Metrics
+	if metricsCfg.Retry.Enabled {
+		retryMetrics := retry.NewOTelMetrics(retry.MetricsConfig{
+			Enabled:      true,
+			RecordBudget: metricsCfg.Retry.RecordBudget,
+		})
+		if err := registry.Register(retryMetrics); err == nil {
+			// 注入 Metrics 到命名重试策略，按策略名记录重试指标
+			if retryRegistry, err := do.Invoke[*retry.Registry](b.injector); err == nil && retryRegistry != nil {
+				retryRegistry.SetMetrics(retryMetrics)
+				b.logger.DebugCtx(b.ctx, "✅ Retry Metrics registered with Registry")
+			} else {
+				b.logger.DebugCtx(b.ctx, "✅ Retry Metrics registered (no retry policies)")
+			}
+			do.ProvideValue(b.injector, retryMetrics)
+		}
+	}
+
+	// Register Cache Metrics
+	if metricsCfg.Cache.Enabled {
+		if cacheOrch, err := do.Invoke[*cache.DefaultOrchestrator](b.injector); err == nil && cacheOrch != nil {
+			cacheMetrics := cache.NewCacheMetrics(cache.CacheMetricsConfig{
+				Enabled:        true,
+				RecordHitRatio: metricsCfg.Cache.RecordHitRatio,
+			})
+			if err := registry.Register(cacheMetrics); err == nil {
+				// 注入 Metrics 到 Cache Orchestrator，实现缓存指标记录
+				cacheOrch.SetMetrics(cacheMetrics)
+				b.logger.DebugCtx(b.ctx, "✅ Cache Metrics registered with Orchestrator")
+			}
+		}
+	}
+
 	// Register Limiter Metrics
 	if metricsCfg.Limiter.Enabled {
 		if limiterMgr, err := do.Invoke[*limiter.Manager](b.injector); err == nil && limiterMgr != nil {
@@ -90,6 +90,11 @@ func NewHTTPServer(cfg ApiServerConfig, middlewareCfg *MiddlewareConfig, httpxCf
 			rateLimiterCfg.SkipPaths = limiterCfg.SkipPaths
 		}
 
+		// Rate limit response headers
+		if limiterCfg.Headers != "" {
+			rateLimiterCfg.HeaderStyle = middleware.RateLimitHeaderStyle(limiterCfg.Headers)
+		}
+
 		// Choose key function based on configuration
 		switch limiterCfg.KeyFunc {
 		case "ip":
@@ -323,6 +328,11 @@ func NewHTTPServerWithTelemetry(
 			rateLimiterCfg.SkipPaths = limiterCfg.SkipPaths
 		}
 
+		// Rate limit response headers
+		if limiterCfg.Headers != "" {
+			rateLimiterCfg.HeaderStyle = middleware.RateLimitHeaderStyle(limiterCfg.Headers)
+		}
+
 		// Choose key function based on configuration
 		switch limiterCfg.KeyFunc {
 		case "ip":
@@ -5,10 +5,13 @@
 // - Event-driven, the application layer can subscribe to all events
 // - Metrics exposed, application layer can access and subscribe to real-time data
 // - Optional enablement, does not take effect if not configured
+// - Optional bulkheads per resource (semaphore or worker pool), rejections return ErrBulkheadFull
+// - Optional shared state (state_store: redis), open transitions and resets apply to every instance
 package breaker
 
 import (
 	"context"
+	"strings"
 	"time"
 )
 
@@ -81,6 +84,15 @@ const (
 	
 	// StateHalfOpen Half open (recovery probe)
 	StateHalfOpen
+
+	// StateForcedOpen Forced open by an operator (rejects every call until cleared)
+	StateForcedOpen
+
+	// StateForcedClosed Forced closed by an operator (records metrics, never opens until cleared)
+	StateForcedClosed
+
+	// StateDisabled Breaker disabled by an operator (calls pass through unrecorded until cleared)
+	StateDisabled
 )
 
 // Return status name
@@ -92,14 +104,36 @@ func (s State) String() string {
 		return "Open"
 	case StateHalfOpen:
 		return "HalfOpen"
+	case StateForcedOpen:
+		return "ForcedOpen"
+	case StateForcedClosed:
+		return "ForcedClosed"
+	case StateDisabled:
+		return "Disabled"
 	default:
 		return "Unknown"
 	}
 }
 
+// ParseState parses a state name (e.g., "forced_open", "ForcedOpen")
+func ParseState(name string) (State, bool) {
+	normalized := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "_", "")
+	for s := StateClosed; s <= StateDisabled; s++ {
+		if strings.ToLower(s.String()) == normalized {
+			return s, true
+		}
+	}
+	return StateClosed, false
+}
+
+// IsForced whether the state is set by an operator (forced open, forced closed, disabled)
+func (s State) IsForced() bool {
+	return s == StateForcedOpen || s == StateForcedClosed || s == StateDisabled
+}
+
 // IsOpen whether it is in circuit breaker state
 func (s State) IsOpen() bool {
-	return s == StateOpen
+	return s == StateOpen || s == StateForcedOpen
 }
 
 // IsClosed whether in normal state
@@ -17,6 +17,15 @@ var (
 
 	// ErrTooManyRequests Too many requests in half-open state
 	ErrTooManyRequests = errors.New("too many requests in half-open state")
+
+	// ErrForcedOpen the resource was forced open by an operator (errors.Is ErrCircuitOpen)
+	ErrForcedOpen = fmt.Errorf("%w: forced open", ErrCircuitOpen)
+
+	// ErrInvalidForcedState only ForcedOpen, ForcedClosed and Disabled can be forced
+	ErrInvalidForcedState = errors.New("state cannot be forced, use forced_open, forced_closed or disabled")
+
+	// ErrNotEnabled the breaker manager is not enabled
+	ErrNotEnabled = errors.New("circuit breaker is not enabled")
 )
 
 // circuit breaker implementation
@@ -29,28 +38,102 @@ type circuitBreaker struct {
 	eventBus EventBus
 	logger   *logger.CtxZapLogger
 	mu       sync.RWMutex
+
+	// shared state (optional)
+	shared     StateBackend
+	probeSlots int
+
+	// bulkhead isolation (optional)
+	bulkhead bulkhead
+
+	// classifier decides which errors count as failures (nil: every error)
+	classifier *errorClassifier
 }
 
+// sharedStateTimeout timeout of shared state backend calls
+const sharedStateTimeout = 3 * time.Second
+
 // Create circuit breaker instance
-func newCircuitBreaker(resource string, config ResourceConfig, eventBus EventBus, log *logger.CtxZapLogger) *circuitBreaker {
+func newCircuitBreaker(resource string, config ResourceConfig, eventBus EventBus, log *logger.CtxZapLogger) (*circuitBreaker, error) {
+	bh, err := newBulkhead(config.Bulkhead)
+	if err != nil {
+		return nil, fmt.Errorf("create bulkhead of %s: %w", resource, err)
+	}
+
 	stateMgr := newStateManager()
-	metrics := newSlidingWindowMetrics(resource, config, stateMgr)
+	metrics := newMetricsCollector(resource, config, stateMgr)
 	strategy := GetStrategyByName(config.Strategy)
 
-	return &circuitBreaker{
-		resource: resource,
-		config:   config,
-		stateMgr: stateMgr,
-		metrics:  metrics,
-		strategy: strategy,
-		eventBus: eventBus,
-		logger:   log,
+	var classifier *errorClassifier
+	if config.Errors.configured() {
+		// Lists are checked by Validate
+		classifier, _ = newErrorClassifier(config.Errors)
 	}
+
+	return &circuitBreaker{
+		resource:   resource,
+		classifier: classifier,
+		config:     config,
+		stateMgr:   stateMgr,
+		metrics:    metrics,
+		strategy:   strategy,
+		eventBus:   eventBus,
+		logger:     log,
+		bulkhead:   bh,
+	}, nil
 }
 
-// Execute the protected operation
+// Execute the protected operation (within the bulkhead when configured)
 func (cb *circuitBreaker) Execute(ctx context.Context, req *Request) (interface{}, error) {
+	if cb.bulkhead == nil {
+		return cb.execute(ctx, req)
+	}
+
+	// The bulkhead is entered before the circuit check, a rejected call never takes a half-open slot
+	var result interface{}
+	var err error
+	runErr := cb.bulkhead.Run(ctx, func() {
+		result, err = cb.execute(ctx, req)
+	})
+	if runErr == nil {
+		return result, err
+	}
+
+	if errors.Is(runErr, ErrBulkheadFull) {
+		stats := cb.bulkhead.Stats()
+		if cb.logger != nil {
+			cb.logger.WarnCtx(ctx, "⛔ [CircuitBreaker] Request rejected by bulkhead",
+				zap.String("resource", cb.resource),
+				zap.String("mode", stats.Mode),
+				zap.Int("active", stats.Active),
+				zap.Int("waiting", stats.Waiting))
+		}
+
+		// Publish bulkhead rejection event
+		if cb.eventBus != nil {
+			cb.eventBus.Publish(&BulkheadEvent{
+				BaseEvent: NewBaseEvent(EventBulkheadRejected, cb.resource, ctx),
+				Stats:     stats,
+			})
+		}
+
+		// Try to execute fallback scenario
+		if req.Fallback != nil {
+			return cb.executeFallback(ctx, req, ErrBulkheadFull)
+		}
+	}
+	return nil, runErr
+}
+
+// execute the protected operation
+func (cb *circuitBreaker) execute(ctx context.Context, req *Request) (interface{}, error) {
 	currentState := cb.stateMgr.GetState()
+
+	// Disabled by an operator: pass through without recording
+	if currentState == StateDisabled {
+		return req.Execute(ctx)
+	}
+
 	snapshot := cb.metrics.GetSnapshot()
 
 	if cb.logger != nil {
@@ -63,7 +146,7 @@ func (cb *circuitBreaker) Execute(ctx context.Context, req *Request) (interface{
 	}
 
 	// Check if execution is allowed
-	if !cb.stateMgr.CanAttempt(cb.config) {
+	if !cb.allow(ctx) {
 		if cb.logger != nil {
 			cb.logger.WarnCtx(ctx, "⛔ [CircuitBreaker] Request rejected",
 				zap.String("resource", cb.resource),
@@ -79,12 +162,17 @@ func (cb *circuitBreaker) Execute(ctx context.Context, req *Request) (interface{
 			})
 		}
 
+		rejectErr := ErrCircuitOpen
+		if cb.stateMgr.GetState() == StateForcedOpen {
+			rejectErr = ErrForcedOpen
+		}
+
 		// Try to execute fallback scenario
 		if req.Fallback != nil {
-			return cb.executeFallback(ctx, req, ErrCircuitOpen)
+			return cb.executeFallback(ctx, req, rejectErr)
 		}
 
-		return nil, ErrCircuitOpen
+		return nil, rejectErr
 	}
 
 	if cb.logger != nil {
@@ -98,7 +186,16 @@ func (cb *circuitBreaker) Execute(ctx context.Context, req *Request) (interface{
 	result, err := req.Execute(ctx)
 	duration := time.Since(start)
 
-	if err != nil {
+	if err != nil && !cb.isFailure(err) {
+		// The downstream answered (e.g., not found, validation error)
+		if cb.logger != nil {
+			cb.logger.DebugCtx(ctx, "✅ [CircuitBreaker] Call returned an ignored error",
+				zap.String("resource", cb.resource),
+				zap.Duration("duration", duration),
+				zap.Error(err))
+		}
+		cb.handleSuccess(ctx, duration)
+	} else if err != nil {
 		if cb.logger != nil {
 			cb.logger.DebugCtx(ctx, "❌ [CircuitBreaker] Call failed",
 				zap.String("resource", cb.resource),
@@ -118,6 +215,14 @@ func (cb *circuitBreaker) Execute(ctx context.Context, req *Request) (interface{
 	return result, err
 }
 
+// isFailure whether an error counts as a failure (timeouts always do)
+func (cb *circuitBreaker) isFailure(err error) bool {
+	if cb.classifier == nil || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return true
+	}
+	return cb.classifier.IsFailure(err)
+}
+
 // handle success
 func (cb *circuitBreaker) handleSuccess(ctx context.Context, duration time.Duration) {
 	if cb.logger != nil {
@@ -141,6 +246,7 @@ func (cb *circuitBreaker) handleSuccess(ctx context.Context, duration time.Durat
 	changed, fromState, toState := cb.stateMgr.RecordSuccess(cb.config)
 	if changed {
 		cb.publishStateChangedEvent(ctx, fromState, toState, "success threshold reached")
+		cb.shareTransition(ctx, toState)
 	}
 
 	// If it's a consecutive failure strategy, reset the counter
@@ -189,9 +295,10 @@ func (cb *circuitBreaker) handleFailure(ctx context.Context, duration time.Durat
 	}
 
 	// Update status
-	changed, fromState, toState := cb.stateMgr.RecordFailure()
+	changed, fromState, toState := cb.stateMgr.RecordFailure(cb.config)
 	if changed {
 		cb.publishStateChangedEvent(ctx, fromState, toState, "failure in half-open state")
+		cb.shareTransition(ctx, toState)
 		return
 	}
 
@@ -221,7 +328,133 @@ func (cb *circuitBreaker) handleFailure(ctx context.Context, duration time.Durat
 		changed, fromState, toState := cb.stateMgr.ShouldOpen(true)
 		if changed {
 			cb.publishStateChangedEvent(ctx, fromState, toState, "error threshold exceeded")
+			cb.shareTransition(ctx, toState)
+		}
+	}
+}
+
+// allow checks whether a call may run, with a shared state only instances holding a probe slot go half-open
+func (cb *circuitBreaker) allow(ctx context.Context) bool {
+	if cb.shared != nil && cb.stateMgr.ProbeDue(cb.config) {
+		probeCtx, cancel := context.WithTimeout(ctx, sharedStateTimeout)
+		acquired, err := cb.shared.AcquireProbe(probeCtx, cb.resource, cb.probeSlots, cb.config.Timeout)
+		cancel()
+		if err != nil && cb.logger != nil {
+			// Backend unavailable: probe locally
+			cb.logger.WarnCtx(ctx, "⚠️  [CircuitBreaker] Acquire shared probe failed",
+				zap.String("resource", cb.resource),
+				zap.Error(err))
+		}
+		if err == nil && !acquired {
+			// Other instances are probing, their result is shared
+			cb.stateMgr.DeferProbe(min(cb.config.Timeout, time.Second))
+			return false
+		}
+	}
+	return cb.stateMgr.CanAttempt(cb.config)
+}
+
+// shareTransition publishes Open and Closed transitions to the other instances (shared state)
+func (cb *circuitBreaker) shareTransition(ctx context.Context, toState State) {
+	if cb.shared == nil {
+		return
+	}
+
+	msg := StateMessage{Resource: cb.resource}
+	switch toState {
+	case StateOpen:
+		msg.State = SharedStateOpen
+		msg.Until = cb.stateMgr.GetLastStateChange().Add(cb.config.Timeout)
+	case StateClosed:
+		msg.State = SharedStateClosed
+	default:
+		return
+	}
+
+	publishCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sharedStateTimeout)
+	defer cancel()
+	if err := cb.shared.Publish(publishCtx, msg); err != nil && cb.logger != nil {
+		cb.logger.WarnCtx(ctx, "⚠️  [CircuitBreaker] Publish shared state failed",
+			zap.String("resource", cb.resource),
+			zap.String("state", msg.State),
+			zap.Error(err))
+	}
+}
+
+// applyShared applies a transition of another instance (or a manual reset)
+func (cb *circuitBreaker) applyShared(ctx context.Context, msg StateMessage) {
+	var changed bool
+	var fromState, toState State
+	var reason string
+
+	switch msg.State {
+	case SharedStateOpen:
+		changed, fromState, toState = cb.stateMgr.OpenSince(msg.Until.Add(-cb.config.Timeout))
+		reason = "opened by another instance"
+	case SharedStateClosed:
+		changed, fromState, toState = cb.stateMgr.Reset()
+		if changed {
+			cb.metrics.Reset()
+		}
+		reason = "closed by another instance"
+	case SharedStateReset:
+		changed, fromState, toState = cb.stateMgr.Reset()
+		cb.metrics.Reset()
+		reason = "manual reset"
+	case SharedStateForced:
+		state, ok := ParseState(msg.Forced)
+		if !ok || !state.IsForced() {
+			return
 		}
+		changed, fromState, toState = cb.stateMgr.Force(state)
+		reason = "forced by operator"
+	case SharedStateUnforced:
+		changed, fromState, toState = cb.stateMgr.ClearForced()
+		if changed {
+			cb.metrics.Reset()
+		}
+		reason = "forced state cleared"
+	default:
+		return
+	}
+
+	if changed && toState == StateClosed {
+		// Start counting consecutive failures again
+		if s, ok := cb.strategy.(*consecutiveFailuresStrategy); ok {
+			s.RecordSuccess()
+		}
+	}
+	if changed {
+		cb.publishStateChangedEvent(ctx, fromState, toState, reason)
+	}
+}
+
+// publishShared publishes an operator transition to the other instances (no-op without a shared backend)
+func (cb *circuitBreaker) publishShared(ctx context.Context, msg StateMessage) error {
+	if cb.shared == nil {
+		return nil
+	}
+	publishCtx, cancel := context.WithTimeout(ctx, sharedStateTimeout)
+	defer cancel()
+	return cb.shared.Publish(publishCtx, msg)
+}
+
+// loadShared opens a new breaker when the resource is open on other instances
+func (cb *circuitBreaker) loadShared(ctx context.Context) {
+	loadCtx, cancel := context.WithTimeout(ctx, sharedStateTimeout)
+	defer cancel()
+
+	until, err := cb.shared.Load(loadCtx, cb.resource)
+	if err != nil {
+		if cb.logger != nil {
+			cb.logger.WarnCtx(ctx, "⚠️  [CircuitBreaker] Load shared state failed",
+				zap.String("resource", cb.resource),
+				zap.Error(err))
+		}
+		return
+	}
+	if time.Now().Before(until) {
+		cb.applyShared(ctx, StateMessage{Resource: cb.resource, State: SharedStateOpen, Until: until})
 	}
 }
 
@@ -262,6 +495,14 @@ func (cb *circuitBreaker) publishStateChangedEvent(ctx context.Context, fromStat
 	}
 }
 
+// GetBulkheadStats Retrieve bulkhead occupancy (false when no bulkhead is configured)
+func (cb *circuitBreaker) GetBulkheadStats() (BulkheadStats, bool) {
+	if cb.bulkhead == nil {
+		return BulkheadStats{}, false
+	}
+	return cb.bulkhead.Stats(), true
+}
+
 // GetState Retrieve circuit breaker status
 func (cb *circuitBreaker) GetState() State {
 	return cb.stateMgr.GetState()
@@ -285,6 +526,7 @@ type Manager struct {
 	eventBus    EventBus
 	logger      *logger.CtxZapLogger
 	otelMetrics *OTelBreakerMetrics // Optional: OTel metrics provider (injected after creation)
+	shared      StateBackend        // Optional: shared state backend (injected after creation)
 	mu          sync.RWMutex
 }
 
@@ -323,18 +565,174 @@ func NewManagerWithLogger(config Config, ctxLogger *logger.CtxZapLogger) (*Manag
 	ctxLogger.DebugCtx(ctx, "🎯 Circuit breaker manager initialization",
 		zap.Int("event_bus_buffer", config.EventBusBuffer))
 
-	return &Manager{
+	m := &Manager{
 		config:   config,
 		breakers: make(map[string]*circuitBreaker),
 		eventBus: eventBus,
 		logger:   ctxLogger,
-	}, nil
+	}
+
+	// Resources forced in the configuration are listed from the start
+	for resource, resourceConfig := range config.Resources {
+		if resourceConfig.ForcedState != "" {
+			if _, err := m.getOrCreateBreaker(resource); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return m, nil
 }
 
 // SetMetrics injects the OTel metrics provider.
 // This should be called after the Manager is created when metrics are enabled.
 func (m *Manager) SetMetrics(metrics *OTelBreakerMetrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.otelMetrics = metrics
+	for resource, breaker := range m.breakers {
+		m.registerStateCallback(resource, breaker)
+	}
+}
+
+// registerStateCallback exports the state of a breaker through the state gauge (lock required)
+func (m *Manager) registerStateCallback(resource string, breaker *circuitBreaker) {
+	if m.otelMetrics == nil {
+		return
+	}
+	m.otelMetrics.RegisterStateCallback(resource, func() int64 {
+		return int64(breaker.GetState())
+	})
+}
+
+// ForceState pins a resource to StateForcedOpen, StateForcedClosed or StateDisabled
+// The forced state survives automatic transitions and shared state updates until ClearForcedState.
+// With a shared state backend the forced state is applied on every running instance.
+func (m *Manager) ForceState(resource string, state State) error {
+	if !m.config.Enabled {
+		return ErrNotEnabled
+	}
+	if !state.IsForced() {
+		return ErrInvalidForcedState
+	}
+
+	breaker, err := m.getOrCreateBreaker(resource)
+	if err != nil {
+		return err
+	}
+	msg := StateMessage{Resource: resource, State: SharedStateForced, Forced: state.String()}
+	breaker.applyShared(context.Background(), msg)
+	if m.logger != nil {
+		m.logger.InfoCtx(context.Background(), "🔧 [BreakerManager] State forced",
+			zap.String("resource", resource),
+			zap.String("state", state.String()))
+	}
+	return breaker.publishShared(context.Background(), msg)
+}
+
+// ClearForcedState returns a forced resource to closed, automatic transitions apply again
+// With a shared state backend the forced state is cleared on every running instance.
+func (m *Manager) ClearForcedState(resource string) error {
+	if !m.config.Enabled {
+		return ErrNotEnabled
+	}
+
+	breaker, err := m.getOrCreateBreaker(resource)
+	if err != nil {
+		return err
+	}
+	msg := StateMessage{Resource: resource, State: SharedStateUnforced}
+	breaker.applyShared(context.Background(), msg)
+	if m.logger != nil {
+		m.logger.InfoCtx(context.Background(), "🔧 [BreakerManager] Forced state cleared",
+			zap.String("resource", resource))
+	}
+	// Published even when not forced here, the resource may be forced on other instances
+	return breaker.publishShared(context.Background(), msg)
+}
+
+// IsEnabled whether the circuit breaker is enabled
+func (m *Manager) IsEnabled() bool {
+	return m.config.Enabled
+}
+
+// States returns the state of every resource with a breaker
+func (m *Manager) States() map[string]State {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	states := make(map[string]State, len(m.breakers))
+	for resource, breaker := range m.breakers {
+		states[resource] = breaker.GetState()
+	}
+	return states
+}
+
+// SetStateBackend shares the breaker state with other instances through the backend
+// Open transitions and manual resets are applied cluster-wide, half-open probes are coordinated.
+// This should be called once after the Manager is created.
+func (m *Manager) SetStateBackend(backend StateBackend) error {
+	if !m.config.Enabled || backend == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	m.shared = backend
+	for _, breaker := range m.breakers {
+		breaker.shared = backend
+		breaker.probeSlots = m.probeSlots()
+	}
+	m.mu.Unlock()
+
+	return backend.Subscribe(m.applySharedState)
+}
+
+// applySharedState applies a transition published by another instance
+func (m *Manager) applySharedState(msg StateMessage) {
+	if msg.Resource == "" {
+		return
+	}
+	ctx := context.Background()
+	if m.logger != nil {
+		m.logger.DebugCtx(ctx, "🔄 [BreakerManager] Shared state received",
+			zap.String("resource", msg.Resource),
+			zap.String("state", msg.State))
+	}
+	breaker, err := m.getOrCreateBreaker(msg.Resource)
+	if err != nil {
+		if m.logger != nil {
+			m.logger.WarnCtx(ctx, "⚠️  [BreakerManager] Apply shared state failed",
+				zap.String("resource", msg.Resource),
+				zap.Error(err))
+		}
+		return
+	}
+	breaker.applyShared(ctx, msg)
+}
+
+// probeSlots returns the number of instances probing a half-open resource at once
+func (m *Manager) probeSlots() int {
+	if m.config.Redis.ProbeInstances > 0 {
+		return m.config.Redis.ProbeInstances
+	}
+	return 1
+}
+
+// Reset manually closes a resource and clears its metrics
+// With a shared state backend the reset is applied on every instance. Forced states are kept (ClearForcedState).
+func (m *Manager) Reset(resource string) error {
+	if !m.config.Enabled {
+		return nil
+	}
+
+	ctx := context.Background()
+	msg := StateMessage{Resource: resource, State: SharedStateReset}
+	breaker, err := m.getOrCreateBreaker(resource)
+	if err != nil {
+		return err
+	}
+	breaker.applyShared(ctx, msg)
+	return breaker.publishShared(ctx, msg)
 }
 
 // Execute the protected operation
@@ -365,7 +763,10 @@ func (m *Manager) Execute(ctx context.Context, req *Request) (interface{}, error
 	}
 
 	// Get or create the circuit breaker
-	breaker := m.getOrCreateBreaker(req.Resource)
+	breaker, err := m.getOrCreateBreaker(req.Resource)
+	if err != nil {
+		return nil, err
+	}
 	if m.logger != nil {
 		m.logger.DebugCtx(ctx, "🔍 [BreakerManager] Getting circuit breaker",
 			zap.String("resource", req.Resource),
@@ -387,6 +788,8 @@ func (m *Manager) Execute(ctx context.Context, req *Request) (interface{}, error
 	if m.otelMetrics != nil {
 		if errors.Is(err, ErrCircuitOpen) || errors.Is(err, ErrTooManyRequests) {
 			m.otelMetrics.RecordRejection(ctx, req.Resource)
+		} else if errors.Is(err, ErrBulkheadFull) {
+			m.otelMetrics.RecordBulkheadRejection(ctx, req.Resource)
 		} else if err != nil {
 			m.otelMetrics.RecordFailure(ctx, req.Resource, duration, "error")
 		} else {
@@ -406,13 +809,19 @@ func (m *Manager) GetBreaker(resource string) *circuitBreaker {
 
 // GetState Retrieve circuit breaker status
 func (m *Manager) GetState(resource string) State {
-	breaker := m.getOrCreateBreaker(resource)
+	breaker, err := m.getOrCreateBreaker(resource)
+	if err != nil {
+		return StateClosed
+	}
 	return breaker.GetState()
 }
 
 // GetMetrics retrieves circuit breaker metrics
 func (m *Manager) GetMetrics(resource string) *MetricsSnapshot {
-	breaker := m.getOrCreateBreaker(resource)
+	breaker, err := m.getOrCreateBreaker(resource)
+	if err != nil {
+		return nil
+	}
 	return breaker.GetMetrics()
 }
 
@@ -423,42 +832,82 @@ func (m *Manager) GetEventBus() EventBus {
 
 // SubscribeMetrics subscribe metric updates
 func (m *Manager) SubscribeMetrics(resource string, observer MetricsObserver) ObserverID {
-	breaker := m.getOrCreateBreaker(resource)
+	breaker, err := m.getOrCreateBreaker(resource)
+	if err != nil {
+		return ""
+	}
 	return breaker.metrics.Subscribe(observer)
 }
 
+// GetBulkheadStats Retrieve bulkhead occupancy of a resource (false when no bulkhead is configured)
+func (m *Manager) GetBulkheadStats(resource string) (BulkheadStats, bool) {
+	if !m.config.Enabled {
+		return BulkheadStats{}, false
+	}
+	breaker, err := m.getOrCreateBreaker(resource)
+	if err != nil {
+		return BulkheadStats{}, false
+	}
+	return breaker.GetBulkheadStats()
+}
+
 // Close Manager
 func (m *Manager) Close() {
+	m.mu.Lock()
+	shared := m.shared
+	m.shared = nil
+	for _, breaker := range m.breakers {
+		if breaker.bulkhead != nil {
+			breaker.bulkhead.Close()
+		}
+	}
+	m.mu.Unlock()
+	if shared != nil {
+		_ = shared.Close()
+	}
 	if m.eventBus != nil {
 		m.eventBus.Close()
 	}
 }
 
 // getOrCreateBreaker Get or create breaker (thread-safe)
-func (m *Manager) getOrCreateBreaker(resource string) *circuitBreaker {
+// Fails when the bulkhead of the resource cannot be created, the breaker is not cached then
+func (m *Manager) getOrCreateBreaker(resource string) (*circuitBreaker, error) {
 	// Try to read first
 	m.mu.RLock()
 	if breaker, exists := m.breakers[resource]; exists {
 		m.mu.RUnlock()
-		return breaker
+		return breaker, nil
 	}
 	m.mu.RUnlock()
 
 	// Need to create, obtain write lock
 	m.mu.Lock()
-	defer m.mu.Unlock()
 
 	// Double check
 	if breaker, exists := m.breakers[resource]; exists {
-		return breaker
+		m.mu.Unlock()
+		return breaker, nil
 	}
 
 	// Get resource configuration
 	resourceConfig := m.config.GetResourceConfig(resource)
 
 	// Create new circuit breaker (pass in logger)
-	breaker := newCircuitBreaker(resource, resourceConfig, m.eventBus, m.logger)
+	breaker, err := newCircuitBreaker(resource, resourceConfig, m.eventBus, m.logger)
+	if err != nil {
+		m.mu.Unlock()
+		return nil, err
+	}
+	breaker.shared = m.shared
+	breaker.probeSlots = m.probeSlots()
 	m.breakers[resource] = breaker
+	m.registerStateCallback(resource, breaker)
+
+	// Forced state from the configuration
+	if state, ok := ParseState(resourceConfig.ForcedState); ok && state.IsForced() {
+		breaker.stateMgr.Force(state)
+	}
 
 	if m.logger != nil {
 		m.logger.DebugCtx(context.Background(), "🎯 Creating circuit breaker instance",
@@ -467,5 +916,12 @@ func (m *Manager) getOrCreateBreaker(resource string) *circuitBreaker {
 			zap.Duration("timeout", resourceConfig.Timeout))
 	}
 
-	return breaker
+	m.mu.Unlock()
+
+	// Start open when the resource is open on other instances (a shared store call, outside the manager lock)
+	if breaker.shared != nil {
+		breaker.loadShared(context.Background())
+	}
+
+	return breaker, nil
 }
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestNewManager test create manager
@@ -158,7 +159,8 @@ func TestCircuitBreaker_StateTransition(t *testing.T) {
 	defer mgr.Close()
 	
 	// The initial state should be Closed
-	breaker := mgr.getOrCreateBreaker("test")
+	breaker, err := mgr.getOrCreateBreaker("test")
+	require.NoError(t, err)
 	assert.Equal(t, StateClosed, breaker.GetState())
 	
 	// Simulate 10 requests, 6 failures
@@ -274,7 +276,8 @@ func TestCircuitBreaker_Reset(t *testing.T) {
 	mgr, _ := NewManager(config)
 	defer mgr.Close()
 	
-	breaker := mgr.getOrCreateBreaker("test")
+	breaker, err := mgr.getOrCreateBreaker("test")
+	require.NoError(t, err)
 	
 	// Trigger circuit breaker
 	for i := 0; i < 10; i++ {
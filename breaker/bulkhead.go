@@ -0,0 +1,265 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/panjf2000/ants/v2"
+)
+
+// ErrBulkheadFull the bulkhead of the resource has no free capacity (distinct from ErrCircuitOpen)
+var ErrBulkheadFull = errors.New("bulkhead is full")
+
+// Bulkhead modes
+const (
+	// BulkheadSemaphore limits concurrent calls, callers wait up to MaxWait for a permit
+	BulkheadSemaphore = "semaphore"
+
+	// BulkheadPool runs calls on a bounded worker pool with a bounded queue
+	BulkheadPool = "pool"
+)
+
+// BulkheadConfig bulkhead isolation of a resource (disabled when Mode is empty)
+type BulkheadConfig struct {
+	// Mode bulkhead mode: semaphore, pool
+	Mode string `mapstructure:"mode"`
+
+	// MaxConcurrent maximum concurrent calls (pool: number of workers)
+	MaxConcurrent int `mapstructure:"max_concurrent"`
+
+	// MaxWait maximum wait for a permit (semaphore: 0 rejects immediately) or a worker (pool: 0 waits while the context lasts)
+	MaxWait time.Duration `mapstructure:"max_wait"`
+
+	// QueueSize maximum calls waiting for a worker (pool, 0 rejects immediately)
+	QueueSize int `mapstructure:"queue_size"`
+}
+
+// Enabled whether the bulkhead is configured
+func (c BulkheadConfig) Enabled() bool {
+	return c.Mode != ""
+}
+
+// Validate bulkhead configuration
+func (c *BulkheadConfig) Validate() error {
+	switch c.Mode {
+	case "":
+		return nil
+	case BulkheadSemaphore, BulkheadPool:
+	default:
+		return &ValidationError{Field: "Bulkhead.Mode", Message: "must be 'semaphore' or 'pool'"}
+	}
+
+	if c.MaxConcurrent <= 0 {
+		return &ValidationError{Field: "Bulkhead.MaxConcurrent", Message: "must be > 0"}
+	}
+	if c.MaxWait < 0 {
+		return &ValidationError{Field: "Bulkhead.MaxWait", Message: "must be >= 0"}
+	}
+	if c.QueueSize < 0 {
+		return &ValidationError{Field: "Bulkhead.QueueSize", Message: "must be >= 0"}
+	}
+	return nil
+}
+
+// BulkheadStats bulkhead occupancy snapshot
+type BulkheadStats struct {
+	Mode          string
+	MaxConcurrent int
+	Active        int // calls running
+	Waiting       int // calls waiting for a permit or a worker
+}
+
+// bulkhead isolates the calls of a resource
+type bulkhead interface {
+	// Run executes fn within the bulkhead, ErrBulkheadFull when there is no capacity
+	Run(ctx context.Context, fn func()) error
+
+	// Stats returns the current occupancy
+	Stats() BulkheadStats
+
+	// Close releases the resources of the bulkhead
+	Close()
+}
+
+// newBulkhead creates the bulkhead of a resource (nil when not configured)
+func newBulkhead(cfg BulkheadConfig) (bulkhead, error) {
+	switch cfg.Mode {
+	case BulkheadSemaphore:
+		return newSemaphoreBulkhead(cfg), nil
+	case BulkheadPool:
+		return newPoolBulkhead(cfg)
+	default:
+		return nil, nil
+	}
+}
+
+// semaphoreBulkhead runs calls on the caller goroutine, at most MaxConcurrent at once
+type semaphoreBulkhead struct {
+	permits chan struct{}
+	maxWait time.Duration
+	waiting atomic.Int64
+}
+
+func newSemaphoreBulkhead(cfg BulkheadConfig) *semaphoreBulkhead {
+	return &semaphoreBulkhead{
+		permits: make(chan struct{}, cfg.MaxConcurrent),
+		maxWait: cfg.MaxWait,
+	}
+}
+
+// Run acquires a permit (waiting up to MaxWait) and executes fn
+func (b *semaphoreBulkhead) Run(ctx context.Context, fn func()) error {
+	if err := b.acquire(ctx); err != nil {
+		return err
+	}
+	defer func() { <-b.permits }()
+
+	fn()
+	return nil
+}
+
+// acquire takes a permit
+func (b *semaphoreBulkhead) acquire(ctx context.Context) error {
+	select {
+	case b.permits <- struct{}{}:
+		return nil
+	default:
+	}
+	if b.maxWait <= 0 {
+		return ErrBulkheadFull
+	}
+
+	b.waiting.Add(1)
+	defer b.waiting.Add(-1)
+
+	timer := time.NewTimer(b.maxWait)
+	defer timer.Stop()
+
+	select {
+	case b.permits <- struct{}{}:
+		return nil
+	case <-timer.C:
+		return ErrBulkheadFull
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stats returns the current occupancy
+func (b *semaphoreBulkhead) Stats() BulkheadStats {
+	return BulkheadStats{
+		Mode:          BulkheadSemaphore,
+		MaxConcurrent: cap(b.permits),
+		Active:        len(b.permits),
+		Waiting:       int(b.waiting.Load()),
+	}
+}
+
+// Close nothing to release
+func (b *semaphoreBulkhead) Close() {}
+
+// poolBulkhead runs calls on a bounded ants worker pool
+// At most MaxConcurrent calls run and QueueSize wait for a worker, up to MaxWait (when set) and while
+// their context lasts. A caller whose context ends stops waiting, a running call keeps its worker until it returns.
+type poolBulkhead struct {
+	pool      *ants.Pool
+	workers   chan struct{}
+	queueSize int64
+	maxWait   time.Duration
+	waiting   atomic.Int64
+}
+
+func newPoolBulkhead(cfg BulkheadConfig) (*poolBulkhead, error) {
+	// workers bounds the running calls, Submit only waits for a worker finishing its previous call
+	pool, err := ants.NewPool(cfg.MaxConcurrent)
+	if err != nil {
+		return nil, err
+	}
+	return &poolBulkhead{
+		pool:      pool,
+		workers:   make(chan struct{}, cfg.MaxConcurrent),
+		queueSize: int64(cfg.QueueSize),
+		maxWait:   cfg.MaxWait,
+	}, nil
+}
+
+// Run waits for a worker and executes fn on it, a panic in fn is returned as an error
+func (b *poolBulkhead) Run(ctx context.Context, fn func()) error {
+	if err := b.acquire(ctx); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	err := b.pool.Submit(func() {
+		defer func() { <-b.workers }()
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("bulkhead: call panicked: %v", r)
+			}
+		}()
+		fn()
+		done <- nil
+	})
+	if err != nil {
+		<-b.workers
+		if errors.Is(err, ants.ErrPoolOverload) || errors.Is(err, ants.ErrPoolClosed) {
+			return ErrBulkheadFull
+		}
+		return err
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// acquire takes a worker slot, queueing up to QueueSize callers
+func (b *poolBulkhead) acquire(ctx context.Context) error {
+	select {
+	case b.workers <- struct{}{}:
+		return nil
+	default:
+	}
+	if b.waiting.Add(1) > b.queueSize {
+		b.waiting.Add(-1)
+		return ErrBulkheadFull
+	}
+	defer b.waiting.Add(-1)
+
+	var timeout <-chan time.Time
+	if b.maxWait > 0 {
+		timer := time.NewTimer(b.maxWait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case b.workers <- struct{}{}:
+		return nil
+	case <-timeout:
+		return ErrBulkheadFull
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stats returns the current occupancy
+func (b *poolBulkhead) Stats() BulkheadStats {
+	return BulkheadStats{
+		Mode:          BulkheadPool,
+		MaxConcurrent: cap(b.workers),
+		Active:        len(b.workers),
+		Waiting:       int(b.waiting.Load()),
+	}
+}
+
+// Close releases the workers
+func (b *poolBulkhead) Close() {
+	b.pool.Release()
+}
@@ -0,0 +1,215 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBulkheadManager creates a manager with a bulkhead on resource "api"
+func newBulkheadManager(t *testing.T, bulkhead BulkheadConfig) *Manager {
+	config := DefaultConfig()
+	config.Enabled = true
+	config.Default.MinRequests = 1
+	config.Resources["api"] = ResourceConfig{Bulkhead: bulkhead}
+
+	mgr, err := NewManager(config)
+	require.NoError(t, err)
+	t.Cleanup(mgr.Close)
+	return mgr
+}
+
+// occupy starts n calls blocked until release is closed, returns when they run
+func occupy(t *testing.T, mgr *Manager, n int, release chan struct{}) {
+	var running atomic.Int32
+	for i := 0; i < n; i++ {
+		go func() {
+			_, _ = mgr.Execute(context.Background(), &Request{
+				Resource: "api",
+				Execute: func(ctx context.Context) (interface{}, error) {
+					running.Add(1)
+					<-release
+					return nil, nil
+				},
+			})
+		}()
+	}
+	require.Eventually(t, func() bool { return int(running.Load()) == n }, time.Second, time.Millisecond)
+}
+
+func TestBulkhead_SemaphoreRejects(t *testing.T) {
+	mgr := newBulkheadManager(t, BulkheadConfig{Mode: BulkheadSemaphore, MaxConcurrent: 2})
+
+	rejected := make(chan Event, 1)
+	mgr.GetEventBus().Subscribe(EventListenerFunc(func(event Event) {
+		rejected <- event
+	}), EventBulkheadRejected)
+
+	release := make(chan struct{})
+	occupy(t, mgr, 2, release)
+
+	err := call(mgr, "api", nil)
+	assert.ErrorIs(t, err, ErrBulkheadFull)
+	assert.NotErrorIs(t, err, ErrCircuitOpen)
+
+	select {
+	case event := <-rejected:
+		stats := event.(*BulkheadEvent).Stats
+		assert.Equal(t, BulkheadSemaphore, stats.Mode)
+		assert.Equal(t, 2, stats.Active)
+	case <-time.After(time.Second):
+		t.Fatal("bulkhead rejection event not published")
+	}
+
+	// Fallback receives the bulkhead error
+	result, err := mgr.Execute(context.Background(), &Request{
+		Resource: "api",
+		Execute: func(ctx context.Context) (interface{}, error) {
+			return "primary", nil
+		},
+		Fallback: func(ctx context.Context, err error) (interface{}, error) {
+			assert.ErrorIs(t, err, ErrBulkheadFull)
+			return "fallback", nil
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "fallback", result)
+
+	// Rejections are not failures of the resource
+	assert.Equal(t, StateClosed, mgr.GetState("api"))
+
+	close(release)
+	assert.Eventually(t, func() bool { return call(mgr, "api", nil) == nil }, time.Second, time.Millisecond)
+
+	// Other resources are not isolated
+	assert.NoError(t, call(mgr, "other", nil))
+	_, ok := mgr.GetBulkheadStats("other")
+	assert.False(t, ok)
+}
+
+func TestBulkhead_SemaphoreWaits(t *testing.T) {
+	mgr := newBulkheadManager(t, BulkheadConfig{Mode: BulkheadSemaphore, MaxConcurrent: 1, MaxWait: time.Second})
+
+	release := make(chan struct{})
+	occupy(t, mgr, 1, release)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+	}()
+	assert.NoError(t, call(mgr, "api", nil))
+
+	// The caller context bounds the wait
+	occupy(t, mgr, 1, make(chan struct{}))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := mgr.Execute(ctx, &Request{
+		Resource: "api",
+		Execute: func(ctx context.Context) (interface{}, error) {
+			return nil, nil
+		},
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestBulkhead_PoolQueues(t *testing.T) {
+	mgr := newBulkheadManager(t, BulkheadConfig{Mode: BulkheadPool, MaxConcurrent: 1, QueueSize: 1})
+
+	release := make(chan struct{})
+	occupy(t, mgr, 1, release)
+
+	// The second call waits in the queue
+	queued := make(chan error, 1)
+	go func() {
+		queued <- call(mgr, "api", nil)
+	}()
+	require.Eventually(t, func() bool {
+		stats, _ := mgr.GetBulkheadStats("api")
+		return stats.Waiting == 1
+	}, time.Second, time.Millisecond)
+
+	// The queue is full
+	assert.ErrorIs(t, call(mgr, "api", nil), ErrBulkheadFull)
+
+	close(release)
+	select {
+	case err := <-queued:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("queued call not executed")
+	}
+
+	stats, ok := mgr.GetBulkheadStats("api")
+	require.True(t, ok)
+	assert.Equal(t, BulkheadPool, stats.Mode)
+	assert.Equal(t, 1, stats.MaxConcurrent)
+}
+
+func TestBulkhead_PoolBoundsQueueWait(t *testing.T) {
+	mgr := newBulkheadManager(t, BulkheadConfig{Mode: BulkheadPool, MaxConcurrent: 1, QueueSize: 2, MaxWait: 20 * time.Millisecond})
+
+	release := make(chan struct{})
+	defer close(release)
+	occupy(t, mgr, 1, release)
+
+	// Queued calls give up after MaxWait
+	assert.ErrorIs(t, call(mgr, "api", nil), ErrBulkheadFull)
+
+	// and when their context ends
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	_, err := mgr.Execute(ctx, &Request{
+		Resource: "api",
+		Execute:  func(ctx context.Context) (interface{}, error) { return nil, nil },
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	stats, _ := mgr.GetBulkheadStats("api")
+	assert.Equal(t, 0, stats.Waiting)
+}
+
+func TestBulkhead_PoolRecoversPanics(t *testing.T) {
+	mgr := newBulkheadManager(t, BulkheadConfig{Mode: BulkheadPool, MaxConcurrent: 1})
+
+	_, err := mgr.Execute(context.Background(), &Request{
+		Resource: "api",
+		Execute:  func(ctx context.Context) (interface{}, error) { panic("boom") },
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+
+	// The worker is free again
+	assert.NoError(t, call(mgr, "api", nil))
+}
+
+func TestBulkhead_PoolReturnsCallError(t *testing.T) {
+	mgr := newBulkheadManager(t, BulkheadConfig{Mode: BulkheadPool, MaxConcurrent: 2})
+
+	failure := errors.New("failed")
+	assert.ErrorIs(t, call(mgr, "api", failure), failure)
+	assert.Equal(t, int64(1), mgr.GetMetrics("api").Failures)
+}
+
+func TestBulkheadConfig_Validate(t *testing.T) {
+	cfg := BulkheadConfig{}
+	assert.NoError(t, cfg.Validate())
+
+	cfg = BulkheadConfig{Mode: "threads", MaxConcurrent: 1}
+	assert.Error(t, cfg.Validate())
+
+	cfg = BulkheadConfig{Mode: BulkheadSemaphore}
+	assert.Error(t, cfg.Validate())
+
+	cfg = BulkheadConfig{Mode: BulkheadPool, MaxConcurrent: 1, QueueSize: -1}
+	assert.Error(t, cfg.Validate())
+
+	// Resource bulkheads override the default one
+	merged := ResourceConfig{Bulkhead: BulkheadConfig{Mode: BulkheadSemaphore, MaxConcurrent: 10}}.
+		Merge(ResourceConfig{Bulkhead: BulkheadConfig{Mode: BulkheadPool, MaxConcurrent: 2}})
+	assert.Equal(t, BulkheadConfig{Mode: BulkheadPool, MaxConcurrent: 2}, merged.Bulkhead)
+}
@@ -0,0 +1,189 @@
+package breaker
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/KOMKZ/go-yogan-framework/errcode"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorsConfig decides which errors count as failures of a resource
+//
+// Errors matching an ignore list never count (the downstream answered, e.g., 404 or a validation error),
+// they are recorded as successes. When record lists are set, only the classified errors matching them
+// count as failures. Errors without a code, gRPC status or HTTP status (network errors) always count.
+type ErrorsConfig struct {
+	// IgnoreCodes errcode codes that never count as failures
+	IgnoreCodes []int `mapstructure:"ignore_codes"`
+
+	// RecordCodes errcode codes that count as failures
+	RecordCodes []int `mapstructure:"record_codes"`
+
+	// IgnoreGRPCCodes gRPC codes that never count as failures (e.g., NotFound, INVALID_ARGUMENT)
+	IgnoreGRPCCodes []string `mapstructure:"ignore_grpc_codes"`
+
+	// RecordGRPCCodes gRPC codes that count as failures (e.g., Unavailable, DeadlineExceeded)
+	RecordGRPCCodes []string `mapstructure:"record_grpc_codes"`
+
+	// IgnoreHTTPStatus HTTP statuses that never count as failures (e.g., 404, 4xx)
+	IgnoreHTTPStatus []string `mapstructure:"ignore_http_status"`
+
+	// RecordHTTPStatus HTTP statuses that count as failures (e.g., 503, 5xx)
+	RecordHTTPStatus []string `mapstructure:"record_http_status"`
+}
+
+// configured whether any list is set
+func (c ErrorsConfig) configured() bool {
+	return len(c.IgnoreCodes) > 0 || len(c.RecordCodes) > 0 ||
+		len(c.IgnoreGRPCCodes) > 0 || len(c.RecordGRPCCodes) > 0 ||
+		len(c.IgnoreHTTPStatus) > 0 || len(c.RecordHTTPStatus) > 0
+}
+
+// Validate error classification configuration
+func (c *ErrorsConfig) Validate() error {
+	_, err := newErrorClassifier(*c)
+	return err
+}
+
+// codedError errors carrying an application error code (errcode.LayeredError)
+type codedError interface {
+	Code() int
+}
+
+// httpStatusError errors carrying an HTTP status (errcode.LayeredError, httpclient errors)
+type httpStatusError interface {
+	HTTPStatus() int
+}
+
+// errorMatcher the ignore or record lists
+type errorMatcher struct {
+	codes       map[int]bool
+	grpcCodes   map[codes.Code]bool
+	httpStatus  map[int]bool
+	httpClasses map[int]bool // 4 for 4xx
+}
+
+// empty whether no list is set
+func (m errorMatcher) empty() bool {
+	return len(m.codes) == 0 && len(m.grpcCodes) == 0 && len(m.httpStatus) == 0 && len(m.httpClasses) == 0
+}
+
+// errorClassifier compiled ErrorsConfig
+type errorClassifier struct {
+	ignore errorMatcher
+	record errorMatcher
+}
+
+// newErrorClassifier compiles the classification lists
+func newErrorClassifier(cfg ErrorsConfig) (*errorClassifier, error) {
+	ignore, err := newErrorMatcher(cfg.IgnoreCodes, cfg.IgnoreGRPCCodes, cfg.IgnoreHTTPStatus, "Errors.Ignore")
+	if err != nil {
+		return nil, err
+	}
+	record, err := newErrorMatcher(cfg.RecordCodes, cfg.RecordGRPCCodes, cfg.RecordHTTPStatus, "Errors.Record")
+	if err != nil {
+		return nil, err
+	}
+	return &errorClassifier{ignore: ignore, record: record}, nil
+}
+
+// newErrorMatcher parses one side of the lists
+func newErrorMatcher(errCodes []int, grpcCodes []string, httpStatus []string, field string) (errorMatcher, error) {
+	m := errorMatcher{
+		codes:       make(map[int]bool),
+		grpcCodes:   make(map[codes.Code]bool),
+		httpStatus:  make(map[int]bool),
+		httpClasses: make(map[int]bool),
+	}
+	for _, code := range errCodes {
+		m.codes[code] = true
+	}
+	for _, name := range grpcCodes {
+		code, ok := errcode.ParseGRPCCode(name)
+		if !ok {
+			return m, &ValidationError{Field: field + "GRPCCodes", Message: "unknown gRPC code " + strconv.Quote(name)}
+		}
+		m.grpcCodes[code] = true
+	}
+	for _, value := range httpStatus {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if len(value) == 3 && strings.HasSuffix(value, "xx") && value[0] >= '1' && value[0] <= '5' {
+			m.httpClasses[int(value[0]-'0')] = true
+			continue
+		}
+		statusCode, err := strconv.Atoi(value)
+		if err != nil || statusCode < 100 || statusCode > 599 {
+			return m, &ValidationError{Field: field + "HTTPStatus", Message: "invalid HTTP status " + strconv.Quote(value)}
+		}
+		m.httpStatus[statusCode] = true
+	}
+	return m, nil
+}
+
+// errorAttributes the classifiable attributes of an error
+type errorAttributes struct {
+	code       int
+	hasCode    bool
+	grpcCode   codes.Code
+	hasGRPC    bool
+	httpStatus int
+	hasHTTP    bool
+}
+
+// classified whether the error carries any attribute
+func (a errorAttributes) classified() bool {
+	return a.hasCode || a.hasGRPC || a.hasHTTP
+}
+
+// attributesOf extracts the error code, gRPC code and HTTP status of an error chain
+func attributesOf(err error) errorAttributes {
+	var attrs errorAttributes
+
+	var coded codedError
+	if errors.As(err, &coded) {
+		attrs.code, attrs.hasCode = coded.Code(), true
+	}
+	if st, ok := status.FromError(err); ok && st.Code() != codes.Unknown {
+		attrs.grpcCode, attrs.hasGRPC = st.Code(), true
+	}
+	var withStatus httpStatusError
+	if errors.As(err, &withStatus) && withStatus.HTTPStatus() > 0 {
+		attrs.httpStatus, attrs.hasHTTP = withStatus.HTTPStatus(), true
+	}
+	return attrs
+}
+
+// matches whether the attributes match any list
+func (m errorMatcher) matches(attrs errorAttributes) bool {
+	if attrs.hasCode && m.codes[attrs.code] {
+		return true
+	}
+	if attrs.hasGRPC && m.grpcCodes[attrs.grpcCode] {
+		return true
+	}
+	if attrs.hasHTTP && (m.httpStatus[attrs.httpStatus] || m.httpClasses[attrs.httpStatus/100]) {
+		return true
+	}
+	return false
+}
+
+// IsFailure whether the error counts as a failure of the resource
+func (c *errorClassifier) IsFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	attrs := attributesOf(err)
+	if !attrs.classified() {
+		return true
+	}
+	if c.ignore.matches(attrs) {
+		return false
+	}
+	if c.record.empty() {
+		return true
+	}
+	return c.record.matches(attrs)
+}
@@ -0,0 +1,109 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/KOMKZ/go-yogan-framework/errcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// httpError test error carrying an HTTP status
+type httpError int
+
+func (e httpError) Error() string   { return fmt.Sprintf("HTTP %d", int(e)) }
+func (e httpError) HTTPStatus() int { return int(e) }
+
+func TestErrorClassifier_IgnoreLists(t *testing.T) {
+	classifier, err := newErrorClassifier(ErrorsConfig{
+		IgnoreCodes:      []int{10001},
+		IgnoreGRPCCodes:  []string{"NotFound", "INVALID_ARGUMENT"},
+		IgnoreHTTPStatus: []string{"4xx"},
+	})
+	require.NoError(t, err)
+
+	notFound := errcode.New(1, 1, "user", "error.user.not_found", "user not found")
+	assert.False(t, classifier.IsFailure(notFound))
+	assert.False(t, classifier.IsFailure(fmt.Errorf("load user: %w", notFound)))
+	assert.True(t, classifier.IsFailure(errcode.New(1, 2, "user", "error.user.db", "db error")))
+
+	assert.False(t, classifier.IsFailure(status.Error(codes.NotFound, "missing")))
+	assert.False(t, classifier.IsFailure(status.Error(codes.InvalidArgument, "bad")))
+	assert.True(t, classifier.IsFailure(status.Error(codes.Unavailable, "down")))
+
+	assert.False(t, classifier.IsFailure(httpError(404)))
+	assert.True(t, classifier.IsFailure(httpError(503)))
+
+	// Unclassified errors always count
+	assert.True(t, classifier.IsFailure(errors.New("connection refused")))
+}
+
+func TestErrorClassifier_RecordLists(t *testing.T) {
+	classifier, err := newErrorClassifier(ErrorsConfig{
+		RecordGRPCCodes:  []string{"Unavailable", "4"},
+		RecordHTTPStatus: []string{"5xx", "429"},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, classifier.IsFailure(status.Error(codes.Unavailable, "down")))
+	assert.True(t, classifier.IsFailure(status.Error(codes.DeadlineExceeded, "slow")))
+	assert.False(t, classifier.IsFailure(status.Error(codes.PermissionDenied, "denied")))
+
+	assert.True(t, classifier.IsFailure(httpError(502)))
+	assert.True(t, classifier.IsFailure(httpError(429)))
+	assert.False(t, classifier.IsFailure(httpError(400)))
+
+	assert.True(t, classifier.IsFailure(errors.New("connection reset")))
+}
+
+func TestErrorsConfig_Validate(t *testing.T) {
+	cfg := ErrorsConfig{IgnoreGRPCCodes: []string{"NoSuchCode"}}
+	assert.Error(t, cfg.Validate())
+
+	cfg = ErrorsConfig{RecordHTTPStatus: []string{"6xx"}}
+	assert.Error(t, cfg.Validate())
+
+	cfg = ErrorsConfig{RecordHTTPStatus: []string{"abc"}}
+	assert.Error(t, cfg.Validate())
+}
+
+func TestManager_IgnoredErrorsDoNotOpen(t *testing.T) {
+	config := DefaultConfig()
+	config.Enabled = true
+	config.Default.MinRequests = 2
+	config.Default.Errors = ErrorsConfig{IgnoreGRPCCodes: []string{"NotFound"}}
+	mgr, err := NewManager(config)
+	require.NoError(t, err)
+	defer mgr.Close()
+
+	notFound := status.Error(codes.NotFound, "missing")
+	for i := 0; i < 10; i++ {
+		// The error is still returned to the caller
+		assert.ErrorIs(t, call(mgr, "api", notFound), notFound)
+	}
+	assert.Equal(t, StateClosed, mgr.GetState("api"))
+	assert.Equal(t, int64(10), mgr.GetMetrics("api").Successes)
+
+	for i := 0; i < 10; i++ {
+		_ = call(mgr, "api", status.Error(codes.Unavailable, "down"))
+	}
+	assert.Equal(t, StateOpen, mgr.GetState("api"))
+}
+
+func TestManager_TimeoutsAlwaysCount(t *testing.T) {
+	config := DefaultConfig()
+	config.Enabled = true
+	config.Default.MinRequests = 1
+	config.Default.Errors = ErrorsConfig{RecordHTTPStatus: []string{"5xx"}}
+	mgr, err := NewManager(config)
+	require.NoError(t, err)
+	defer mgr.Close()
+
+	_ = call(mgr, "api", context.DeadlineExceeded)
+	assert.Equal(t, int64(1), mgr.GetMetrics("api").Timeouts)
+}
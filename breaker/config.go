@@ -17,6 +17,19 @@ type Config struct {
 
 	// Resources Configuration at the resource level (overrides Default)
 	Resources map[string]ResourceConfig `mapstructure:"resources"`
+
+	// StateStore state backend: local (default, state per process) or redis (state shared by instances)
+	StateStore string `mapstructure:"state_store"`
+
+	// Redis shared state configuration (when StateStore is redis)
+	Redis RedisStateConfig `mapstructure:"redis"`
+}
+
+// RedisStateConfig shared state configuration (reusing kernel redis component)
+type RedisStateConfig struct {
+	Instance       string `mapstructure:"instance"`        // Redis instance name (configured in redis.instances)
+	KeyPrefix      string `mapstructure:"key_prefix"`      // Redis key prefix (default "breaker:")
+	ProbeInstances int    `mapstructure:"probe_instances"` // instances probing a half-open resource at once (default 1)
 }
 
 // ResourceConfig resource-level configuration
@@ -45,13 +58,44 @@ type ResourceConfig struct {
 	// HalfOpenRequests Number of requests allowed in half-open state
 	HalfOpenRequests int `mapstructure:"half_open_requests"`
 
+	// WindowType sliding window type: time (default, last WindowSize) or count (last WindowCount calls)
+	WindowType string `mapstructure:"window_type"`
+
+	// WindowCount number of calls of a count window
+	WindowCount int `mapstructure:"window_count"`
+
 	// Window size of sliding window
 	WindowSize time.Duration `mapstructure:"window_size"`
 
 	// BucketSize bucket size
 	BucketSize time.Duration `mapstructure:"bucket_size"`
+
+	// Bulkhead isolation of concurrent calls (optional)
+	Bulkhead BulkheadConfig `mapstructure:"bulkhead"`
+
+	// Errors decides which errors count as failures (default: every error)
+	Errors ErrorsConfig `mapstructure:"errors"`
+
+	// RampSteps traffic percentages allowed step by step after the half-open probes succeed (e.g., [10, 25, 50, 100])
+	// Empty closes the circuit as soon as the probes succeed
+	RampSteps []int `mapstructure:"ramp_steps"`
+
+	// RampInterval minimum duration of a ramp step
+	RampInterval time.Duration `mapstructure:"ramp_interval"`
+
+	// ForcedState operator state applied when the breaker is created: forced_open, forced_closed, disabled
+	ForcedState string `mapstructure:"forced_state"`
 }
 
+// Window types
+const (
+	// WindowTypeTime metrics over the last WindowSize
+	WindowTypeTime = "time"
+
+	// WindowTypeCount metrics over the last WindowCount calls
+	WindowTypeCount = "count"
+)
+
 // Return default configuration
 func DefaultConfig() Config {
 	return Config{
@@ -73,6 +117,8 @@ func DefaultResourceConfig() ResourceConfig {
 		ConsecutiveFailures: 5,
 		Timeout:             30 * time.Second,
 		HalfOpenRequests:    3,
+		WindowType:          WindowTypeTime,
+		WindowCount:         100,
 		WindowSize:          10 * time.Second,
 		BucketSize:          time.Second,
 	}
@@ -88,6 +134,23 @@ func (c *Config) Validate() error {
 		c.EventBusBuffer = 500
 	}
 
+	// Validate state backend
+	switch c.StateStore {
+	case "", "local":
+	case "redis":
+		if c.Redis.Instance == "" {
+			return &ValidationError{Field: "redis.instance", Message: "redis instance name is required"}
+		}
+		if c.Redis.KeyPrefix == "" {
+			c.Redis.KeyPrefix = "breaker:"
+		}
+		if c.Redis.ProbeInstances <= 0 {
+			c.Redis.ProbeInstances = 1
+		}
+	default:
+		return &ValidationError{Field: "state_store", Message: "must be 'local' or 'redis'"}
+	}
+
 	// Verify default configuration
 	if err := c.Default.Validate(); err != nil {
 		return err
@@ -97,14 +160,14 @@ func (c *Config) Validate() error {
 	for name, cfg := range c.Resources {
 		// Merge default configuration (use default values for fields not set in resource configuration)
 		merged := c.Default.Merge(cfg)
-		c.Resources[name] = merged
-
 		if err := merged.Validate(); err != nil {
 			return &ValidationError{
 				Resource: name,
 				Err:      err,
 			}
 		}
+		// Store after validation, which fills defaults
+		c.Resources[name] = merged
 	}
 
 	return nil
@@ -145,6 +208,27 @@ func (rc ResourceConfig) Merge(override ResourceConfig) ResourceConfig {
 	if override.BucketSize > 0 {
 		result.BucketSize = override.BucketSize
 	}
+	if override.WindowType != "" {
+		result.WindowType = override.WindowType
+	}
+	if override.WindowCount > 0 {
+		result.WindowCount = override.WindowCount
+	}
+	if override.Bulkhead.Enabled() {
+		result.Bulkhead = override.Bulkhead
+	}
+	if override.Errors.configured() {
+		result.Errors = override.Errors
+	}
+	if len(override.RampSteps) > 0 {
+		result.RampSteps = override.RampSteps
+	}
+	if override.RampInterval > 0 {
+		result.RampInterval = override.RampInterval
+	}
+	if override.ForcedState != "" {
+		result.ForcedState = override.ForcedState
+	}
 
 	return result
 }
@@ -187,6 +271,42 @@ func (rc *ResourceConfig) Validate() error {
 		return &ValidationError{Field: "WindowSize", Message: "must be >= BucketSize"}
 	}
 
+	switch rc.WindowType {
+	case "", WindowTypeTime:
+	case WindowTypeCount:
+		if rc.WindowCount <= 0 {
+			return &ValidationError{Field: "WindowCount", Message: "must be > 0"}
+		}
+	default:
+		return &ValidationError{Field: "WindowType", Message: "must be 'time' or 'count'"}
+	}
+
+	if err := rc.Bulkhead.Validate(); err != nil {
+		return err
+	}
+
+	if err := rc.Errors.Validate(); err != nil {
+		return err
+	}
+
+	for i, step := range rc.RampSteps {
+		if step <= 0 || step > 100 {
+			return &ValidationError{Field: "RampSteps", Message: "must be between 1 and 100"}
+		}
+		if i > 0 && step <= rc.RampSteps[i-1] {
+			return &ValidationError{Field: "RampSteps", Message: "must be increasing"}
+		}
+	}
+	if len(rc.RampSteps) > 0 && rc.RampInterval <= 0 {
+		rc.RampInterval = 10 * time.Second
+	}
+
+	if rc.ForcedState != "" {
+		if state, ok := ParseState(rc.ForcedState); !ok || !state.IsForced() {
+			return &ValidationError{Field: "ForcedState", Message: "must be 'forced_open', 'forced_closed' or 'disabled'"}
+		}
+	}
+
 	return nil
 }
 
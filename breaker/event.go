@@ -43,6 +43,9 @@ const (
 
 	// EventThresholdExceeded Threshold Exceeded
 	EventThresholdExceeded EventType = "threshold_exceeded"
+
+	// EventBulkheadRejected Request rejected (bulkhead full)
+	EventBulkheadRejected EventType = "bulkhead_rejected"
 )
 
 // EventBus event bus interface
@@ -128,3 +131,9 @@ type FallbackEvent struct {
 	Duration time.Duration
 	Error    error
 }
+
+// BulkheadEvent bulkhead event (rejection)
+type BulkheadEvent struct {
+	BaseEvent
+	Stats BulkheadStats
+}
@@ -0,0 +1,165 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// newForcedManager creates a manager opening after 1 failure
+func newForcedManager(t *testing.T) *Manager {
+	config := DefaultConfig()
+	config.Enabled = true
+	config.Default.Strategy = "consecutive_failures"
+	config.Default.ConsecutiveFailures = 1
+	config.Default.Timeout = time.Millisecond
+
+	mgr, err := NewManager(config)
+	require.NoError(t, err)
+	t.Cleanup(mgr.Close)
+	return mgr
+}
+
+func TestManager_ForcedOpen(t *testing.T) {
+	mgr := newForcedManager(t)
+
+	changes := make(chan *StateChangedEvent, 4)
+	mgr.GetEventBus().Subscribe(EventListenerFunc(func(event Event) {
+		changes <- event.(*StateChangedEvent)
+	}), EventStateChanged)
+
+	require.NoError(t, mgr.ForceState("api", StateForcedOpen))
+	select {
+	case event := <-changes:
+		assert.Equal(t, StateClosed, event.FromState)
+		assert.Equal(t, StateForcedOpen, event.ToState)
+		assert.Equal(t, "forced by operator", event.Reason)
+	case <-time.After(time.Second):
+		t.Fatal("state change event not published")
+	}
+
+	err := call(mgr, "api", nil)
+	assert.ErrorIs(t, err, ErrForcedOpen)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	// The open timeout does not move a forced resource to half-open
+	time.Sleep(5 * time.Millisecond)
+	assert.ErrorIs(t, call(mgr, "api", nil), ErrForcedOpen)
+	assert.Equal(t, StateForcedOpen, mgr.GetState("api"))
+
+	// Reset keeps the forced state, clearing closes the resource
+	require.NoError(t, mgr.Reset("api"))
+	assert.Equal(t, StateForcedOpen, mgr.GetState("api"))
+	require.NoError(t, mgr.ClearForcedState("api"))
+	assert.Equal(t, StateClosed, mgr.GetState("api"))
+	assert.NoError(t, call(mgr, "api", nil))
+}
+
+func TestManager_ForcedClosed(t *testing.T) {
+	mgr := newForcedManager(t)
+	require.NoError(t, mgr.ForceState("api", StateForcedClosed))
+
+	failure := errors.New("failed")
+	for i := 0; i < 5; i++ {
+		assert.ErrorIs(t, call(mgr, "api", failure), failure)
+	}
+	assert.Equal(t, StateForcedClosed, mgr.GetState("api"))
+	assert.Equal(t, int64(5), mgr.GetMetrics("api").Failures)
+
+	// Cleared with a clean window
+	require.NoError(t, mgr.ClearForcedState("api"))
+	assert.Equal(t, int64(0), mgr.GetMetrics("api").Failures)
+	_ = call(mgr, "api", failure)
+	assert.Equal(t, StateOpen, mgr.GetState("api"))
+}
+
+func TestManager_Disabled(t *testing.T) {
+	mgr := newForcedManager(t)
+
+	_ = call(mgr, "api", errors.New("failed"))
+	require.Equal(t, StateOpen, mgr.GetState("api"))
+
+	// Disabling an open breaker lets calls through unrecorded
+	require.NoError(t, mgr.ForceState("api", StateDisabled))
+	failure := errors.New("failed")
+	assert.ErrorIs(t, call(mgr, "api", failure), failure)
+	assert.NoError(t, call(mgr, "api", nil))
+	assert.Equal(t, int64(1), mgr.GetMetrics("api").TotalRequests)
+	assert.Equal(t, StateDisabled, mgr.GetState("api"))
+}
+
+func TestManager_ForceStateInvalid(t *testing.T) {
+	mgr := newForcedManager(t)
+	assert.ErrorIs(t, mgr.ForceState("api", StateOpen), ErrInvalidForcedState)
+
+	disabled, err := NewManager(DefaultConfig())
+	require.NoError(t, err)
+	assert.ErrorIs(t, disabled.ForceState("api", StateForcedOpen), ErrNotEnabled)
+}
+
+func TestManager_ForcedStateFromConfig(t *testing.T) {
+	config := DefaultConfig()
+	config.Enabled = true
+	config.Resources["payment"] = ResourceConfig{ForcedState: "forced_open"}
+	mgr, err := NewManager(config)
+	require.NoError(t, err)
+	defer mgr.Close()
+
+	assert.Equal(t, map[string]State{"payment": StateForcedOpen}, mgr.States())
+	assert.ErrorIs(t, call(mgr, "payment", nil), ErrForcedOpen)
+
+	config.Resources["payment"] = ResourceConfig{ForcedState: "open"}
+	_, err = NewManager(config)
+	assert.Error(t, err)
+}
+
+func TestManager_StateGauge(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test")
+
+	mgr := newForcedManager(t)
+	_ = call(mgr, "before", errors.New("failed"))
+
+	metrics := NewOTelBreakerMetrics(BreakerMetricsConfig{Enabled: true, RecordState: true})
+	require.NoError(t, metrics.RegisterMetrics(meter))
+	mgr.SetMetrics(metrics)
+	require.NoError(t, mgr.ForceState("after", StateDisabled))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	states := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "breaker_state" {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Gauge[int64]).DataPoints {
+				resource, _ := dp.Attributes.Value("resource")
+				states[resource.AsString()] = dp.Value
+			}
+		}
+	}
+	assert.Equal(t, map[string]int64{"before": int64(StateOpen), "after": int64(StateDisabled)}, states)
+}
+
+func TestParseState(t *testing.T) {
+	for name, expected := range map[string]State{
+		"closed":       StateClosed,
+		"half_open":    StateHalfOpen,
+		"forced_open":  StateForcedOpen,
+		"ForcedClosed": StateForcedClosed,
+		" disabled ":   StateDisabled,
+	} {
+		state, ok := ParseState(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, expected, state, name)
+	}
+	_, ok := ParseState("broken")
+	assert.False(t, ok)
+}
@@ -35,6 +35,7 @@ type MetricsCollector interface {
 type MetricsSnapshot struct {
 	Resource      string
 	State         State
+	RampPercent   int // traffic percentage allowed by the half-open ramp (0 when not ramping)
 	WindowStart   time.Time
 	WindowEnd     time.Time
 	
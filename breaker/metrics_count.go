@@ -0,0 +1,196 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+// outcome kinds of a recorded call
+const (
+	outcomeSuccess = iota
+	outcomeFailure
+	outcomeTimeout
+)
+
+// callOutcome a call recorded in a count window
+type callOutcome struct {
+	kind     int
+	latency  time.Duration
+	errorKey string
+	at       time.Time
+}
+
+// countWindowMetrics metrics over the last WindowCount calls (independent of traffic rate)
+type countWindowMetrics struct {
+	resource string
+	config   ResourceConfig
+	stateMgr *stateManager
+
+	// ring of the last calls
+	calls      []callOutcome
+	next       int
+	filled     bool
+	rejections int64
+
+	// Observer
+	observers  map[ObserverID]MetricsObserver
+	observerMu sync.RWMutex
+
+	mu sync.RWMutex
+}
+
+// create count window metrics collector
+func newCountWindowMetrics(resource string, config ResourceConfig, stateMgr *stateManager) *countWindowMetrics {
+	return &countWindowMetrics{
+		resource:  resource,
+		config:    config,
+		stateMgr:  stateMgr,
+		calls:     make([]callOutcome, config.WindowCount),
+		observers: make(map[ObserverID]MetricsObserver),
+	}
+}
+
+// newMetricsCollector creates the metrics collector of the configured window type
+func newMetricsCollector(resource string, config ResourceConfig, stateMgr *stateManager) MetricsCollector {
+	if config.WindowType == WindowTypeCount {
+		return newCountWindowMetrics(resource, config, stateMgr)
+	}
+	return newSlidingWindowMetrics(resource, config, stateMgr)
+}
+
+// RecordSuccess Recording successful
+func (m *countWindowMetrics) RecordSuccess(duration time.Duration) {
+	m.record(callOutcome{kind: outcomeSuccess, latency: duration})
+}
+
+// RecordFailure log failure
+func (m *countWindowMetrics) RecordFailure(duration time.Duration, err error) {
+	outcome := callOutcome{kind: outcomeFailure, latency: duration}
+	if err != nil {
+		outcome.errorKey = err.Error()
+	}
+	m.record(outcome)
+}
+
+// RecordTimeout record timeout
+func (m *countWindowMetrics) RecordTimeout(duration time.Duration) {
+	m.record(callOutcome{kind: outcomeTimeout, latency: duration})
+}
+
+// RecordRejection record rejection (rejections are not calls, they do not move the window)
+func (m *countWindowMetrics) RecordRejection() {
+	m.mu.Lock()
+	m.rejections++
+	m.mu.Unlock()
+
+	m.notifyObservers()
+}
+
+// record appends a call, replacing the oldest one when the window is full
+func (m *countWindowMetrics) record(outcome callOutcome) {
+	outcome.at = time.Now()
+
+	m.mu.Lock()
+	m.calls[m.next] = outcome
+	m.next = (m.next + 1) % len(m.calls)
+	if m.next == 0 {
+		m.filled = true
+	}
+	m.mu.Unlock()
+
+	m.notifyObservers()
+}
+
+// GetSnapshot Get current snapshot
+func (m *countWindowMetrics) GetSnapshot() *MetricsSnapshot {
+	m.mu.RLock()
+	count := m.next
+	if m.filled {
+		count = len(m.calls)
+	}
+
+	var successes, failures, timeouts int64
+	latencies := make([]time.Duration, 0, count)
+	errorTypes := make(map[string]int64)
+	for i := 0; i < count; i++ {
+		outcome := m.calls[i]
+		switch outcome.kind {
+		case outcomeSuccess:
+			successes++
+		case outcomeFailure:
+			failures++
+			if outcome.errorKey != "" {
+				errorTypes[outcome.errorKey]++
+			}
+		case outcomeTimeout:
+			timeouts++
+		}
+		latencies = append(latencies, outcome.latency)
+	}
+	rejections := m.rejections
+
+	// The oldest call starts the window
+	windowStart := time.Now()
+	if m.filled {
+		windowStart = m.calls[m.next].at
+	} else if count > 0 {
+		windowStart = m.calls[0].at
+	}
+	m.mu.RUnlock()
+
+	snapshot := buildSnapshot(m.config, successes, failures, timeouts, rejections, latencies, errorTypes)
+	snapshot.Resource = m.resource
+	snapshot.State = m.stateMgr.GetState()
+	snapshot.RampPercent = m.stateMgr.RampPercent(m.config)
+	snapshot.WindowStart = windowStart
+	snapshot.WindowEnd = time.Now()
+	return snapshot
+}
+
+// Subscribe to real-time metrics
+func (m *countWindowMetrics) Subscribe(observer MetricsObserver) ObserverID {
+	m.observerMu.Lock()
+	defer m.observerMu.Unlock()
+
+	id := ObserverID(time.Now().Format("20060102150405.000000"))
+	m.observers[id] = observer
+	return id
+}
+
+// Unsubscribe from subscription
+func (m *countWindowMetrics) Unsubscribe(id ObserverID) {
+	m.observerMu.Lock()
+	defer m.observerMu.Unlock()
+
+	delete(m.observers, id)
+}
+
+// Reset metrics
+func (m *countWindowMetrics) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = make([]callOutcome, len(m.calls))
+	m.next = 0
+	m.filled = false
+	m.rejections = 0
+}
+
+// notifyObservers notifies all observers
+func (m *countWindowMetrics) notifyObservers() {
+	m.observerMu.RLock()
+	observers := make([]MetricsObserver, 0, len(m.observers))
+	for _, obs := range m.observers {
+		observers = append(observers, obs)
+	}
+	m.observerMu.RUnlock()
+
+	if len(observers) == 0 {
+		return
+	}
+
+	snapshot := m.GetSnapshot()
+	for _, obs := range observers {
+		go obs.OnMetricsUpdated(snapshot)
+	}
+}
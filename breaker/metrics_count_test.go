@@ -0,0 +1,73 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountWindowMetrics_KeepsLastCalls(t *testing.T) {
+	config := DefaultResourceConfig()
+	config.WindowType = WindowTypeCount
+	config.WindowCount = 4
+	m := newCountWindowMetrics("api", config, newStateManager())
+
+	for i := 0; i < 4; i++ {
+		m.RecordFailure(time.Millisecond, errors.New("failed"))
+	}
+	snapshot := m.GetSnapshot()
+	assert.Equal(t, int64(4), snapshot.TotalRequests)
+	assert.Equal(t, 1.0, snapshot.ErrorRate)
+
+	// Newer calls replace the oldest ones, however old they are
+	m.RecordSuccess(time.Millisecond)
+	m.RecordSuccess(time.Millisecond)
+	m.RecordTimeout(time.Second)
+	m.RecordRejection()
+	snapshot = m.GetSnapshot()
+	assert.Equal(t, int64(4), snapshot.TotalRequests)
+	assert.Equal(t, int64(2), snapshot.Successes)
+	assert.Equal(t, int64(1), snapshot.Failures)
+	assert.Equal(t, int64(1), snapshot.Timeouts)
+	assert.Equal(t, int64(1), snapshot.Rejections)
+	assert.Equal(t, time.Second, snapshot.MaxLatency)
+
+	m.Reset()
+	assert.Equal(t, int64(0), m.GetSnapshot().TotalRequests)
+}
+
+func TestManager_CountWindow(t *testing.T) {
+	config := DefaultConfig()
+	config.Enabled = true
+	config.Default.MinRequests = 10
+	config.Default.WindowType = WindowTypeCount
+	config.Default.WindowCount = 10
+	mgr, err := NewManager(config)
+	require.NoError(t, err)
+	defer mgr.Close()
+
+	for i := 0; i < 20; i++ {
+		require.NoError(t, call(mgr, "api", nil))
+	}
+	// 5 failures in the last 10 calls reach the 50% threshold
+	failure := errors.New("failed")
+	for i := 0; i < 4; i++ {
+		_ = call(mgr, "api", failure)
+	}
+	assert.Equal(t, StateClosed, mgr.GetState("api"))
+	_ = call(mgr, "api", failure)
+	assert.Equal(t, StateOpen, mgr.GetState("api"))
+}
+
+func TestResourceConfig_ValidateWindowType(t *testing.T) {
+	config := DefaultResourceConfig()
+	config.WindowType = WindowTypeCount
+	config.WindowCount = 0
+	assert.Error(t, config.Validate())
+
+	config.WindowType = "sessions"
+	assert.Error(t, config.Validate())
+}
@@ -132,7 +132,6 @@ func (m *slidingWindowMetrics) GetSnapshot() *MetricsSnapshot {
 	defer m.mu.RUnlock()
 	
 	var (
-		totalRequests int64
 		successes     int64
 		failures      int64
 		timeouts      int64
@@ -159,70 +158,13 @@ func (m *slidingWindowMetrics) GetSnapshot() *MetricsSnapshot {
 		bucket.mu.RUnlock()
 	}
 	
-	totalRequests = successes + failures + timeouts
-	
-	// Calculate percentage
-	var successRate, errorRate, timeoutRate float64
-	if totalRequests > 0 {
-		successRate = float64(successes) / float64(totalRequests)
-		errorRate = float64(failures) / float64(totalRequests)
-		timeoutRate = float64(timeouts) / float64(totalRequests)
-	}
-	
-	// Calculate latency statistics
-	var avgLatency, p50, p95, p99, maxLatency time.Duration
-	var slowCalls int64
-	var slowCallRate float64
-	
-	if len(allLatencies) > 0 {
-		sort.Slice(allLatencies, func(i, j int) bool {
-			return allLatencies[i] < allLatencies[j]
-		})
-		
-		// average latency
-		var total time.Duration
-		for _, lat := range allLatencies {
-			total += lat
-			if lat >= m.config.SlowCallThreshold {
-				slowCalls++
-			}
-		}
-		avgLatency = total / time.Duration(len(allLatencies))
-		
-		// percentile
-		p50 = allLatencies[len(allLatencies)*50/100]
-		p95 = allLatencies[len(allLatencies)*95/100]
-		p99 = allLatencies[len(allLatencies)*99/100]
-		maxLatency = allLatencies[len(allLatencies)-1]
-		
-		// low call rate
-		if totalRequests > 0 {
-			slowCallRate = float64(slowCalls) / float64(totalRequests)
-		}
-	}
-	
-	return &MetricsSnapshot{
-		Resource:      m.resource,
-		State:         m.stateMgr.GetState(),
-		WindowStart:   windowStart,
-		WindowEnd:     windowEnd,
-		TotalRequests: totalRequests,
-		Successes:     successes,
-		Failures:      failures,
-		Timeouts:      timeouts,
-		Rejections:    rejections,
-		SuccessRate:   successRate,
-		ErrorRate:     errorRate,
-		TimeoutRate:   timeoutRate,
-		AvgLatency:    avgLatency,
-		P50Latency:    p50,
-		P95Latency:    p95,
-		P99Latency:    p99,
-		MaxLatency:    maxLatency,
-		SlowCalls:     slowCalls,
-		SlowCallRate:  slowCallRate,
-		ErrorTypes:    errorTypes,
-	}
+	snapshot := buildSnapshot(m.config, successes, failures, timeouts, rejections, allLatencies, errorTypes)
+	snapshot.Resource = m.resource
+	snapshot.State = m.stateMgr.GetState()
+	snapshot.RampPercent = m.stateMgr.RampPercent(m.config)
+	snapshot.WindowStart = windowStart
+	snapshot.WindowEnd = windowEnd
+	return snapshot
 }
 
 // Subscribe to real-time metrics
@@ -310,3 +252,66 @@ func (m *slidingWindowMetrics) notifyObservers() {
 	}
 }
 
+// buildSnapshot computes rates and latency statistics of the recorded calls
+func buildSnapshot(config ResourceConfig, successes, failures, timeouts, rejections int64, allLatencies []time.Duration, errorTypes map[string]int64) *MetricsSnapshot {
+	totalRequests := successes + failures + timeouts
+	
+	// Calculate percentage
+	var successRate, errorRate, timeoutRate float64
+	if totalRequests > 0 {
+		successRate = float64(successes) / float64(totalRequests)
+		errorRate = float64(failures) / float64(totalRequests)
+		timeoutRate = float64(timeouts) / float64(totalRequests)
+	}
+	
+	// Calculate latency statistics
+	var avgLatency, p50, p95, p99, maxLatency time.Duration
+	var slowCalls int64
+	var slowCallRate float64
+	
+	if len(allLatencies) > 0 {
+		sort.Slice(allLatencies, func(i, j int) bool {
+			return allLatencies[i] < allLatencies[j]
+		})
+		
+		// average latency
+		var total time.Duration
+		for _, lat := range allLatencies {
+			total += lat
+			if lat >= config.SlowCallThreshold {
+				slowCalls++
+			}
+		}
+		avgLatency = total / time.Duration(len(allLatencies))
+		
+		// percentile
+		p50 = allLatencies[len(allLatencies)*50/100]
+		p95 = allLatencies[len(allLatencies)*95/100]
+		p99 = allLatencies[len(allLatencies)*99/100]
+		maxLatency = allLatencies[len(allLatencies)-1]
+		
+		// low call rate
+		if totalRequests > 0 {
+			slowCallRate = float64(slowCalls) / float64(totalRequests)
+		}
+	}
+	
+	return &MetricsSnapshot{
+		TotalRequests: totalRequests,
+		Successes:     successes,
+		Failures:      failures,
+		Timeouts:      timeouts,
+		Rejections:    rejections,
+		SuccessRate:   successRate,
+		ErrorRate:     errorRate,
+		TimeoutRate:   timeoutRate,
+		AvgLatency:    avgLatency,
+		P50Latency:    p50,
+		P95Latency:    p95,
+		P99Latency:    p99,
+		MaxLatency:    maxLatency,
+		SlowCalls:     slowCalls,
+		SlowCallRate:  slowCallRate,
+		ErrorTypes:    errorTypes,
+	}
+}
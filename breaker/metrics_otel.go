@@ -21,6 +21,7 @@ type OTelBreakerMetrics struct {
 	successesTotal  metric.Int64Counter       // Successful requests
 	failuresTotal   metric.Int64Counter       // Failed requests
 	rejectionsTotal metric.Int64Counter       // Rejected requests
+	bulkheadTotal   metric.Int64Counter       // Requests rejected by bulkheads
 	latency         metric.Float64Histogram   // Request latency
 	stateGauge      metric.Int64ObservableGauge // Current state (0=closed, 1=open, 2=half-open)
 
@@ -106,6 +107,16 @@ func (m *OTelBreakerMetrics) RegisterMetrics(meter metric.Meter) error {
 		return err
 	}
 
+	// Counter: bulkhead rejections
+	m.bulkheadTotal, err = meter.Int64Counter(
+		"breaker_bulkhead_rejections_total",
+		metric.WithDescription("Total number of requests rejected by bulkheads"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		return err
+	}
+
 	// Histogram: latency
 	m.latency, err = meter.Float64Histogram(
 		"breaker_latency_seconds",
@@ -120,7 +131,7 @@ func (m *OTelBreakerMetrics) RegisterMetrics(meter metric.Meter) error {
 	if m.config.RecordState {
 		m.stateGauge, err = meter.Int64ObservableGauge(
 			"breaker_state",
-			metric.WithDescription("Current circuit breaker state (0=closed, 1=open, 2=half-open)"),
+			metric.WithDescription("Current circuit breaker state (0=closed, 1=open, 2=half-open, 3=forced-open, 4=forced-closed, 5=disabled)"),
 			metric.WithInt64Callback(m.collectState),
 		)
 		if err != nil {
@@ -210,6 +221,21 @@ func (m *OTelBreakerMetrics) RecordRejection(ctx context.Context, resource strin
 	m.rejectionsTotal.Add(ctx, 1, metric.WithAttributes(attribute.String("resource", resource)))
 }
 
+// RecordBulkheadRejection records a request rejected by a bulkhead
+func (m *OTelBreakerMetrics) RecordBulkheadRejection(ctx context.Context, resource string) {
+	if !m.registered {
+		return
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("resource", resource),
+		attribute.String("result", "bulkhead_rejected"),
+	}
+
+	m.requestsTotal.Add(ctx, 1, metric.WithAttributes(attrs...))
+	m.bulkheadTotal.Add(ctx, 1, metric.WithAttributes(attribute.String("resource", resource)))
+}
+
 // IsRegistered returns whether metrics have been registered
 func (m *OTelBreakerMetrics) IsRegistered() bool {
 	m.mu.RLock()
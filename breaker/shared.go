@@ -0,0 +1,58 @@
+package breaker
+
+import (
+	"context"
+	"time"
+)
+
+// Shared state transitions
+const (
+	// SharedStateOpen a resource opened on an instance, others open until the same time
+	SharedStateOpen = "open"
+
+	// SharedStateClosed a half-open probe succeeded, the resource closes on every instance
+	SharedStateClosed = "closed"
+
+	// SharedStateReset a manual reset, every instance closes the resource and clears its metrics
+	SharedStateReset = "reset"
+
+	// SharedStateForced an operator forced the resource (Forced holds the state), every instance applies it
+	SharedStateForced = "forced"
+
+	// SharedStateUnforced an operator cleared the forced state, every instance returns the resource to closed
+	SharedStateUnforced = "unforced"
+)
+
+// StateMessage state transition shared between instances
+type StateMessage struct {
+	Resource string    `json:"resource"`
+	State    string    `json:"state"`            // open, closed, reset, forced, unforced
+	Until    time.Time `json:"until,omitempty"`  // end of the open period (open)
+	Forced   string    `json:"forced,omitempty"` // forced state name (forced)
+	Origin   string    `json:"origin"`           // instance that published the transition
+}
+
+// StateBackend shares circuit breaker state between instances (e.g., Redis)
+//
+// Open transitions are published and honored by every instance, so a dead downstream is detected once
+// for the whole cluster. When the open period ends, only the instances holding a probe slot move to
+// half-open, the others stay open until the probes close or reopen the resource. Operator actions
+// (reset, force, clear) are published as well; forced states are only delivered to running instances,
+// new instances start from the configured forced states.
+type StateBackend interface {
+	// Publish records a transition and notifies the other instances
+	Publish(ctx context.Context, msg StateMessage) error
+
+	// Load returns the end of the shared open period of a resource (zero when not open)
+	Load(ctx context.Context, resource string) (time.Time, error)
+
+	// AcquireProbe claims one of the half-open probe slots of a resource for ttl
+	// Returns false when the slots are held by other probes
+	AcquireProbe(ctx context.Context, resource string, slots int, ttl time.Duration) (bool, error)
+
+	// Subscribe delivers the transitions published by other instances until Close
+	Subscribe(handler func(StateMessage)) error
+
+	// Close stops the subscription
+	Close() error
+}
@@ -0,0 +1,159 @@
+package breaker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// acquireProbeScript claims a probe slot: KEYS[1] probe counter, ARGV[1] slots, ARGV[2] ttl (ms)
+var acquireProbeScript = redis.NewScript(`
+local n = redis.call('INCR', KEYS[1])
+if n == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+if n <= tonumber(ARGV[1]) then
+	return 1
+end
+return 0
+`)
+
+// RedisStateBackend shares circuit breaker state through Redis
+//
+// Keys: {prefix}open:{resource} holds the end of the open period (expires with it),
+// {prefix}probe:{resource} counts the half-open probes. Transitions are published on {prefix}events.
+type RedisStateBackend struct {
+	client    *redis.Client
+	keyPrefix string
+	id        string // instance id, own messages are ignored
+
+	mu     sync.Mutex
+	pubsub *redis.PubSub
+	done   chan struct{}
+}
+
+// NewRedisStateBackend creates a Redis state backend
+func NewRedisStateBackend(client *redis.Client, keyPrefix string) *RedisStateBackend {
+	if keyPrefix == "" {
+		keyPrefix = "breaker:"
+	}
+	return &RedisStateBackend{
+		client:    client,
+		keyPrefix: keyPrefix,
+		id:        uuid.NewString(),
+	}
+}
+
+func (b *RedisStateBackend) openKey(resource string) string { return b.keyPrefix + "open:" + resource }
+func (b *RedisStateBackend) probeKey(resource string) string {
+	return b.keyPrefix + "probe:" + resource
+}
+func (b *RedisStateBackend) channel() string { return b.keyPrefix + "events" }
+
+// Publish records a transition and notifies the other instances
+func (b *RedisStateBackend) Publish(ctx context.Context, msg StateMessage) error {
+	msg.Origin = b.id
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshal breaker state failed: %w", err)
+	}
+
+	_, err = b.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if msg.State == SharedStateForced || msg.State == SharedStateUnforced {
+			// Forced states override the shared state locally, the open period and probes are kept
+			pipe.Publish(ctx, b.channel(), payload)
+			return nil
+		}
+
+		// A transition ends the running probes
+		pipe.Del(ctx, b.probeKey(msg.Resource))
+		if ttl := time.Until(msg.Until); msg.State == SharedStateOpen && ttl > 0 {
+			pipe.Set(ctx, b.openKey(msg.Resource), msg.Until.UnixMilli(), ttl)
+		} else {
+			pipe.Del(ctx, b.openKey(msg.Resource))
+		}
+		pipe.Publish(ctx, b.channel(), payload)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("publish breaker state failed: %w", err)
+	}
+	return nil
+}
+
+// Load returns the end of the shared open period of a resource (zero when not open)
+func (b *RedisStateBackend) Load(ctx context.Context, resource string) (time.Time, error) {
+	val, err := b.client.Get(ctx, b.openKey(resource)).Result()
+	if err == redis.Nil {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("load breaker state failed: %w", err)
+	}
+	ms, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid breaker state %q: %w", val, err)
+	}
+	return time.UnixMilli(ms), nil
+}
+
+// AcquireProbe claims one of the half-open probe slots of a resource for ttl
+func (b *RedisStateBackend) AcquireProbe(ctx context.Context, resource string, slots int, ttl time.Duration) (bool, error) {
+	acquired, err := acquireProbeScript.Run(ctx, b.client, []string{b.probeKey(resource)}, slots, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("acquire breaker probe failed: %w", err)
+	}
+	return acquired == 1, nil
+}
+
+// Subscribe delivers the transitions published by other instances until Close
+func (b *RedisStateBackend) Subscribe(handler func(StateMessage)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.pubsub != nil {
+		return fmt.Errorf("breaker state backend already subscribed")
+	}
+
+	ctx := context.Background()
+	pubsub := b.client.Subscribe(ctx, b.channel())
+	// Wait for the subscription, transitions published after Subscribe returns are received
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return fmt.Errorf("subscribe breaker state failed: %w", err)
+	}
+	b.pubsub = pubsub
+	b.done = make(chan struct{})
+
+	go func(messages <-chan *redis.Message, done chan struct{}) {
+		defer close(done)
+		for message := range messages {
+			var msg StateMessage
+			if err := json.Unmarshal([]byte(message.Payload), &msg); err != nil || msg.Origin == b.id {
+				continue
+			}
+			handler(msg)
+		}
+	}(pubsub.Channel(), b.done)
+	return nil
+}
+
+// Close stops the subscription
+func (b *RedisStateBackend) Close() error {
+	b.mu.Lock()
+	pubsub, done := b.pubsub, b.done
+	b.pubsub = nil
+	b.mu.Unlock()
+
+	if pubsub == nil {
+		return nil
+	}
+	err := pubsub.Close()
+	<-done
+	return err
+}
@@ -0,0 +1,241 @@
+package breaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSharedManager creates a manager sharing its state through miniredis (opens after 2 consecutive failures)
+func newSharedManager(t *testing.T, mr *miniredis.Miniredis) *Manager {
+	config := DefaultConfig()
+	config.Enabled = true
+	config.Default.Strategy = "consecutive_failures"
+	config.Default.MinRequests = 1
+	config.Default.ConsecutiveFailures = 2
+	config.Default.Timeout = 200 * time.Millisecond
+	config.Default.HalfOpenRequests = 1
+	config.StateStore = "redis"
+	config.Redis.Instance = "main"
+
+	mgr, err := NewManager(config)
+	require.NoError(t, err)
+	t.Cleanup(mgr.Close)
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	require.NoError(t, mgr.SetStateBackend(NewRedisStateBackend(client, "")))
+	return mgr
+}
+
+// call executes a request on a manager returning the given error
+func call(mgr *Manager, resource string, err error) error {
+	_, execErr := mgr.Execute(context.Background(), &Request{
+		Resource: resource,
+		Execute: func(ctx context.Context) (interface{}, error) {
+			return nil, err
+		},
+	})
+	return execErr
+}
+
+func TestSharedState_OpenPropagates(t *testing.T) {
+	mr := miniredis.RunT(t)
+	a := newSharedManager(t, mr)
+	b := newSharedManager(t, mr)
+
+	// b knows the resource before it opens on a
+	require.NoError(t, call(b, "api", nil))
+
+	failure := errors.New("downstream down")
+	for i := 0; i < 2; i++ {
+		assert.ErrorIs(t, call(a, "api", failure), failure)
+	}
+	assert.Equal(t, StateOpen, a.GetState("api"))
+
+	assert.Eventually(t, func() bool { return b.GetState("api") == StateOpen }, time.Second, 5*time.Millisecond)
+	assert.ErrorIs(t, call(b, "api", nil), ErrCircuitOpen)
+
+	// A new instance loads the open state
+	c := newSharedManager(t, mr)
+	assert.ErrorIs(t, call(c, "api", nil), ErrCircuitOpen)
+}
+
+func TestSharedState_SingleProbe(t *testing.T) {
+	mr := miniredis.RunT(t)
+	a := newSharedManager(t, mr)
+	b := newSharedManager(t, mr)
+
+	require.NoError(t, call(b, "api", nil))
+	failure := errors.New("downstream down")
+	for i := 0; i < 2; i++ {
+		_ = call(a, "api", failure)
+	}
+	require.Eventually(t, func() bool { return b.GetState("api") == StateOpen }, time.Second, 5*time.Millisecond)
+
+	// After the open period only one instance probes
+	time.Sleep(250 * time.Millisecond)
+	block := make(chan struct{})
+	probing := make(chan struct{})
+	go func() {
+		_, _ = a.Execute(context.Background(), &Request{
+			Resource: "api",
+			Execute: func(ctx context.Context) (interface{}, error) {
+				close(probing)
+				<-block
+				return nil, nil
+			},
+		})
+	}()
+	<-probing
+	assert.Equal(t, StateHalfOpen, a.GetState("api"))
+	assert.ErrorIs(t, call(b, "api", nil), ErrCircuitOpen)
+	assert.Equal(t, StateOpen, b.GetState("api"))
+
+	// The successful probe closes the resource everywhere
+	close(block)
+	assert.Eventually(t, func() bool { return a.GetState("api") == StateClosed }, time.Second, 5*time.Millisecond)
+	assert.Eventually(t, func() bool { return b.GetState("api") == StateClosed }, time.Second, 5*time.Millisecond)
+	assert.NoError(t, call(b, "api", nil))
+}
+
+func TestSharedState_FailedProbeReopens(t *testing.T) {
+	mr := miniredis.RunT(t)
+	a := newSharedManager(t, mr)
+	b := newSharedManager(t, mr)
+
+	require.NoError(t, call(b, "api", nil))
+	failure := errors.New("downstream down")
+	for i := 0; i < 2; i++ {
+		_ = call(a, "api", failure)
+	}
+	require.Eventually(t, func() bool { return b.GetState("api") == StateOpen }, time.Second, 5*time.Millisecond)
+
+	time.Sleep(250 * time.Millisecond)
+	assert.ErrorIs(t, call(a, "api", failure), failure)
+	assert.Equal(t, StateOpen, a.GetState("api"))
+
+	// b follows the new open period instead of probing
+	time.Sleep(50 * time.Millisecond)
+	assert.ErrorIs(t, call(b, "api", nil), ErrCircuitOpen)
+}
+
+func TestSharedState_ResetAppliesClusterWide(t *testing.T) {
+	mr := miniredis.RunT(t)
+	a := newSharedManager(t, mr)
+	b := newSharedManager(t, mr)
+
+	require.NoError(t, call(b, "api", nil))
+	failure := errors.New("downstream down")
+	for i := 0; i < 2; i++ {
+		_ = call(a, "api", failure)
+	}
+	require.Eventually(t, func() bool { return b.GetState("api") == StateOpen }, time.Second, 5*time.Millisecond)
+
+	require.NoError(t, b.Reset("api"))
+	assert.Equal(t, StateClosed, b.GetState("api"))
+	assert.Eventually(t, func() bool { return a.GetState("api") == StateClosed }, time.Second, 5*time.Millisecond)
+	assert.NoError(t, call(a, "api", nil))
+
+	// The shared open period is cleared for new instances
+	c := newSharedManager(t, mr)
+	assert.NoError(t, call(c, "api", nil))
+}
+
+func TestSharedState_ForcedStateAppliesClusterWide(t *testing.T) {
+	mr := miniredis.RunT(t)
+	a := newSharedManager(t, mr)
+	b := newSharedManager(t, mr)
+	require.NoError(t, call(b, "api", nil))
+
+	require.NoError(t, a.ForceState("api", StateForcedOpen))
+	assert.Equal(t, StateForcedOpen, a.GetState("api"))
+	assert.Eventually(t, func() bool { return b.GetState("api") == StateForcedOpen }, time.Second, 5*time.Millisecond)
+	assert.ErrorIs(t, call(b, "api", nil), ErrCircuitOpen)
+
+	require.NoError(t, a.ClearForcedState("api"))
+	assert.Eventually(t, func() bool { return b.GetState("api") == StateClosed }, time.Second, 5*time.Millisecond)
+	assert.NoError(t, call(b, "api", nil))
+}
+
+func TestManager_ResetLocal(t *testing.T) {
+	config := DefaultConfig()
+	config.Enabled = true
+	config.Default.Strategy = "consecutive_failures"
+	config.Default.ConsecutiveFailures = 1
+	mgr, err := NewManager(config)
+	require.NoError(t, err)
+	defer mgr.Close()
+
+	_ = call(mgr, "api", errors.New("failed"))
+	require.Equal(t, StateOpen, mgr.GetState("api"))
+
+	require.NoError(t, mgr.Reset("api"))
+	assert.Equal(t, StateClosed, mgr.GetState("api"))
+	assert.Equal(t, int64(0), mgr.GetMetrics("api").TotalRequests)
+}
+
+// blockingLoadBackend state backend whose Load blocks until released
+type blockingLoadBackend struct {
+	StateBackend
+	loading chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingLoadBackend) Load(ctx context.Context, resource string) (time.Time, error) {
+	b.loading <- struct{}{}
+	<-b.release
+	return time.Time{}, nil
+}
+
+func (b *blockingLoadBackend) Subscribe(handler func(StateMessage)) error { return nil }
+func (b *blockingLoadBackend) Close() error                               { return nil }
+
+func TestSharedState_LoadOutsideManagerLock(t *testing.T) {
+	config := DefaultConfig()
+	config.Enabled = true
+	mgr, err := NewManager(config)
+	require.NoError(t, err)
+	t.Cleanup(mgr.Close)
+
+	backend := &blockingLoadBackend{loading: make(chan struct{}, 2), release: make(chan struct{})}
+	require.NoError(t, mgr.SetStateBackend(backend))
+
+	done := make(chan error, 2)
+	go func() { done <- call(mgr, "slow", nil) }()
+	<-backend.loading
+
+	// Another resource is created while the shared state of "slow" is still loading
+	go func() { done <- call(mgr, "fast", nil) }()
+	select {
+	case <-backend.loading:
+	case <-time.After(time.Second):
+		close(backend.release)
+		t.Fatal("manager lock held during the shared state load")
+	}
+
+	close(backend.release)
+	require.NoError(t, <-done)
+	require.NoError(t, <-done)
+}
+
+func TestConfig_ValidateStateStore(t *testing.T) {
+	config := DefaultConfig()
+	config.Enabled = true
+	config.StateStore = "redis"
+	assert.Error(t, config.Validate())
+
+	config.Redis.Instance = "main"
+	require.NoError(t, config.Validate())
+	assert.Equal(t, "breaker:", config.Redis.KeyPrefix)
+	assert.Equal(t, 1, config.Redis.ProbeInstances)
+
+	config.StateStore = "etcd"
+	assert.Error(t, config.Validate())
+}
@@ -9,9 +9,20 @@ import (
 type stateManager struct {
 	state           State
 	lastStateChange time.Time
+	nextProbe       time.Time // earliest next half-open probe attempt (shared state)
 	failureCount    int
 	successCount    int
 	halfOpenAttempts int
+	forced          bool // state set by an operator, automatic transitions are ignored
+
+	// half-open traffic ramp (after the probes succeed)
+	ramping       bool
+	rampStep      int
+	rampStepStart time.Time
+	rampSeen      int64
+	rampSuccesses int64
+	rampFailures  int64
+
 	mu              sync.RWMutex
 }
 
@@ -35,6 +46,10 @@ func (sm *stateManager) CanAttempt(config ResourceConfig) bool {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 	
+	if sm.forced {
+		return sm.state != StateForcedOpen
+	}
+
 	switch sm.state {
 	case StateClosed:
 		// closed state, allow all requests
@@ -51,6 +66,13 @@ func (sm *stateManager) CanAttempt(config ResourceConfig) bool {
 		return false
 		
 	case StateHalfOpen:
+		// ramp: admit the step percentage of the traffic
+		if sm.ramping {
+			sm.rampSeen++
+			percent := int64(config.RampSteps[sm.rampStep])
+			return sm.rampSeen*percent/100 > (sm.rampSeen-1)*percent/100
+		}
+
 		// half-open state, limit request count
 		if sm.halfOpenAttempts < config.HalfOpenRequests {
 			sm.halfOpenAttempts++
@@ -67,6 +89,11 @@ func (sm *stateManager) CanAttempt(config ResourceConfig) bool {
 func (sm *stateManager) RecordSuccess(config ResourceConfig) (stateChanged bool, fromState, toState State) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
+
+	// Forced states survive automatic transitions
+	if sm.forced {
+		return false, sm.state, sm.state
+	}
 	
 	switch sm.state {
 	case StateClosed:
@@ -74,8 +101,17 @@ func (sm *stateManager) RecordSuccess(config ResourceConfig) (stateChanged bool,
 		sm.failureCount = 0
 		
 	case StateHalfOpen:
+		if sm.ramping {
+			return sm.advanceRamp(config)
+		}
+
 		// Half-open state, increase success count
 		sm.successCount++
+		if sm.successCount >= config.HalfOpenRequests && len(config.RampSteps) > 0 {
+			// Probes succeeded, raise the traffic step by step
+			sm.startRampStep(0)
+			return false, sm.state, sm.state
+		}
 		if sm.successCount >= config.HalfOpenRequests {
 			// Reach threshold, revert to closed state
 			fromState = sm.state
@@ -91,9 +127,14 @@ func (sm *stateManager) RecordSuccess(config ResourceConfig) (stateChanged bool,
 }
 
 // RecordFailure record failure
-func (sm *stateManager) RecordFailure() (stateChanged bool, fromState, toState State) {
+func (sm *stateManager) RecordFailure(config ResourceConfig) (stateChanged bool, fromState, toState State) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
+
+	// Forced states survive automatic transitions
+	if sm.forced {
+		return false, sm.state, sm.state
+	}
 	
 	switch sm.state {
 	case StateClosed:
@@ -101,6 +142,15 @@ func (sm *stateManager) RecordFailure() (stateChanged bool, fromState, toState S
 		sm.failureCount++
 		
 	case StateHalfOpen:
+		// Ramp: reopen when the error rate of the step reaches the threshold
+		if sm.ramping {
+			sm.rampFailures++
+			total := sm.rampSuccesses + sm.rampFailures
+			if total < int64(config.HalfOpenRequests) || float64(sm.rampFailures)/float64(total) < config.ErrorRateThreshold {
+				return false, sm.state, sm.state
+			}
+		}
+
 		// Half-open state failure, switch directly to open state
 		fromState = sm.state
 		sm.transitionTo(StateOpen, "failed in half-open state")
@@ -117,6 +167,11 @@ func (sm *stateManager) RecordFailure() (stateChanged bool, fromState, toState S
 func (sm *stateManager) ShouldOpen(shouldOpen bool) (stateChanged bool, fromState, toState State) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
+
+	// Forced states survive automatic transitions
+	if sm.forced {
+		return false, sm.state, sm.state
+	}
 	
 	if sm.state == StateClosed && shouldOpen {
 		fromState = sm.state
@@ -132,6 +187,11 @@ func (sm *stateManager) ShouldOpen(shouldOpen bool) (stateChanged bool, fromStat
 func (sm *stateManager) Reset() (stateChanged bool, fromState, toState State) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
+
+	// Forced states survive automatic transitions
+	if sm.forced {
+		return false, sm.state, sm.state
+	}
 	
 	if sm.state != StateClosed {
 		fromState = sm.state
@@ -146,10 +206,114 @@ func (sm *stateManager) Reset() (stateChanged bool, fromState, toState State) {
 	return false, sm.state, sm.state
 }
 
+// ProbeDue whether the open period is over and the next request would probe the resource (half-open)
+func (sm *stateManager) ProbeDue(config ResourceConfig) bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	return sm.state == StateOpen && time.Since(sm.lastStateChange) >= config.Timeout && !time.Now().Before(sm.nextProbe)
+}
+
+// DeferProbe keeps the resource open for d before trying to probe again (other instances are probing)
+func (sm *stateManager) DeferProbe(d time.Duration) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.nextProbe = time.Now().Add(d)
+}
+
+// OpenSince switches to open with the open period starting at since (state opened by another instance)
+func (sm *stateManager) OpenSince(since time.Time) (stateChanged bool, fromState, toState State) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	// Forced states survive automatic transitions
+	if sm.forced {
+		return false, sm.state, sm.state
+	}
+
+	fromState = sm.state
+	sm.state = StateOpen
+	sm.lastStateChange = since
+	sm.nextProbe = time.Time{}
+	sm.ramping = false
+	sm.successCount = 0
+	sm.failureCount = 0
+	sm.halfOpenAttempts = 0
+	return fromState != StateOpen, fromState, StateOpen
+}
+
+// Force pins an operator state (forced open, forced closed, disabled) until ClearForced
+func (sm *stateManager) Force(state State) (stateChanged bool, fromState, toState State) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	fromState = sm.state
+	sm.transitionTo(state, "forced")
+	sm.forced = true
+	sm.successCount = 0
+	sm.failureCount = 0
+	sm.halfOpenAttempts = 0
+	return fromState != state, fromState, state
+}
+
+// ClearForced returns a forced resource to closed
+func (sm *stateManager) ClearForced() (stateChanged bool, fromState, toState State) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if !sm.forced {
+		return false, sm.state, sm.state
+	}
+	fromState = sm.state
+	sm.forced = false
+	sm.transitionTo(StateClosed, "forced state cleared")
+	return true, fromState, sm.state
+}
+
 // transitionTo Switch state (internal method, lock required)
 func (sm *stateManager) transitionTo(newState State, reason string) {
 	sm.state = newState
 	sm.lastStateChange = time.Now()
+	sm.ramping = false
+}
+
+// startRampStep starts a ramp step (internal method, lock required)
+func (sm *stateManager) startRampStep(step int) {
+	sm.ramping = true
+	sm.rampStep = step
+	sm.rampStepStart = time.Now()
+	sm.rampSeen = 0
+	sm.rampSuccesses = 0
+	sm.rampFailures = 0
+}
+
+// advanceRamp records a ramp success and moves to the next step, closing after the last one (lock required)
+// A step lasts at least RampInterval and needs HalfOpenRequests successes.
+func (sm *stateManager) advanceRamp(config ResourceConfig) (stateChanged bool, fromState, toState State) {
+	sm.rampSuccesses++
+	if time.Since(sm.rampStepStart) < config.RampInterval || sm.rampSuccesses < int64(config.HalfOpenRequests) {
+		return false, sm.state, sm.state
+	}
+
+	if sm.rampStep+1 < len(config.RampSteps) {
+		sm.startRampStep(sm.rampStep + 1)
+		return false, sm.state, sm.state
+	}
+
+	fromState = sm.state
+	sm.transitionTo(StateClosed, "ramp completed")
+	sm.successCount = 0
+	sm.failureCount = 0
+	return true, fromState, sm.state
+}
+
+// RampPercent traffic percentage allowed by the half-open ramp (0 when not ramping)
+func (sm *stateManager) RampPercent(config ResourceConfig) int {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	if !sm.ramping || sm.rampStep >= len(config.RampSteps) {
+		return 0
+	}
+	return config.RampSteps[sm.rampStep]
 }
 
 // GetFailureCount gets the failure count
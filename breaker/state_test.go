@@ -135,7 +135,7 @@ func TestStateManager_RecordFailure(t *testing.T) {
 	t.Run("Closed状态记录失败增加计数", func(t *testing.T) {
 		sm := newStateManager()
 		
-		changed, _, _ := sm.RecordFailure()
+		changed, _, _ := sm.RecordFailure(DefaultResourceConfig())
 		
 		assert.False(t, changed)
 		assert.Equal(t, 1, sm.GetFailureCount())
@@ -150,7 +150,7 @@ func TestStateManager_RecordFailure(t *testing.T) {
 		sm.failureCount = 2
 		sm.mu.Unlock()
 		
-		changed, fromState, toState := sm.RecordFailure()
+		changed, fromState, toState := sm.RecordFailure(DefaultResourceConfig())
 		
 		assert.True(t, changed)
 		assert.Equal(t, StateHalfOpen, fromState)
@@ -166,7 +166,7 @@ func TestStateManager_RecordFailure(t *testing.T) {
 		sm.state = StateOpen
 		sm.mu.Unlock()
 		
-		changed, _, _ := sm.RecordFailure()
+		changed, _, _ := sm.RecordFailure(DefaultResourceConfig())
 		
 		assert.False(t, changed)
 		assert.Equal(t, StateOpen, sm.GetState())
@@ -289,7 +289,7 @@ func TestStateManager_Concurrent(t *testing.T) {
 			for j := 0; j < 100; j++ {
 				_ = sm.CanAttempt(config)
 				sm.RecordSuccess(config)
-				sm.RecordFailure()
+				sm.RecordFailure(config)
 			}
 			done <- true
 		}()
@@ -324,3 +324,75 @@ func TestStateManager_GetLastStateChange(t *testing.T) {
 	assert.True(t, lastChange.Before(after))
 }
 
+
+// rampConfig probes with 1 request then ramps 50% → 100%
+func rampConfig() ResourceConfig {
+	config := DefaultResourceConfig()
+	config.HalfOpenRequests = 1
+	config.Timeout = time.Millisecond
+	config.RampSteps = []int{50, 100}
+	config.RampInterval = 20 * time.Millisecond
+	return config
+}
+
+// TestStateManager_Ramp traffic is raised step by step after the probe succeeds
+func TestStateManager_Ramp(t *testing.T) {
+	config := rampConfig()
+	sm := newStateManager()
+	sm.ShouldOpen(true)
+	time.Sleep(2 * time.Millisecond)
+
+	// Probe
+	assert.True(t, sm.CanAttempt(config))
+	changed, _, _ := sm.RecordSuccess(config)
+	assert.False(t, changed)
+	assert.Equal(t, StateHalfOpen, sm.GetState())
+	assert.Equal(t, 50, sm.RampPercent(config))
+
+	// Half of the traffic is admitted
+	admitted := 0
+	for i := 0; i < 10; i++ {
+		if sm.CanAttempt(config) {
+			admitted++
+		}
+	}
+	assert.Equal(t, 5, admitted)
+
+	// Steps last at least RampInterval
+	sm.RecordSuccess(config)
+	assert.Equal(t, 50, sm.RampPercent(config))
+	time.Sleep(25 * time.Millisecond)
+	sm.RecordSuccess(config)
+	assert.Equal(t, 100, sm.RampPercent(config))
+
+	time.Sleep(25 * time.Millisecond)
+	changed, fromState, toState := sm.RecordSuccess(config)
+	assert.True(t, changed)
+	assert.Equal(t, StateHalfOpen, fromState)
+	assert.Equal(t, StateClosed, toState)
+	assert.Equal(t, 0, sm.RampPercent(config))
+}
+
+// TestStateManager_RampReopens failures of a step above the error rate reopen the circuit
+func TestStateManager_RampReopens(t *testing.T) {
+	config := rampConfig()
+	config.HalfOpenRequests = 2
+	sm := newStateManager()
+	sm.ShouldOpen(true)
+	time.Sleep(2 * time.Millisecond)
+
+	assert.True(t, sm.CanAttempt(config))
+	assert.True(t, sm.CanAttempt(config))
+	sm.RecordSuccess(config)
+	sm.RecordSuccess(config)
+	assert.Equal(t, 50, sm.RampPercent(config))
+
+	// One failure out of one call is below the minimum sample of the step
+	changed, _, _ := sm.RecordFailure(config)
+	assert.False(t, changed)
+
+	changed, _, toState := sm.RecordFailure(config)
+	assert.True(t, changed)
+	assert.Equal(t, StateOpen, toState)
+	assert.Equal(t, 0, sm.RampPercent(config))
+}
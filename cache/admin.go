@@ -0,0 +1,203 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// InspectKey reports existence, size and remaining TTL of a key of a cache item
+// The TTL is only reported when the store implements KeyInspector
+func (o *DefaultOrchestrator) InspectKey(ctx context.Context, name string, key string) (*KeyInfo, error) {
+	o.mu.RLock()
+	config, ok := o.cacheables[name]
+	o.mu.RUnlock()
+	if !ok {
+		return nil, ErrCacheableNotFound.WithMsgf("缓存项未配置: %s", name)
+	}
+
+	storeName := o.storeNameFor(config)
+	store, err := o.GetStore(storeName)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &KeyInfo{Cacheable: name, Store: storeName, Key: key}
+	data, err := store.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, ErrCacheMiss) {
+			return info, nil
+		}
+		return nil, err
+	}
+	info.Exists = true
+	info.Size = len(data)
+
+	if inspector, ok := store.(KeyInspector); ok {
+		ttl, err := inspector.TTL(ctx, key)
+		if err != nil && !errors.Is(err, ErrCacheMiss) {
+			return nil, err
+		}
+		info.TTL = ttl
+	}
+	return info, nil
+}
+
+// PurgeCacheable removes every entry of a cache item
+// Keys are matched against the whole key pattern; a key matching several cache items of the store
+// (e.g., "user:{0}" and "user:profile:{0}") belongs to the most specific pattern.
+func (o *DefaultOrchestrator) PurgeCacheable(ctx context.Context, name string) error {
+	o.mu.RLock()
+	config, ok := o.cacheables[name]
+	o.mu.RUnlock()
+	if !ok {
+		return ErrCacheableNotFound.WithMsgf("缓存项未配置: %s", name)
+	}
+
+	prefix := keyPrefix(config.KeyPattern)
+	if prefix == "" {
+		// Purging an empty prefix would wipe the whole store
+		return ErrConfigInvalid.WithMsgf("缓存项 %s 的 key_pattern 没有固定前缀，无法清除", name)
+	}
+
+	storeName := o.storeNameFor(config)
+	store, err := o.GetStore(storeName)
+	if err != nil {
+		return err
+	}
+
+	templates := o.keyTemplatesOverlapping(storeName, prefix)
+	if deleter, ok := store.(KeyMatchDeleter); ok {
+		err = deleter.DeleteMatching(ctx, prefix, func(key string) bool {
+			return ownerOfKey(templates, key) == name
+		})
+	} else if len(templates) == 1 {
+		err = store.DeleteByPrefix(ctx, prefix)
+	} else {
+		err = ErrConfigInvalid.WithMsgf("存储 %s 不支持按键匹配删除，缓存项 %s 与其他缓存项共用前缀", storeName, name)
+	}
+	if err != nil {
+		return err
+	}
+
+	o.recordEviction(ctx, name, storeName, "purge")
+	if o.logger != nil {
+		o.logger.Info("cache purged", zap.String("name", name), zap.String("prefix", prefix))
+	}
+	return nil
+}
+
+// PurgeTag purges every cache item carrying the tag, returns the purged cache item names
+func (o *DefaultOrchestrator) PurgeTag(ctx context.Context, tag string) ([]string, error) {
+	o.mu.RLock()
+	var names []string
+	for name, config := range o.cacheables {
+		for _, t := range config.Tags {
+			if t == tag {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	o.mu.RUnlock()
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := o.PurgeCacheable(ctx, name); err != nil {
+			return nil, err
+		}
+	}
+	return names, nil
+}
+
+// PurgePrefix removes every key with the prefix from a storage backend
+func (o *DefaultOrchestrator) PurgePrefix(ctx context.Context, storeName string, prefix string) error {
+	if prefix == "" {
+		return ErrConfigInvalid.WithMsgf("清除前缀不能为空")
+	}
+	if storeName == "" {
+		storeName = o.config.DefaultStore
+	}
+
+	store, err := o.GetStore(storeName)
+	if err != nil {
+		return err
+	}
+	if err := store.DeleteByPrefix(ctx, prefix); err != nil {
+		return err
+	}
+
+	o.recordEviction(ctx, o.cacheableForKey(prefix), storeName, "purge")
+	if o.logger != nil {
+		o.logger.Info("cache purged by prefix", zap.String("store", storeName), zap.String("prefix", prefix))
+	}
+	return nil
+}
+
+// keyTemplate key pattern of a cache item compiled for key matching
+type keyTemplate struct {
+	name     string
+	re       *regexp.Regexp
+	literals int // Static characters of the pattern, the most specific pattern owns a key
+}
+
+// placeholderRe matches the placeholders of a key pattern ({0}, {1}, {hash})
+var placeholderRe = regexp.MustCompile(`\{[^}]*\}`)
+
+// keyTemplatesOverlapping compiles the key patterns of the store's cache items whose static prefixes overlap the prefix
+func (o *DefaultOrchestrator) keyTemplatesOverlapping(storeName, prefix string) []keyTemplate {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	var templates []keyTemplate
+	for name, config := range o.cacheables {
+		other := keyPrefix(config.KeyPattern)
+		if o.storeNameFor(config) != storeName || !(strings.HasPrefix(other, prefix) || strings.HasPrefix(prefix, other)) {
+			continue
+		}
+
+		var expr strings.Builder
+		expr.WriteString("^")
+		last := 0
+		for _, loc := range placeholderRe.FindAllStringIndex(config.KeyPattern, -1) {
+			expr.WriteString(regexp.QuoteMeta(config.KeyPattern[last:loc[0]]) + ".+")
+			last = loc[1]
+		}
+		expr.WriteString(regexp.QuoteMeta(config.KeyPattern[last:]))
+		if config.Variants {
+			expr.WriteString("(:.+)?")
+		}
+		expr.WriteString("$")
+
+		templates = append(templates, keyTemplate{
+			name:     name,
+			re:       regexp.MustCompile(expr.String()),
+			literals: len(placeholderRe.ReplaceAllString(config.KeyPattern, "")),
+		})
+	}
+	return templates
+}
+
+// ownerOfKey returns the cache item owning a key, the matching template with the most static characters
+func ownerOfKey(templates []keyTemplate, key string) string {
+	var owner string
+	best := -1
+	for _, t := range templates {
+		if t.literals > best && t.re.MatchString(key) {
+			owner, best = t.name, t.literals
+		}
+	}
+	return owner
+}
+
+// keyPrefix returns the static part of a key pattern before the first placeholder
+func keyPrefix(pattern string) string {
+	if i := strings.Index(pattern, "{"); i >= 0 {
+		return pattern[:i]
+	}
+	return pattern
+}
@@ -0,0 +1,123 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAdminTestOrchestrator() *DefaultOrchestrator {
+	cfg := &Config{
+		Enabled:      true,
+		DefaultStore: "memory",
+		Cacheables: []CacheableConfig{
+			{Name: "user:get", KeyPattern: "user:{0}", TTL: time.Minute, Store: "memory", Enabled: true, Tags: []string{"user"}},
+			{Name: "user:profile", KeyPattern: "profile:{0}", TTL: time.Minute, Store: "memory", Enabled: true, Tags: []string{"user"}},
+			{Name: "order:get", KeyPattern: "order:{0}", TTL: time.Minute, Store: "memory", Enabled: true},
+			{Name: "user:settings", KeyPattern: "user:settings:{0}", TTL: time.Minute, Store: "memory", Enabled: true},
+			{Name: "any", KeyPattern: "{0}", Store: "memory", Enabled: true},
+		},
+	}
+	o := NewOrchestrator(cfg, nil, nil)
+	o.RegisterStore("memory", NewMemoryStore("memory", 100))
+	for _, name := range []string{"user:get", "user:profile", "order:get", "user:settings"} {
+		o.RegisterLoader(name, func(ctx context.Context, args ...any) (any, error) {
+			return "value", nil
+		})
+	}
+	return o
+}
+
+func TestOrchestrator_InspectKey(t *testing.T) {
+	o := newAdminTestOrchestrator()
+	ctx := context.Background()
+	_, err := o.Call(ctx, "user:get", 1)
+	require.NoError(t, err)
+
+	info, err := o.InspectKey(ctx, "user:get", "user:1")
+	require.NoError(t, err)
+	assert.True(t, info.Exists)
+	assert.Equal(t, "memory", info.Store)
+	assert.Equal(t, len(`"value"`), info.Size)
+	assert.True(t, info.TTL > 0 && info.TTL <= time.Minute)
+
+	info, err = o.InspectKey(ctx, "user:get", "user:2")
+	require.NoError(t, err)
+	assert.False(t, info.Exists)
+
+	_, err = o.InspectKey(ctx, "missing", "x")
+	assert.Error(t, err)
+}
+
+func TestOrchestrator_Purge(t *testing.T) {
+	o := newAdminTestOrchestrator()
+	ctx := context.Background()
+	store, _ := o.GetStore("memory")
+
+	load := func() {
+		for _, name := range []string{"user:get", "user:profile", "order:get", "user:settings"} {
+			_, err := o.Call(ctx, name, 1)
+			require.NoError(t, err)
+		}
+	}
+
+	t.Run("by cacheable", func(t *testing.T) {
+		load()
+		require.NoError(t, o.PurgeCacheable(ctx, "user:get"))
+		assert.False(t, store.Exists(ctx, "user:1"))
+		assert.True(t, store.Exists(ctx, "profile:1"))
+		assert.True(t, store.Exists(ctx, "user:settings:1"), "keys of a more specific pattern sharing the prefix are kept")
+
+		require.NoError(t, o.PurgeCacheable(ctx, "user:settings"))
+		assert.False(t, store.Exists(ctx, "user:settings:1"))
+	})
+
+	t.Run("by tag", func(t *testing.T) {
+		load()
+		names, err := o.PurgeTag(ctx, "user")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user:get", "user:profile"}, names)
+		assert.False(t, store.Exists(ctx, "user:1"))
+		assert.False(t, store.Exists(ctx, "profile:1"))
+		assert.True(t, store.Exists(ctx, "order:1"))
+	})
+
+	t.Run("by prefix", func(t *testing.T) {
+		load()
+		require.NoError(t, o.PurgePrefix(ctx, "", "order:"))
+		assert.False(t, store.Exists(ctx, "order:1"))
+		assert.Error(t, o.PurgePrefix(ctx, "memory", ""))
+		assert.Error(t, o.PurgePrefix(ctx, "missing", "order:"))
+	})
+
+	t.Run("pattern without static prefix is refused", func(t *testing.T) {
+		assert.Error(t, o.PurgeCacheable(ctx, "any"))
+		assert.Error(t, o.PurgeCacheable(ctx, "missing"))
+	})
+}
+
+func TestStore_InspectTTL(t *testing.T) {
+	store := NewMemoryStore("memory", 10)
+	ctx := context.Background()
+
+	_, err := store.TTL(ctx, "missing")
+	assert.ErrorIs(t, err, ErrCacheMiss)
+
+	require.NoError(t, store.Set(ctx, "forever", []byte("v"), 0))
+	ttl, err := store.TTL(ctx, "forever")
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(-1), ttl)
+
+	require.NoError(t, store.Set(ctx, "short", []byte("v"), time.Minute))
+	ttl, err = store.TTL(ctx, "short")
+	require.NoError(t, err)
+	assert.True(t, ttl > 0 && ttl <= time.Minute)
+
+	chain := NewChainStore("chain", NewMemoryStore("l1", 10), store)
+	ttl, err = chain.TTL(ctx, "short")
+	require.NoError(t, err)
+	assert.True(t, ttl > 0)
+}
@@ -24,8 +24,43 @@ type Config struct {
 
 	// InvalidationRules invalidation rules
 	InvalidationRules []InvalidationRule `mapstructure:"invalidation_rules"`
+
+	// Lease distributed load lease (cross-instance stampede protection)
+	Lease LeaseConfig `mapstructure:"lease"`
+}
+
+// LeaseConfig distributed load lease configuration
+// When enabled, only one instance loads a missing key while the others wait, poll, or serve stale data
+type LeaseConfig struct {
+	// Enabled whether to enable the distributed lease
+	Enabled bool `mapstructure:"enabled"`
+
+	// Instance Redis instance name of the lease backend (global configuration only, required when any lease is enabled)
+	Instance string `mapstructure:"instance"`
+
+	// Strategy behavior when the lease is held by another instance: wait, stale
+	// wait polls the cacheable store, so it requires a shared store (redis), not memory
+	Strategy string `mapstructure:"strategy"`
+
+	// TTL lease expiration time, should exceed the slowest expected load
+	TTL time.Duration `mapstructure:"ttl"`
+
+	// WaitTimeout maximum time to wait for the lease holder before loading locally
+	WaitTimeout time.Duration `mapstructure:"wait_timeout"`
+
+	// PollInterval interval for polling the store while waiting
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// StaleTTL how long a stale copy is kept after the value expires (stale strategy only)
+	StaleTTL time.Duration `mapstructure:"stale_ttl"`
 }
 
+// Lease strategies
+const (
+	LeaseStrategyWait  = "wait"
+	LeaseStrategyStale = "stale"
+)
+
 // StoreConfig stores backend configuration
 type StoreConfig struct {
 	// Type storage: redis, memory, chain
@@ -64,8 +99,18 @@ type CacheableConfig struct {
 	// List of events for failed dependencies
 	DependsOn []string `mapstructure:"depends_on"`
 
+	// Tags group cache items for administrative purge
+	Tags []string `mapstructure:"tags"`
+
 	// Enabled whether to enable
 	Enabled bool `mapstructure:"enabled"`
+
+	// Lease overrides the global lease configuration for this cache item
+	Lease *LeaseConfig `mapstructure:"lease"`
+
+	// Variants entries are stored as "<key>:<variant>" (e.g., HTTP responses varying by query or header)
+	// Precise invalidation then removes every variant of the key
+	Variants bool `mapstructure:"variants"`
 }
 
 // InvalidationRule invalidation rule
@@ -108,6 +153,13 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if err := c.Lease.validate(); err != nil {
+		return fmt.Errorf("lease: %w", err)
+	}
+	if c.Lease.Instance == "" && c.leaseEnabled() {
+		return fmt.Errorf("lease: instance is required when the lease is enabled")
+	}
+
 	// Validate cache item configuration
 	for _, cacheable := range c.Cacheables {
 		if cacheable.Name == "" {
@@ -116,11 +168,52 @@ func (c *Config) Validate() error {
 		if cacheable.KeyPattern == "" {
 			return fmt.Errorf("cacheable %s: key_pattern is required", cacheable.Name)
 		}
+		if cacheable.Lease != nil {
+			if err := cacheable.Lease.validate(); err != nil {
+				return fmt.Errorf("cacheable %s: lease: %w", cacheable.Name, err)
+			}
+		}
+
+		// Waiters poll the cacheable store, a local memory store never sees the holder's write
+		lease := &c.Lease
+		if cacheable.Lease != nil {
+			lease = cacheable.Lease
+		}
+		if lease.Enabled && lease.Strategy != LeaseStrategyStale && c.storeType(cacheable.Store) == "memory" {
+			return fmt.Errorf("cacheable %s: lease strategy wait requires a shared store, not memory", cacheable.Name)
+		}
 	}
 
 	return nil
 }
 
+// leaseEnabled reports whether the lease is enabled globally or for any cache item
+func (c *Config) leaseEnabled() bool {
+	if c.Lease.Enabled {
+		return true
+	}
+	for _, cacheable := range c.Cacheables {
+		if cacheable.Lease != nil && cacheable.Lease.Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// storeType returns the backend type of a store name (empty: default store)
+func (c *Config) storeType(name string) string {
+	if name == "" {
+		name = c.DefaultStore
+	}
+	if store, ok := c.Stores[name]; ok {
+		return store.Type
+	}
+	if name == "memory" {
+		return "memory"
+	}
+	return ""
+}
+
 // ApplyDefaults Apply default values
 func (c *Config) ApplyDefaults() {
 	if c.DefaultTTL <= 0 {
@@ -129,6 +222,7 @@ func (c *Config) ApplyDefaults() {
 	if c.DefaultStore == "" {
 		c.DefaultStore = "memory"
 	}
+	c.Lease.applyDefaults()
 
 	// Apply default values to each cache item
 	for i := range c.Cacheables {
@@ -142,5 +236,44 @@ func (c *Config) ApplyDefaults() {
 		if !c.Cacheables[i].Enabled {
 			c.Cacheables[i].Enabled = true
 		}
+		if c.Cacheables[i].Lease != nil {
+			c.Cacheables[i].Lease.applyDefaults()
+		}
+	}
+}
+
+// validate lease configuration
+func (l *LeaseConfig) validate() error {
+	if !l.Enabled {
+		return nil
+	}
+	switch l.Strategy {
+	case "", LeaseStrategyWait, LeaseStrategyStale:
+		// valid
+	default:
+		return fmt.Errorf("unknown strategy %s", l.Strategy)
+	}
+	if l.TTL < 0 || l.WaitTimeout < 0 || l.PollInterval < 0 || l.StaleTTL < 0 {
+		return fmt.Errorf("durations must not be negative")
+	}
+	return nil
+}
+
+// applyDefaults Apply lease default values
+func (l *LeaseConfig) applyDefaults() {
+	if l.Strategy == "" {
+		l.Strategy = LeaseStrategyWait
+	}
+	if l.TTL <= 0 {
+		l.TTL = 5 * time.Second
+	}
+	if l.WaitTimeout <= 0 {
+		l.WaitTimeout = time.Second
+	}
+	if l.PollInterval <= 0 {
+		l.PollInterval = 50 * time.Millisecond
+	}
+	if l.StaleTTL <= 0 && l.Strategy == LeaseStrategyStale {
+		l.StaleTTL = time.Minute
 	}
 }
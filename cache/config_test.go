@@ -53,6 +53,41 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "lease without instance",
+			config: Config{
+				Enabled: true,
+				Lease:   LeaseConfig{Enabled: true},
+			},
+			wantErr: true,
+		},
+		{
+			name: "lease wait on memory store",
+			config: Config{
+				Enabled: true,
+				Lease:   LeaseConfig{Enabled: true, Instance: "main"},
+				Cacheables: []CacheableConfig{
+					{Name: "user:getById", KeyPattern: "user:{0}"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "lease wait on redis store",
+			config: Config{
+				Enabled:      true,
+				DefaultStore: "redis",
+				Stores: map[string]StoreConfig{
+					"redis": {Type: "redis", Instance: "main"},
+				},
+				Lease: LeaseConfig{Enabled: true, Instance: "main"},
+				Cacheables: []CacheableConfig{
+					{Name: "user:getById", KeyPattern: "user:{0}"},
+					{Name: "user:local", KeyPattern: "local:{0}", Store: "memory", Lease: &LeaseConfig{Enabled: true, Strategy: LeaseStrategyStale}},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "cacheable without key pattern",
 			config: Config{
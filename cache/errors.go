@@ -24,6 +24,7 @@ const (
 	ErrCodeStoreDelete       = 8
 	ErrCodeConfigInvalid     = 9
 	ErrCodeCacheableNotFound = 10
+	ErrCodeCacheableDisabled = 11
 )
 
 var (
@@ -96,4 +97,11 @@ var (
 		"cache", "error.cache.cacheable_not_found", "缓存项未配置",
 		http.StatusInternalServerError,
 	)
+
+	// ErrCacheableDisabled Cache item disabled
+	ErrCacheableDisabled = errcode.New(
+		ModuleCode, ErrCodeCacheableDisabled,
+		"cache", "error.cache.cacheable_disabled", "缓存项已禁用",
+		http.StatusInternalServerError,
+	)
 )
@@ -83,5 +83,61 @@ type CacheStats struct {
 	Misses      int64            `json:"misses"`
 	Invalidates int64            `json:"invalidates"`
 	Errors      int64            `json:"errors"`
-	ByName      map[string]int64 `json:"by_name"`
+	ByName      map[string]int64 `json:"by_name"` // Calls per cache item
+
+	// ByCacheable per cache item statistics
+	ByCacheable map[string]CacheableStats `json:"by_cacheable"`
+
+	// Distributed lease outcomes
+	LeaseAcquired    int64 `json:"lease_acquired"`     // Lease taken, this instance loaded the value
+	LeaseWaitHits    int64 `json:"lease_wait_hits"`    // Value produced by another instance while waiting
+	LeaseStaleServed int64 `json:"lease_stale_served"` // Stale copy served while another instance reloads
+	LeaseTimeouts    int64 `json:"lease_timeouts"`     // Wait timed out, loaded locally
+	LeaseErrors      int64 `json:"lease_errors"`       // Lease backend failures
+}
+
+// CacheableStats per cache item statistics
+type CacheableStats struct {
+	Hits     int64   `json:"hits"`
+	Misses   int64   `json:"misses"`
+	Errors   int64   `json:"errors"`
+	Loads    int64   `json:"loads"`
+	HitRatio float64 `json:"hit_ratio"`
+}
+
+// cacheableCounters per cache item counters
+type cacheableCounters struct {
+	hits   int64
+	misses int64
+	errors int64
+	loads  int64
+}
+
+// KeyInspector optional interface for stores that can report the remaining TTL of a key
+type KeyInspector interface {
+	// TTL returns the remaining lifetime, negative when the key never expires
+	// Return ErrCacheMiss when the key does not exist
+	TTL(ctx context.Context, key string) (time.Duration, error)
+}
+
+// KeyMatchDeleter optional interface for stores that can delete a selection of the keys of a prefix
+type KeyMatchDeleter interface {
+	// DeleteMatching deletes the keys with the prefix for which match returns true
+	DeleteMatching(ctx context.Context, prefix string, match func(key string) bool) error
+}
+
+// EvictionNotifier optional interface for stores that evict entries on their own (e.g., capacity limits)
+type EvictionNotifier interface {
+	// OnEvict registers a callback invoked with the evicted key
+	OnEvict(fn func(key string))
+}
+
+// KeyInfo key inspection result
+type KeyInfo struct {
+	Cacheable string        `json:"cacheable"`
+	Store     string        `json:"store"`
+	Key       string        `json:"key"`
+	Exists    bool          `json:"exists"`
+	Size      int           `json:"size"`          // Serialized value size in bytes
+	TTL       time.Duration `json:"ttl,omitempty"` // Remaining lifetime, negative when it never expires
 }
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// staleKeySuffix suffix of the stale copy key, kept under the same prefix so prefix invalidation covers it
+const staleKeySuffix = "#stale"
+
+// Leaser distributed load lease interface
+// Guarantees that only one instance loads a missing key at a time
+type Leaser interface {
+	// Acquire try to take the lease for key, returns the owner token when acquired
+	Acquire(ctx context.Context, key string, ttl time.Duration) (token string, acquired bool, err error)
+
+	// Release the lease, only when it is still held by token
+	Release(ctx context.Context, key string, token string) error
+}
+
+// releaseScript deletes the lease only if the token still matches (avoid releasing a lease taken over after expiry)
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// RedisLeaser Redis-based load lease
+type RedisLeaser struct {
+	client    *redis.Client
+	keyPrefix string
+}
+
+// NewRedisLeaser creates a Redis lease, keyPrefix defaults to "cache:lease:"
+func NewRedisLeaser(client *redis.Client, keyPrefix string) *RedisLeaser {
+	if keyPrefix == "" {
+		keyPrefix = "cache:lease:"
+	}
+	return &RedisLeaser{
+		client:    client,
+		keyPrefix: keyPrefix,
+	}
+}
+
+// Acquire try to take the lease with SET NX PX
+func (l *RedisLeaser) Acquire(ctx context.Context, key string, ttl time.Duration) (string, bool, error) {
+	token := uuid.New().String()
+	ok, err := l.client.SetNX(ctx, l.keyPrefix+key, token, ttl).Result()
+	if err != nil {
+		return "", false, err
+	}
+	if !ok {
+		return "", false, nil
+	}
+	return token, true, nil
+}
+
+// Release the lease if still owned
+func (l *RedisLeaser) Release(ctx context.Context, key string, token string) error {
+	return releaseScript.Run(ctx, l.client, []string{l.keyPrefix + key}, token).Err()
+}
@@ -0,0 +1,199 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLeaseTestOrchestrator creates an orchestrator simulating one instance sharing Redis with others
+func newLeaseTestOrchestrator(client *redis.Client, lease LeaseConfig, loader LoaderFunc) *DefaultOrchestrator {
+	cfg := &Config{
+		Enabled:      true,
+		DefaultStore: "redis",
+		Cacheables: []CacheableConfig{
+			{Name: "article:get", KeyPattern: "article:{0}", TTL: time.Minute, Store: "redis", Enabled: true},
+		},
+		Lease: lease,
+	}
+	o := NewOrchestrator(cfg, nil, nil)
+	o.RegisterStore("redis", NewRedisStore("redis", client, "cache:"))
+	o.RegisterLoader("article:get", loader)
+	o.SetLeaser(NewRedisLeaser(client, ""))
+	return o
+}
+
+func TestRedisLeaser_AcquireRelease(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	leaser := NewRedisLeaser(client, "")
+	ctx := context.Background()
+
+	token, ok, err := leaser.Acquire(ctx, "k", time.Second)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.NotEmpty(t, token)
+
+	_, ok, err = leaser.Acquire(ctx, "k", time.Second)
+	require.NoError(t, err)
+	assert.False(t, ok, "lease is already held")
+
+	// A foreign token must not release the lease
+	require.NoError(t, leaser.Release(ctx, "k", "other"))
+	assert.True(t, mr.Exists("cache:lease:k"))
+
+	require.NoError(t, leaser.Release(ctx, "k", token))
+	assert.False(t, mr.Exists("cache:lease:k"))
+}
+
+func TestOrchestrator_LeaseSingleLoaderAcrossInstances(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	var loads int32
+	loader := func(ctx context.Context, args ...any) (any, error) {
+		atomic.AddInt32(&loads, 1)
+		time.Sleep(100 * time.Millisecond)
+		return "content", nil
+	}
+
+	lease := LeaseConfig{Enabled: true, TTL: time.Second, WaitTimeout: time.Second, PollInterval: 10 * time.Millisecond}
+	instances := make([]*DefaultOrchestrator, 5)
+	for i := range instances {
+		instances[i] = newLeaseTestOrchestrator(client, lease, loader)
+	}
+
+	var wg sync.WaitGroup
+	for _, o := range instances {
+		wg.Add(1)
+		go func(o *DefaultOrchestrator) {
+			defer wg.Done()
+			result, err := o.Call(context.Background(), "article:get", 1)
+			assert.NoError(t, err)
+			assert.Equal(t, "content", result)
+		}(o)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&loads))
+
+	var acquired, waitHits int64
+	for _, o := range instances {
+		stats := o.Stats()
+		acquired += stats.LeaseAcquired
+		waitHits += stats.LeaseWaitHits
+	}
+	assert.Equal(t, int64(1), acquired)
+	assert.Equal(t, int64(4), waitHits)
+	assert.False(t, mr.Exists("cache:lease:article:1"), "lease released after load")
+}
+
+func TestOrchestrator_LeaseServeStale(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	var version int32
+	loader := func(ctx context.Context, args ...any) (any, error) {
+		if atomic.AddInt32(&version, 1) == 1 {
+			return "v1", nil
+		}
+		return "v2", nil
+	}
+
+	lease := LeaseConfig{Enabled: true, Strategy: LeaseStrategyStale, StaleTTL: time.Hour}
+	o := newLeaseTestOrchestrator(client, lease, loader)
+	ctx := context.Background()
+
+	result, err := o.Call(ctx, "article:get", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", result)
+
+	// Value expires while another instance holds the lease
+	mr.Del("cache:article:1")
+	mr.Set("cache:lease:article:1", "other-instance")
+
+	result, err = o.Call(ctx, "article:get", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", result)
+	assert.Equal(t, int64(1), o.Stats().LeaseStaleServed)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&version))
+}
+
+func TestOrchestrator_LeaseWaitTimeout(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	var loads int32
+	loader := func(ctx context.Context, args ...any) (any, error) {
+		atomic.AddInt32(&loads, 1)
+		return "content", nil
+	}
+
+	lease := LeaseConfig{Enabled: true, WaitTimeout: 50 * time.Millisecond, PollInterval: 10 * time.Millisecond}
+	o := newLeaseTestOrchestrator(client, lease, loader)
+
+	// Lease held by a holder that never writes the value
+	mr.Set("cache:lease:article:1", "stuck-instance")
+
+	result, err := o.Call(context.Background(), "article:get", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "content", result)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&loads))
+	assert.Equal(t, int64(1), o.Stats().LeaseTimeouts)
+}
+
+func TestOrchestrator_LeaseBackendErrorFallback(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	cfg := &Config{
+		Enabled:      true,
+		DefaultStore: "memory",
+		Cacheables: []CacheableConfig{
+			{Name: "article:get", KeyPattern: "article:{0}", Store: "memory", Enabled: true},
+		},
+		Lease: LeaseConfig{Enabled: true},
+	}
+	o := NewOrchestrator(cfg, nil, nil)
+	o.RegisterStore("memory", NewMemoryStore("memory", 100))
+	o.RegisterLoader("article:get", func(ctx context.Context, args ...any) (any, error) {
+		return "content", nil
+	})
+	o.SetLeaser(NewRedisLeaser(client, ""))
+
+	mr.Close()
+
+	result, err := o.Call(context.Background(), "article:get", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "content", result)
+	assert.Equal(t, int64(1), o.Stats().LeaseErrors)
+}
+
+func TestLeaseConfig_Validate(t *testing.T) {
+	cfg := Config{Enabled: true, Lease: LeaseConfig{Enabled: true, Strategy: "unknown"}}
+	assert.Error(t, cfg.Validate())
+
+	cfg = Config{Enabled: true, Cacheables: []CacheableConfig{
+		{Name: "a", KeyPattern: "a:{0}", Lease: &LeaseConfig{Enabled: true, TTL: -time.Second}},
+	}}
+	assert.Error(t, cfg.Validate())
+
+	cfg = Config{Lease: LeaseConfig{Enabled: true}}
+	cfg.ApplyDefaults()
+	assert.Equal(t, LeaseStrategyWait, cfg.Lease.Strategy)
+	assert.Equal(t, 5*time.Second, cfg.Lease.TTL)
+	assert.Equal(t, time.Second, cfg.Lease.WaitTimeout)
+}
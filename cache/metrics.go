@@ -0,0 +1,236 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// CacheMetricsConfig holds configuration for cache metrics
+type CacheMetricsConfig struct {
+	Enabled        bool
+	RecordHitRatio bool
+}
+
+// CacheMetrics implements component.MetricsProvider for cache instrumentation.
+// Metrics are labeled by cacheable and store.
+type CacheMetrics struct {
+	config     CacheMetricsConfig
+	meter      metric.Meter
+	registered bool
+	mu         sync.RWMutex
+
+	// Metrics instruments
+	hitsTotal      metric.Int64Counter           // Cache hits
+	missesTotal    metric.Int64Counter           // Cache misses
+	errorsTotal    metric.Int64Counter           // Store/serialization errors
+	evictionsTotal metric.Int64Counter           // Removed entries (invalidation, capacity)
+	loadDuration   metric.Float64Histogram       // Loader duration
+	getDuration    metric.Float64Histogram       // Store read latency
+	hitRatio       metric.Float64ObservableGauge // Per-cacheable hit ratio (optional)
+
+	// Hit ratio source
+	statsCallback func() map[string]CacheableStats
+}
+
+// NewCacheMetrics creates a new cache metrics provider
+func NewCacheMetrics(cfg CacheMetricsConfig) *CacheMetrics {
+	return &CacheMetrics{
+		config: cfg,
+	}
+}
+
+// MetricsName returns the metrics group name
+func (m *CacheMetrics) MetricsName() string {
+	return "cache"
+}
+
+// IsMetricsEnabled returns whether metrics collection is enabled
+func (m *CacheMetrics) IsMetricsEnabled() bool {
+	return m.config.Enabled
+}
+
+// RegisterMetrics registers all cache metrics with the provided Meter
+func (m *CacheMetrics) RegisterMetrics(meter metric.Meter) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.registered {
+		return nil
+	}
+
+	m.meter = meter
+	var err error
+
+	m.hitsTotal, err = meter.Int64Counter(
+		"cache_hits_total",
+		metric.WithDescription("Total number of cache hits"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.missesTotal, err = meter.Int64Counter(
+		"cache_misses_total",
+		metric.WithDescription("Total number of cache misses"),
+		metric.WithUnit("{request}"),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.errorsTotal, err = meter.Int64Counter(
+		"cache_errors_total",
+		metric.WithDescription("Total number of cache errors"),
+		metric.WithUnit("{error}"),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.evictionsTotal, err = meter.Int64Counter(
+		"cache_evictions_total",
+		metric.WithDescription("Total number of cache entries removed"),
+		metric.WithUnit("{entry}"),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.loadDuration, err = meter.Float64Histogram(
+		"cache_load_duration_seconds",
+		metric.WithDescription("Cache loader duration"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.getDuration, err = meter.Float64Histogram(
+		"cache_get_duration_seconds",
+		metric.WithDescription("Cache store read latency"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+
+	if m.config.RecordHitRatio {
+		m.hitRatio, err = meter.Float64ObservableGauge(
+			"cache_hit_ratio",
+			metric.WithDescription("Cache hit ratio per cacheable"),
+			metric.WithFloat64Callback(m.collectHitRatio),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	m.registered = true
+	return nil
+}
+
+// collectHitRatio is the callback for the hit ratio gauge
+func (m *CacheMetrics) collectHitRatio(_ context.Context, observer metric.Float64Observer) error {
+	m.mu.RLock()
+	callback := m.statsCallback
+	m.mu.RUnlock()
+	if callback == nil {
+		return nil
+	}
+
+	for name, stats := range callback() {
+		observer.Observe(stats.HitRatio, metric.WithAttributes(attribute.String("cacheable", name)))
+	}
+	return nil
+}
+
+// SetStatsCallback sets the source of per-cacheable statistics for the hit ratio gauge
+func (m *CacheMetrics) SetStatsCallback(callback func() map[string]CacheableStats) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.statsCallback = callback
+}
+
+// RecordHit records a cache hit
+func (m *CacheMetrics) RecordHit(ctx context.Context, cacheable, store string) {
+	if !m.registered {
+		return
+	}
+	m.hitsTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("cacheable", cacheable),
+		attribute.String("store", store),
+	))
+}
+
+// RecordMiss records a cache miss
+func (m *CacheMetrics) RecordMiss(ctx context.Context, cacheable, store string) {
+	if !m.registered {
+		return
+	}
+	m.missesTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("cacheable", cacheable),
+		attribute.String("store", store),
+	))
+}
+
+// RecordError records a cache error, operation is one of get, set, delete, serialize, deserialize
+func (m *CacheMetrics) RecordError(ctx context.Context, cacheable, store, operation string) {
+	if !m.registered {
+		return
+	}
+	m.errorsTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("cacheable", cacheable),
+		attribute.String("store", store),
+		attribute.String("operation", operation),
+	))
+}
+
+// RecordEviction records removed entries, reason is one of invalidate, purge, capacity
+func (m *CacheMetrics) RecordEviction(ctx context.Context, cacheable, store, reason string, count int64) {
+	if !m.registered {
+		return
+	}
+	m.evictionsTotal.Add(ctx, count, metric.WithAttributes(
+		attribute.String("cacheable", cacheable),
+		attribute.String("store", store),
+		attribute.String("reason", reason),
+	))
+}
+
+// RecordLoad records a loader call
+func (m *CacheMetrics) RecordLoad(ctx context.Context, cacheable string, duration time.Duration, err error) {
+	if !m.registered {
+		return
+	}
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	m.loadDuration.Record(ctx, duration.Seconds(), metric.WithAttributes(
+		attribute.String("cacheable", cacheable),
+		attribute.String("result", result),
+	))
+}
+
+// RecordGet records the latency of a store read
+func (m *CacheMetrics) RecordGet(ctx context.Context, cacheable, store string, duration time.Duration) {
+	if !m.registered {
+		return
+	}
+	m.getDuration.Record(ctx, duration.Seconds(), metric.WithAttributes(
+		attribute.String("cacheable", cacheable),
+		attribute.String("store", store),
+	))
+}
+
+// IsRegistered returns whether metrics have been registered
+func (m *CacheMetrics) IsRegistered() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.registered
+}
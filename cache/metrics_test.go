@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestCacheMetrics_MetricsProvider(t *testing.T) {
+	m := NewCacheMetrics(CacheMetricsConfig{Enabled: true})
+	assert.Equal(t, "cache", m.MetricsName())
+	assert.True(t, m.IsMetricsEnabled())
+	assert.False(t, NewCacheMetrics(CacheMetricsConfig{}).IsMetricsEnabled())
+
+	// Recording before registration is a no-op
+	m.RecordHit(context.Background(), "a", "memory")
+	assert.False(t, m.IsRegistered())
+}
+
+func TestCacheMetrics_RecordedByOrchestrator(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	m := NewCacheMetrics(CacheMetricsConfig{Enabled: true, RecordHitRatio: true})
+	require.NoError(t, m.RegisterMetrics(provider.Meter("test")))
+	require.NoError(t, m.RegisterMetrics(provider.Meter("test")), "idempotent registration")
+
+	cfg := &Config{
+		Enabled:      true,
+		DefaultStore: "memory",
+		Cacheables: []CacheableConfig{
+			{Name: "user:get", KeyPattern: "user:{0}", Store: "memory", Enabled: true},
+			{Name: "user:fail", KeyPattern: "fail:{0}", Store: "memory", Enabled: true},
+		},
+	}
+	o := NewOrchestrator(cfg, nil, nil)
+	o.RegisterStore("memory", NewMemoryStore("memory", 100))
+	o.SetMetrics(m)
+	o.RegisterLoader("user:get", func(ctx context.Context, args ...any) (any, error) {
+		return "u", nil
+	})
+	o.RegisterLoader("user:fail", func(ctx context.Context, args ...any) (any, error) {
+		return nil, errors.New("db down")
+	})
+
+	ctx := context.Background()
+	_, _ = o.Call(ctx, "user:get", 1)
+	_, _ = o.Call(ctx, "user:get", 1)
+	_, _ = o.Call(ctx, "user:get", 1)
+	_, _ = o.Call(ctx, "user:fail", 1)
+	require.NoError(t, o.Invalidate(ctx, "user:get", 1))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	values := collectSums(rm)
+
+	assert.Equal(t, int64(2), values["cache_hits_total"])
+	assert.Equal(t, int64(2), values["cache_misses_total"])
+	assert.Equal(t, int64(1), values["cache_evictions_total"])
+	assert.Contains(t, values, "cache_load_duration_seconds")
+	assert.Contains(t, values, "cache_get_duration_seconds")
+
+	ratio := findGauge(rm, "cache_hit_ratio", attribute.String("cacheable", "user:get"))
+	assert.InDelta(t, 2.0/3.0, ratio, 0.001)
+
+	stats := o.Stats()
+	assert.Equal(t, int64(3), stats.ByName["user:get"])
+	assert.Equal(t, int64(1), stats.ByCacheable["user:fail"].Loads)
+	assert.InDelta(t, 2.0/3.0, stats.ByCacheable["user:get"].HitRatio, 0.001)
+}
+
+func TestCacheMetrics_CapacityEviction(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	m := NewCacheMetrics(CacheMetricsConfig{Enabled: true})
+	require.NoError(t, m.RegisterMetrics(provider.Meter("test")))
+
+	cfg := &Config{
+		Enabled: true,
+		Cacheables: []CacheableConfig{
+			{Name: "item", KeyPattern: "item:{0}", Store: "memory", Enabled: true, TTL: time.Minute},
+		},
+	}
+	o := NewOrchestrator(cfg, nil, nil)
+	o.SetMetrics(m)
+	o.RegisterStore("memory", NewMemoryStore("memory", 1))
+	o.RegisterLoader("item", func(ctx context.Context, args ...any) (any, error) {
+		return args[0], nil
+	})
+
+	ctx := context.Background()
+	_, _ = o.Call(ctx, "item", 1)
+	_, _ = o.Call(ctx, "item", 2)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	assert.Equal(t, int64(1), collectSums(rm)["cache_evictions_total"])
+}
+
+// collectSums sums counters and counts histogram observations by metric name
+func collectSums(rm metricdata.ResourceMetrics) map[string]int64 {
+	values := make(map[string]int64)
+	for _, sm := range rm.ScopeMetrics {
+		for _, metric := range sm.Metrics {
+			switch data := metric.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					values[metric.Name] += dp.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					values[metric.Name] += int64(dp.Count)
+				}
+			}
+		}
+	}
+	return values
+}
+
+// findGauge returns the gauge value carrying the attribute
+func findGauge(rm metricdata.ResourceMetrics, name string, attr attribute.KeyValue) float64 {
+	for _, sm := range rm.ScopeMetrics {
+		for _, metric := range sm.Metrics {
+			if metric.Name != name {
+				continue
+			}
+			if gauge, ok := metric.Data.(metricdata.Gauge[float64]); ok {
+				for _, dp := range gauge.DataPoints {
+					if v, ok := dp.Attributes.Value(attr.Key); ok && v == attr.Value {
+						return dp.Value
+					}
+				}
+			}
+		}
+	}
+	return -1
+}
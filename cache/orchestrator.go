@@ -2,10 +2,12 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/KOMKZ/go-yogan-framework/event"
 	"github.com/KOMKZ/go-yogan-framework/logger"
@@ -38,6 +40,16 @@ type DefaultOrchestrator struct {
 	misses      int64
 	invalidates int64
 	errors      int64
+	counters    map[string]*cacheableCounters
+	metrics     *CacheMetrics
+
+	// Distributed lease
+	leaser           Leaser
+	leaseAcquired    int64
+	leaseWaitHits    int64
+	leaseStaleServed int64
+	leaseTimeouts    int64
+	leaseErrors      int64
 }
 
 // NewOrchestrator creates the orchestrator center
@@ -49,6 +61,7 @@ func NewOrchestrator(cfg *Config, dispatcher event.Dispatcher, log *logger.CtxZa
 		stores:     make(map[string]Store),
 		loaders:    make(map[string]LoaderFunc),
 		cacheables: make(map[string]*CacheableConfig),
+		counters:   make(map[string]*cacheableCounters),
 		serializer: NewJSONSerializer(),
 		dispatcher: dispatcher,
 		logger:     log,
@@ -58,6 +71,7 @@ func NewOrchestrator(cfg *Config, dispatcher event.Dispatcher, log *logger.CtxZa
 	for i := range cfg.Cacheables {
 		c := &cfg.Cacheables[i]
 		o.cacheables[c.Name] = c
+		o.counters[c.Name] = &cacheableCounters{}
 	}
 
 	// subscription expiration event
@@ -83,6 +97,7 @@ func (o *DefaultOrchestrator) RegisterStore(name string, store Store) {
 	o.mu.Lock()
 	defer o.mu.Unlock()
 	o.stores[name] = store
+	o.watchEvictions(name, store)
 	if o.logger != nil {
 		o.logger.Debug("cache store registered", zap.String("name", name))
 	}
@@ -120,10 +135,11 @@ func (o *DefaultOrchestrator) Call(ctx context.Context, name string, args ...any
 	}
 
 	// Retrieve storage backend
-	store, err := o.getStoreForCacheable(config)
+	storeName := o.storeNameFor(config)
+	store, err := o.GetStore(storeName)
 	if err != nil {
 		// Degraded to direct call when storage is unavailable
-		atomic.AddInt64(&o.errors, 1)
+		o.recordError(ctx, name, storeName, "store")
 		if o.logger != nil {
 			o.logger.Warn("cache store unavailable, fallback to loader",
 				zap.String("name", name),
@@ -137,71 +153,192 @@ func (o *DefaultOrchestrator) Call(ctx context.Context, name string, args ...any
 	key := o.buildKey(config.KeyPattern, args...)
 
 	// 1. Try to get from cache
+	start := time.Now()
 	data, err := store.Get(ctx, key)
+	if o.metrics != nil {
+		o.metrics.RecordGet(ctx, name, storeName, time.Since(start))
+	}
 	if err == nil {
 		// cache hit
 		var result any
 		if err := o.serializer.Deserialize(data, &result); err == nil {
-			atomic.AddInt64(&o.hits, 1)
+			o.recordHit(ctx, name, storeName)
 			if o.logger != nil {
 				o.logger.Debug("cache hit", zap.String("name", name), zap.String("key", key))
 			}
 			return result, nil
 		}
 		// Deserialization failed, treat as miss
-		atomic.AddInt64(&o.errors, 1)
+		o.recordError(ctx, name, storeName, "deserialize")
+	} else if !errors.Is(err, ErrCacheMiss) {
+		o.recordError(ctx, name, storeName, "get")
 	}
 
 	// 2. Cache miss, use singleflight to prevent penetration hits
-	atomic.AddInt64(&o.misses, 1)
+	o.recordMiss(ctx, name, storeName)
 	if o.logger != nil {
 		o.logger.Debug("cache miss", zap.String("name", name), zap.String("key", key))
 	}
 
 	result, err, _ := o.sf.Do(key, func() (any, error) {
 		// Double-check: Recheck cache
-		if data, err := store.Get(ctx, key); err == nil {
-			var result any
-			if err := o.serializer.Deserialize(data, &result); err == nil {
-				return result, nil
-			}
+		if result, ok := o.readValue(ctx, store, key); ok {
+			return result, nil
 		}
 
-		// call loader
-		result, err := loader(ctx, args...)
-		if err != nil {
-			return nil, err
+		// Cross-instance protection: only the lease holder calls the loader
+		if lease := o.leaseConfigFor(config); lease != nil {
+			return o.loadWithLease(ctx, name, config, lease, store, key, loader, args)
 		}
 
-		// Write to cache
-		ttl := config.TTL
-		if ttl <= 0 {
-			ttl = o.config.DefaultTTL
+		return o.loadAndStore(ctx, name, config, nil, store, key, loader, args)
+	})
+
+	return result, err
+}
+
+// readValue reads and deserializes a cached value
+func (o *DefaultOrchestrator) readValue(ctx context.Context, store Store, key string) (any, bool) {
+	data, err := store.Get(ctx, key)
+	if err != nil {
+		return nil, false
+	}
+	var result any
+	if err := o.serializer.Deserialize(data, &result); err != nil {
+		return nil, false
+	}
+	return result, true
+}
+
+// loadAndStore calls the loader and writes the result to the cache
+func (o *DefaultOrchestrator) loadAndStore(ctx context.Context, name string, config *CacheableConfig, lease *LeaseConfig, store Store, key string, loader LoaderFunc, args []any) (any, error) {
+	start := time.Now()
+	result, err := loader(ctx, args...)
+	if counters, ok := o.counters[name]; ok {
+		atomic.AddInt64(&counters.loads, 1)
+	}
+	if o.metrics != nil {
+		o.metrics.RecordLoad(ctx, name, time.Since(start), err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Write to cache
+	storeName := o.storeNameFor(config)
+	ttl := config.TTL
+	if ttl <= 0 {
+		ttl = o.config.DefaultTTL
+	}
+	data, serErr := o.serializer.Serialize(result)
+	if serErr != nil {
+		o.recordError(ctx, name, storeName, "serialize")
+		if o.logger != nil {
+			o.logger.Warn("cache serialize failed", zap.String("name", name), zap.Error(serErr))
 		}
-		data, serErr := o.serializer.Serialize(result)
-		if serErr != nil {
-			atomic.AddInt64(&o.errors, 1)
-			if o.logger != nil {
-				o.logger.Warn("cache serialize failed", zap.String("name", name), zap.Error(serErr))
-			}
-		} else {
-			if setErr := store.Set(ctx, key, data, ttl); setErr != nil {
-				atomic.AddInt64(&o.errors, 1)
+		return result, nil
+	}
+	if setErr := store.Set(ctx, key, data, ttl); setErr != nil {
+		o.recordError(ctx, name, storeName, "set")
+		if o.logger != nil {
+			o.logger.Warn("cache set failed", zap.String("name", name), zap.Error(setErr))
+		}
+	}
+	// Keep a longer-lived copy that waiting instances may serve while another instance reloads
+	if lease != nil && lease.Strategy == LeaseStrategyStale {
+		if setErr := store.Set(ctx, key+staleKeySuffix, data, ttl+lease.StaleTTL); setErr != nil {
+			o.recordError(ctx, name, storeName, "set")
+		}
+	}
+
+	return result, nil
+}
+
+// loadWithLease load under a distributed lease
+// The holder loads the value; other instances serve stale data or poll the store until the holder finishes
+func (o *DefaultOrchestrator) loadWithLease(ctx context.Context, name string, config *CacheableConfig, lease *LeaseConfig, store Store, key string, loader LoaderFunc, args []any) (any, error) {
+	token, acquired, err := o.leaser.Acquire(ctx, key, lease.TTL)
+	if err != nil {
+		// Lease backend unavailable, degrade to local load
+		atomic.AddInt64(&o.leaseErrors, 1)
+		if o.logger != nil {
+			o.logger.Warn("cache lease acquire failed, fallback to loader", zap.String("name", name), zap.Error(err))
+		}
+		return o.loadAndStore(ctx, name, config, lease, store, key, loader, args)
+	}
+
+	if acquired {
+		atomic.AddInt64(&o.leaseAcquired, 1)
+		defer func() {
+			if err := o.leaser.Release(context.WithoutCancel(ctx), key, token); err != nil {
+				atomic.AddInt64(&o.leaseErrors, 1)
 				if o.logger != nil {
-					o.logger.Warn("cache set failed", zap.String("name", name), zap.Error(setErr))
+					o.logger.Warn("cache lease release failed", zap.String("name", name), zap.Error(err))
 				}
 			}
+		}()
+		return o.loadAndStore(ctx, name, config, lease, store, key, loader, args)
+	}
+
+	// Lease held by another instance
+	if lease.Strategy == LeaseStrategyStale {
+		if result, ok := o.readValue(ctx, store, key+staleKeySuffix); ok {
+			atomic.AddInt64(&o.leaseStaleServed, 1)
+			return result, nil
 		}
+	}
 
-		return result, nil
-	})
+	timeout := time.NewTimer(lease.WaitTimeout)
+	defer timeout.Stop()
+	ticker := time.NewTicker(lease.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeout.C:
+			// Holder too slow or gone, load locally
+			atomic.AddInt64(&o.leaseTimeouts, 1)
+			if o.logger != nil {
+				o.logger.Debug("cache lease wait timeout", zap.String("name", name), zap.String("key", key))
+			}
+			return o.loadAndStore(ctx, name, config, lease, store, key, loader, args)
+		case <-ticker.C:
+			if result, ok := o.readValue(ctx, store, key); ok {
+				atomic.AddInt64(&o.leaseWaitHits, 1)
+				return result, nil
+			}
+		}
+	}
+}
 
-	return result, err
+// leaseConfigFor returns the effective lease configuration, nil when the lease does not apply
+func (o *DefaultOrchestrator) leaseConfigFor(config *CacheableConfig) *LeaseConfig {
+	if o.leaser == nil {
+		return nil
+	}
+	lease := &o.config.Lease
+	if config.Lease != nil {
+		lease = config.Lease
+	}
+	if !lease.Enabled {
+		return nil
+	}
+	return lease
+}
+
+// SetLeaser set the distributed load lease
+func (o *DefaultOrchestrator) SetLeaser(l Leaser) {
+	o.leaser = l
 }
 
 // Invalidate specified cache manually
 func (o *DefaultOrchestrator) Invalidate(ctx context.Context, name string, args ...any) error {
+	o.mu.RLock()
 	config, ok := o.cacheables[name]
+	variants := ok && config.Variants
+	o.mu.RUnlock()
 	if !ok {
 		return ErrCacheableNotFound.WithMsgf("缓存项未配置: %s", name)
 	}
@@ -215,8 +352,19 @@ func (o *DefaultOrchestrator) Invalidate(ctx context.Context, name string, args
 	if err := store.Delete(ctx, key); err != nil {
 		return err
 	}
+	if variants {
+		if err := store.DeleteByPrefix(ctx, key+":"); err != nil {
+			return err
+		}
+	}
+	if lease := o.leaseConfigFor(config); lease != nil && lease.Strategy == LeaseStrategyStale {
+		// Invalidated data must not be served as stale
+		if err := store.Delete(ctx, key+staleKeySuffix); err != nil {
+			return err
+		}
+	}
 
-	atomic.AddInt64(&o.invalidates, 1)
+	o.recordEviction(ctx, name, o.storeNameFor(config), "invalidate")
 	if o.logger != nil {
 		o.logger.Info("cache invalidated", zap.String("name", name), zap.String("key", key))
 	}
@@ -239,31 +387,183 @@ func (o *DefaultOrchestrator) InvalidateByPattern(ctx context.Context, name stri
 		return err
 	}
 
-	atomic.AddInt64(&o.invalidates, 1)
+	o.recordEviction(ctx, name, o.storeNameFor(config), "invalidate")
 	if o.logger != nil {
 		o.logger.Info("cache invalidated by pattern", zap.String("name", name), zap.String("pattern", pattern))
 	}
 	return nil
 }
 
+// ResolveKey returns the storage backend, key and TTL of a cache item
+// Used by callers that manage raw entries themselves (e.g., HTTP response caching) while sharing the invalidation rules
+func (o *DefaultOrchestrator) ResolveKey(name string, args ...any) (Store, string, time.Duration, error) {
+	o.mu.RLock()
+	config, ok := o.cacheables[name]
+	o.mu.RUnlock()
+	if !ok {
+		return nil, "", 0, ErrCacheableNotFound.WithMsgf("缓存项未配置: %s", name)
+	}
+	if !config.Enabled || !o.config.Enabled {
+		return nil, "", 0, ErrCacheableDisabled.WithMsgf("缓存项已禁用: %s", name)
+	}
+
+	store, err := o.getStoreForCacheable(config)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	ttl := config.TTL
+	if ttl <= 0 {
+		ttl = o.config.DefaultTTL
+	}
+	return store, o.buildKey(config.KeyPattern, args...), ttl, nil
+}
+
+// EnableVariants marks a cache item as storing variants under its key
+func (o *DefaultOrchestrator) EnableVariants(name string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	config, ok := o.cacheables[name]
+	if !ok {
+		return ErrCacheableNotFound.WithMsgf("缓存项未配置: %s", name)
+	}
+	config.Variants = true
+	return nil
+}
+
 // Get cache statistics
 func (o *DefaultOrchestrator) Stats() *CacheStats {
+	byCacheable := o.cacheableStats()
+	byName := make(map[string]int64, len(byCacheable))
+	for name, stats := range byCacheable {
+		byName[name] = stats.Hits + stats.Misses
+	}
+
 	return &CacheStats{
 		Hits:        atomic.LoadInt64(&o.hits),
 		Misses:      atomic.LoadInt64(&o.misses),
 		Invalidates: atomic.LoadInt64(&o.invalidates),
 		Errors:      atomic.LoadInt64(&o.errors),
-		ByName:      make(map[string]int64),
+		ByName:      byName,
+		ByCacheable: byCacheable,
+
+		LeaseAcquired:    atomic.LoadInt64(&o.leaseAcquired),
+		LeaseWaitHits:    atomic.LoadInt64(&o.leaseWaitHits),
+		LeaseStaleServed: atomic.LoadInt64(&o.leaseStaleServed),
+		LeaseTimeouts:    atomic.LoadInt64(&o.leaseTimeouts),
+		LeaseErrors:      atomic.LoadInt64(&o.leaseErrors),
+	}
+}
+
+// cacheableStats snapshot of per-cacheable statistics
+func (o *DefaultOrchestrator) cacheableStats() map[string]CacheableStats {
+	result := make(map[string]CacheableStats, len(o.counters))
+	for name, counters := range o.counters {
+		stats := CacheableStats{
+			Hits:   atomic.LoadInt64(&counters.hits),
+			Misses: atomic.LoadInt64(&counters.misses),
+			Errors: atomic.LoadInt64(&counters.errors),
+			Loads:  atomic.LoadInt64(&counters.loads),
+		}
+		if total := stats.Hits + stats.Misses; total > 0 {
+			stats.HitRatio = float64(stats.Hits) / float64(total)
+		}
+		result[name] = stats
+	}
+	return result
+}
+
+// SetMetrics set OTel metrics, hooks store evictions and the hit ratio gauge
+func (o *DefaultOrchestrator) SetMetrics(m *CacheMetrics) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.metrics = m
+	if m == nil {
+		return
+	}
+	m.SetStatsCallback(o.cacheableStats)
+	for name, store := range o.stores {
+		o.watchEvictions(name, store)
+	}
+}
+
+// watchEvictions reports evictions made by the store itself (must hold lock)
+func (o *DefaultOrchestrator) watchEvictions(storeName string, store Store) {
+	notifier, ok := store.(EvictionNotifier)
+	if !ok || o.metrics == nil {
+		return
+	}
+	metrics := o.metrics
+	notifier.OnEvict(func(key string) {
+		metrics.RecordEviction(context.Background(), o.cacheableForKey(key), storeName, "capacity", 1)
+	})
+}
+
+// cacheableForKey finds the cache item owning a key by the longest static key prefix
+func (o *DefaultOrchestrator) cacheableForKey(key string) string {
+	var owner string
+	var longest int
+	for name, config := range o.cacheables {
+		prefix := keyPrefix(config.KeyPattern)
+		if prefix != "" && strings.HasPrefix(key, prefix) && len(prefix) > longest {
+			owner, longest = name, len(prefix)
+		}
+	}
+	return owner
+}
+
+// recordHit update hit statistics
+func (o *DefaultOrchestrator) recordHit(ctx context.Context, name, storeName string) {
+	atomic.AddInt64(&o.hits, 1)
+	if counters, ok := o.counters[name]; ok {
+		atomic.AddInt64(&counters.hits, 1)
+	}
+	if o.metrics != nil {
+		o.metrics.RecordHit(ctx, name, storeName)
 	}
 }
 
+// recordMiss update miss statistics
+func (o *DefaultOrchestrator) recordMiss(ctx context.Context, name, storeName string) {
+	atomic.AddInt64(&o.misses, 1)
+	if counters, ok := o.counters[name]; ok {
+		atomic.AddInt64(&counters.misses, 1)
+	}
+	if o.metrics != nil {
+		o.metrics.RecordMiss(ctx, name, storeName)
+	}
+}
+
+// recordError update error statistics
+func (o *DefaultOrchestrator) recordError(ctx context.Context, name, storeName, operation string) {
+	atomic.AddInt64(&o.errors, 1)
+	if counters, ok := o.counters[name]; ok {
+		atomic.AddInt64(&counters.errors, 1)
+	}
+	if o.metrics != nil {
+		o.metrics.RecordError(ctx, name, storeName, operation)
+	}
+}
+
+// recordEviction update invalidation statistics
+func (o *DefaultOrchestrator) recordEviction(ctx context.Context, name, storeName, reason string) {
+	atomic.AddInt64(&o.invalidates, 1)
+	if o.metrics != nil {
+		o.metrics.RecordEviction(ctx, name, storeName, reason, 1)
+	}
+}
+
+// storeNameFor returns the storage backend name of a cache item
+func (o *DefaultOrchestrator) storeNameFor(config *CacheableConfig) string {
+	if config.Store == "" {
+		return o.config.DefaultStore
+	}
+	return config.Store
+}
+
 // getStoreForCacheable Get the storage backend for a cache item
 func (o *DefaultOrchestrator) getStoreForCacheable(config *CacheableConfig) (Store, error) {
-	storeName := config.Store
-	if storeName == "" {
-		storeName = o.config.DefaultStore
-	}
-	return o.GetStore(storeName)
+	return o.GetStore(o.storeNameFor(config))
 }
 
 // buildKey to construct cache key
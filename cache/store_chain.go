@@ -88,6 +88,23 @@ func (s *ChainStore) DeleteByPrefix(ctx context.Context, prefix string) error {
 	return lastErr
 }
 
+// DeleteMatching deletes the matching keys from all layers
+// Every layer must implement KeyMatchDeleter
+func (s *ChainStore) DeleteMatching(ctx context.Context, prefix string, match func(key string) bool) error {
+	var lastErr error
+	for _, store := range s.stores {
+		deleter, ok := store.(KeyMatchDeleter)
+		if !ok {
+			lastErr = ErrConfigInvalid.WithMsgf("存储 %s 不支持按键匹配删除", store.Name())
+			continue
+		}
+		if err := deleter.DeleteMatching(ctx, prefix, match); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
 // Exists Check if there is any existence (existence in any layer is sufficient)
 func (s *ChainStore) Exists(ctx context.Context, key string) bool {
 	for _, store := range s.stores {
@@ -98,6 +115,20 @@ func (s *ChainStore) Exists(ctx context.Context, key string) bool {
 	return false
 }
 
+// TTL returns the remaining lifetime from the first layer holding the key
+func (s *ChainStore) TTL(ctx context.Context, key string) (time.Duration, error) {
+	for _, store := range s.stores {
+		inspector, ok := store.(KeyInspector)
+		if !ok {
+			continue
+		}
+		if ttl, err := inspector.TTL(ctx, key); err == nil {
+			return ttl, nil
+		}
+	}
+	return 0, ErrCacheMiss
+}
+
 // Close all layers
 func (s *ChainStore) Close() error {
 	var lastErr error
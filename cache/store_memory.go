@@ -13,6 +13,7 @@ type MemoryStore struct {
 	data    map[string]*memoryItem
 	mu      sync.RWMutex
 	maxSize int
+	onEvict func(key string)
 }
 
 // memoryItem cache item
@@ -104,6 +105,19 @@ func (s *MemoryStore) DeleteByPrefix(ctx context.Context, prefix string) error {
 	return nil
 }
 
+// DeleteMatching deletes the keys with the prefix accepted by match
+func (s *MemoryStore) DeleteMatching(ctx context.Context, prefix string, match func(key string) bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key := range s.data {
+		if strings.HasPrefix(key, prefix) && match(key) {
+			delete(s.data, key)
+		}
+	}
+	return nil
+}
+
 // Exists check if Key exists
 func (s *MemoryStore) Exists(ctx context.Context, key string) bool {
 	s.mu.RLock()
@@ -130,6 +144,32 @@ func (s *MemoryStore) Close() error {
 	return nil
 }
 
+// TTL returns the remaining lifetime of a key
+func (s *MemoryStore) TTL(ctx context.Context, key string) (time.Duration, error) {
+	s.mu.RLock()
+	item, ok := s.data[key]
+	s.mu.RUnlock()
+
+	if !ok {
+		return 0, ErrCacheMiss
+	}
+	if item.expiresAt.IsZero() {
+		return -1, nil
+	}
+	ttl := time.Until(item.expiresAt)
+	if ttl <= 0 {
+		return 0, ErrCacheMiss
+	}
+	return ttl, nil
+}
+
+// OnEvict registers a callback invoked when an entry is evicted for capacity
+func (s *MemoryStore) OnEvict(fn func(key string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onEvict = fn
+}
+
 // Returns the current cache size
 func (s *MemoryStore) Size() int {
 	s.mu.RLock()
@@ -152,6 +192,9 @@ func (s *MemoryStore) evictOne() {
 
 	if oldest != "" {
 		delete(s.data, oldest)
+		if s.onEvict != nil {
+			s.onEvict(oldest)
+		}
 	}
 }
 
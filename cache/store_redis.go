@@ -101,6 +101,33 @@ func (s *RedisStore) DeleteByPrefix(ctx context.Context, prefix string) error {
 	return nil
 }
 
+// DeleteMatching deletes the keys with the prefix accepted by match (keys are passed without the store key prefix)
+func (s *RedisStore) DeleteMatching(ctx context.Context, prefix string, match func(key string) bool) error {
+	var cursor uint64
+	var keys []string
+	for {
+		batch, next, err := s.client.Scan(ctx, cursor, s.buildKey(prefix)+"*", 100).Result()
+		if err != nil {
+			return ErrStoreDelete.Wrap(err)
+		}
+		for _, fullKey := range batch {
+			if match(strings.TrimPrefix(fullKey, s.keyPrefix)) {
+				keys = append(keys, fullKey)
+			}
+		}
+		if cursor = next; cursor == 0 {
+			break
+		}
+	}
+
+	if len(keys) > 0 {
+		if err := s.client.Del(ctx, keys...).Err(); err != nil {
+			return ErrStoreDelete.Wrap(err)
+		}
+	}
+	return nil
+}
+
 // Exists check if Key exists
 func (s *RedisStore) Exists(ctx context.Context, key string) bool {
 	fullKey := s.buildKey(key)
@@ -111,6 +138,22 @@ func (s *RedisStore) Exists(ctx context.Context, key string) bool {
 	return n > 0
 }
 
+// TTL returns the remaining lifetime of a key
+func (s *RedisStore) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := s.client.PTTL(ctx, s.buildKey(key)).Result()
+	if err != nil {
+		return 0, ErrStoreGet.Wrap(err)
+	}
+	// -2: key does not exist, -1: no expiration
+	switch ttl {
+	case -2:
+		return 0, ErrCacheMiss
+	case -1:
+		return -1, nil
+	}
+	return ttl, nil
+}
+
 // Close storage
 func (s *RedisStore) Close() error {
 	// The Redis client is managed externally, so we do not close it here.
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -180,6 +181,32 @@ func TestRedisStore_DeleteByPattern(t *testing.T) {
 	})
 }
 
+func TestRedisStore_DeleteMatching(t *testing.T) {
+	client := getTestRedisClient(t)
+	defer client.Close()
+
+	store := NewRedisStore("test-redis", client, "cache:matching:")
+	ctx := context.Background()
+
+	defer store.DeleteByPrefix(ctx, "")
+
+	store.Set(ctx, "user:1", []byte("a"), time.Minute)
+	store.Set(ctx, "user:profile:1", []byte("b"), time.Minute)
+
+	err := store.DeleteMatching(ctx, "user:", func(key string) bool {
+		return !strings.HasPrefix(key, "user:profile:")
+	})
+	if err != nil {
+		t.Errorf("DeleteMatching() error = %v", err)
+	}
+	if store.Exists(ctx, "user:1") {
+		t.Error("Matching key should be deleted")
+	}
+	if !store.Exists(ctx, "user:profile:1") {
+		t.Error("Key rejected by match should still exist")
+	}
+}
+
 func TestRedisStore_Close(t *testing.T) {
 	client := getTestRedisClient(t)
 	defer client.Close()
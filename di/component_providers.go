@@ -2,6 +2,7 @@ package di
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/KOMKZ/go-yogan-framework/auth"
 	"github.com/KOMKZ/go-yogan-framework/breaker"
@@ -9,6 +10,7 @@ import (
 	"github.com/KOMKZ/go-yogan-framework/config"
 	"github.com/KOMKZ/go-yogan-framework/database"
 	"github.com/KOMKZ/go-yogan-framework/event"
+	"github.com/KOMKZ/go-yogan-framework/governance"
 	"github.com/KOMKZ/go-yogan-framework/grpc"
 	"github.com/KOMKZ/go-yogan-framework/health"
 	"github.com/KOMKZ/go-yogan-framework/jwt"
@@ -16,6 +18,7 @@ import (
 	"github.com/KOMKZ/go-yogan-framework/limiter"
 	"github.com/KOMKZ/go-yogan-framework/logger"
 	"github.com/KOMKZ/go-yogan-framework/redis"
+	"github.com/KOMKZ/go-yogan-framework/retry"
 	"github.com/KOMKZ/go-yogan-framework/telemetry"
 	goredis "github.com/redis/go-redis/v9"
 	"github.com/samber/do/v2"
@@ -308,7 +311,17 @@ func ProvideKafkaManager(i do.Injector) (*kafka.Manager, error) {
 		return nil, nil // Kafka brokers not configured
 	}
 
-	return kafka.NewManager(cfg, log)
+	mgr, err := kafka.NewManager(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+
+	// Named retry policies of the consumers (kafka.consumers.*.retry_policy)
+	if registry, _ := do.Invoke[*retry.Registry](i); registry != nil {
+		mgr.SetRetryRegistry(registry)
+	}
+
+	return mgr, nil
 }
 
 // ============================================
@@ -427,6 +440,10 @@ func ProvideCacheOrchestrator(i do.Injector) (*cache.DefaultOrchestrator, error)
 		return nil, nil // Cache not enabled
 	}
 
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
 	log, _ := do.Invoke[*logger.CtxZapLogger](i)
 	if log == nil {
 		log = logger.GetLogger("yogan")
@@ -435,7 +452,22 @@ func ProvideCacheOrchestrator(i do.Injector) (*cache.DefaultOrchestrator, error)
 	// Try to get the Event Dispatcher
 	dispatcher, _ := do.Invoke[event.Dispatcher](i)
 
-	return cache.NewOrchestrator(&cfg, dispatcher, log), nil
+	orchestrator := cache.NewOrchestrator(&cfg, dispatcher, log)
+
+	// Distributed load lease requires Redis
+	if cfg.Lease.Instance != "" {
+		redisMgr, _ := do.Invoke[*redis.Manager](i)
+		if redisMgr == nil {
+			return nil, fmt.Errorf("cache lease: redis is not configured")
+		}
+		client := redisMgr.Client(cfg.Lease.Instance)
+		if client == nil {
+			return nil, fmt.Errorf("cache lease: redis instance %s not found", cfg.Lease.Instance)
+		}
+		orchestrator.SetLeaser(cache.NewRedisLeaser(client, ""))
+	}
+
+	return orchestrator, nil
 }
 
 // ============================================
@@ -502,7 +534,21 @@ func ProvideGRPCServer(i do.Injector) (*grpc.Server, error) {
 		log = logger.GetLogger("yogan")
 	}
 
-	return grpc.NewServer(cfg.Server, log), nil
+	server := grpc.NewServer(cfg.Server, log)
+
+	// Inbound rate limiting (optional)
+	if cfg.Server.RateLimit.Enabled {
+		if limiterMgr, _ := do.Invoke[*limiter.Manager](i); limiterMgr != nil {
+			server.SetLimiter(limiterMgr)
+		}
+		if cfg.Server.RateLimit.CallerKey == "jwt_subject" {
+			if tokenManager, _ := do.Invoke[jwt.TokenManager](i); tokenManager != nil {
+				server.SetTokenManager(tokenManager)
+			}
+		}
+	}
+
+	return server, nil
 }
 
 // ProvideGRPCClientManager creates an independent Provider for grpc.ClientManager
@@ -521,13 +567,64 @@ func ProvideGRPCClientManager(i do.Injector) (*grpc.ClientManager, error) {
 	if len(cfg.Clients) == 0 {
 		return nil, nil // gRPC client not configured
 	}
+	for name, clientCfg := range cfg.Clients {
+		if err := clientCfg.Hedging.Validate(); err != nil {
+			return nil, fmt.Errorf("grpc client %s: %w", name, err)
+		}
+		if err := clientCfg.Routing.Validate(); err != nil {
+			return nil, fmt.Errorf("grpc client %s: %w", name, err)
+		}
+		if err := clientCfg.Outlier.Validate(); err != nil {
+			return nil, fmt.Errorf("grpc client %s: %w", name, err)
+		}
+	}
 
 	log, _ := do.Invoke[*logger.CtxZapLogger](i)
 	if log == nil {
 		log = logger.GetLogger("yogan")
 	}
 
-	return grpc.NewClientManager(cfg.Clients, log), nil
+	mgr := grpc.NewClientManager(cfg.Clients, log)
+
+	// Named retry policies of the clients (grpc.clients.*.retry_policy)
+	if registry, _ := do.Invoke[*retry.Registry](i); registry != nil {
+		mgr.SetRetryRegistry(registry)
+	}
+
+	// Static / file discovery of the clients (discovery_mode: static | file)
+	if err := setupStaticDiscovery(loader, mgr, cfg.Clients, log); err != nil {
+		return nil, err
+	}
+
+	return mgr, nil
+}
+
+// setupStaticDiscovery creates the discoverers of the static and file modes used by the clients
+// Both read governance.static, the file discoverer polls the file for the lifetime of the application.
+func setupStaticDiscovery(loader *config.Loader, mgr *grpc.ClientManager, clients map[string]grpc.ClientConfig, log *logger.CtxZapLogger) error {
+	modes := make(map[string]bool)
+	for _, clientCfg := range clients {
+		if mode := clientCfg.GetMode(); mode == governance.RegistryTypeStatic || mode == governance.RegistryTypeFile {
+			modes[mode] = true
+		}
+	}
+	if len(modes) == 0 {
+		return nil
+	}
+
+	var govCfg governance.Config
+	if err := loader.GetViper().UnmarshalKey("governance", &govCfg); err != nil {
+		return fmt.Errorf("read governance config: %w", err)
+	}
+	for mode := range modes {
+		govCfg.RegistryType = mode
+		discovery, err := governance.NewServiceDiscovery(govCfg, log)
+		if err != nil {
+			return fmt.Errorf("create %s discovery: %w", mode, err)
+		}
+		mgr.SetModeDiscovery(mode, discovery)
+	}
+	return nil
 }
 
 // ============================================
@@ -604,5 +701,56 @@ func ProvideBreakerManager(i do.Injector) (*breaker.Manager, error) {
 		log = logger.GetLogger("yogan")
 	}
 
-	return breaker.NewManagerWithLogger(cfg, log)
+	mgr, err := breaker.NewManagerWithLogger(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+
+	// Shared state across instances requires Redis
+	if cfg.StateStore == "redis" {
+		var client *goredis.Client
+		if redisMgr, _ := do.Invoke[*redis.Manager](i); redisMgr != nil {
+			client = redisMgr.Client(cfg.Redis.Instance)
+		}
+		if client == nil {
+			mgr.Close()
+			return nil, fmt.Errorf("breaker redis instance %q not found", cfg.Redis.Instance)
+		}
+		if err := mgr.SetStateBackend(breaker.NewRedisStateBackend(client, cfg.Redis.KeyPrefix)); err != nil {
+			mgr.Close()
+			return nil, err
+		}
+	}
+
+	return mgr, nil
+}
+
+// ============================================
+// Retry Component Provider
+// Dependencies: Config
+// ============================================
+
+// ProvideRetryRegistry creates an independent Provider for retry.Registry
+// The named policies of retry.policies are shared by the HTTP clients, gRPC clients and Kafka consumers.
+func ProvideRetryRegistry(i do.Injector) (*retry.Registry, error) {
+	loader, err := do.Invoke[*config.Loader](i)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read retry configuration
+	if !loader.IsSet("retry") {
+		return nil, nil // retry not configured
+	}
+
+	var cfg retry.RegistryConfig
+	if err := loader.GetViper().UnmarshalKey("retry", &cfg); err != nil {
+		return nil, nil
+	}
+
+	if len(cfg.Policies) == 0 {
+		return nil, nil
+	}
+
+	return retry.NewRegistry(cfg)
 }
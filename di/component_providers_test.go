@@ -14,6 +14,7 @@ import (
 	"github.com/KOMKZ/go-yogan-framework/limiter"
 	"github.com/KOMKZ/go-yogan-framework/logger"
 	"github.com/KOMKZ/go-yogan-framework/redis"
+	"github.com/KOMKZ/go-yogan-framework/retry"
 	"github.com/samber/do/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -687,3 +688,39 @@ func TestProvideGRPCClientManager(t *testing.T) {
 		}
 	})
 }
+
+// ============================================
+// Retry Provider test
+// ============================================
+
+// TestProvideRetryRegistry test retry Registry Provider
+func TestProvideRetryRegistry(t *testing.T) {
+	t.Run("without config loader", func(t *testing.T) {
+		injector := do.New()
+		defer injector.Shutdown()
+
+		do.Provide(injector, ProvideRetryRegistry)
+
+		// Without config.Loader, an error should be reported
+		_, err := do.Invoke[*retry.Registry](injector)
+		assert.Error(t, err)
+	})
+
+	t.Run("with config but no retry policies", func(t *testing.T) {
+		injector := do.New()
+		defer injector.Shutdown()
+
+		opts := ConfigOptions{
+			ConfigPath: "./testdata",
+			AppType:    "http",
+		}
+		do.Provide(injector, ProvideConfigLoader(opts))
+		do.Provide(injector, ProvideRetryRegistry)
+
+		// Retry not configured, return nil
+		registry, err := do.Invoke[*retry.Registry](injector)
+		if err == nil {
+			assert.Nil(t, registry)
+		}
+	})
+}
@@ -45,6 +45,7 @@ func RegisterCoreProviders(injector *do.RootScope, opts ConfigOptions) {
 	do.Provide(injector, ProvideCacheOrchestrator)
 	do.Provide(injector, ProvideLimiterManager)
 	do.Provide(injector, ProvideBreakerManager)
+	do.Provide(injector, ProvideRetryRegistry)
 	do.Provide(injector, ProvideHealthAggregator)
 	do.Provide(injector, ProvideTelemetryManager)
 	do.Provide(injector, ProvideMetricsRegistry)
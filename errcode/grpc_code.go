@@ -0,0 +1,23 @@
+package errcode
+
+import (
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+)
+
+// ParseGRPCCode parses a gRPC status code from configuration
+// Accepts code names (NotFound), canonical names (NOT_FOUND) and numbers (5)
+func ParseGRPCCode(name string) (codes.Code, bool) {
+	normalized := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "_", "")
+	for c := codes.OK; c <= codes.Unauthenticated; c++ {
+		if strings.ToLower(c.String()) == normalized {
+			return c, true
+		}
+	}
+	if n, err := strconv.Atoi(normalized); err == nil && n >= 0 && n <= int(codes.Unauthenticated) {
+		return codes.Code(n), true
+	}
+	return 0, false
+}
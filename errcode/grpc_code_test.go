@@ -0,0 +1,30 @@
+package errcode
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+// TestParseGRPCCode test parsing gRPC codes from configuration
+func TestParseGRPCCode(t *testing.T) {
+	cases := map[string]codes.Code{
+		"NotFound":     codes.NotFound,
+		"NOT_FOUND":    codes.NotFound,
+		" unavailable": codes.Unavailable,
+		"14":           codes.Unavailable,
+		"0":            codes.OK,
+	}
+	for name, want := range cases {
+		got, ok := ParseGRPCCode(name)
+		if !ok || got != want {
+			t.Errorf("ParseGRPCCode(%q) = %v, %v, want %v", name, got, ok, want)
+		}
+	}
+
+	for _, name := range []string{"", "Teapot", "17", "-1"} {
+		if _, ok := ParseGRPCCode(name); ok {
+			t.Errorf("ParseGRPCCode(%q) should fail", name)
+		}
+	}
+}
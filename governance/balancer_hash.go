@@ -0,0 +1,300 @@
+package governance
+
+import (
+	"context"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// KeyedBalancer load balancer choosing by request key (consistent hashing)
+// The same key maps to the same instance while the instance list is stable,
+// and only about 1/N of the keys move when an instance joins or leaves.
+type KeyedBalancer interface {
+	LoadBalancer
+
+	// SelectKey selects the instance of a key (an empty key falls back to Select)
+	SelectKey(instances []*ServiceInstance, key string) *ServiceInstance
+}
+
+type hashKeyCtxKey struct{}
+
+// WithHashKey sets the key of consistent hash balancers for the calls made with ctx
+func WithHashKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, hashKeyCtxKey{}, key)
+}
+
+// HashKeyFromContext returns the key set by WithHashKey
+func HashKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(hashKeyCtxKey{}).(string)
+	return key
+}
+
+// hash64 FNV-1a hash of a string
+func hash64(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	// FNV-1a spreads short similar keys poorly in the high bits, finish with a 64-bit mixer
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// instancesSignature identifies an instance list (order independent)
+func instancesSignature(instances []*ServiceInstance) string {
+	keys := make([]string, len(instances))
+	for i, instance := range instances {
+		keys[i] = instanceKey(instance) + "/" + strconv.Itoa(instance.Weight)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+// sortedInstances returns the instances sorted by key (tables do not depend on the discovery order)
+func sortedInstances(instances []*ServiceInstance) []*ServiceInstance {
+	sorted := append([]*ServiceInstance(nil), instances...)
+	sort.Slice(sorted, func(i, j int) bool { return instanceKey(sorted[i]) < instanceKey(sorted[j]) })
+	return sorted
+}
+
+// hashTable lookup table built for one instance list
+type hashTable interface {
+	lookup(hash uint64) *ServiceInstance
+}
+
+// hashTableCache rebuilds the table only when the instance list changes
+type hashTableCache struct {
+	mu        sync.Mutex
+	signature string
+	table     hashTable
+	build     func(instances []*ServiceInstance) hashTable
+	counter   uint64
+}
+
+// selectKey looks the key up in the table of the instances
+func (c *hashTableCache) selectKey(instances []*ServiceInstance, key string) *ServiceInstance {
+	if len(instances) == 0 {
+		return nil
+	}
+	if key == "" {
+		// No key: spread the calls
+		idx := atomic.AddUint64(&c.counter, 1) - 1
+		return instances[int(idx%uint64(len(instances)))]
+	}
+
+	signature := instancesSignature(instances)
+	c.mu.Lock()
+	if c.table == nil || c.signature != signature {
+		c.table = c.build(sortedInstances(instances))
+		c.signature = signature
+	}
+	table := c.table
+	c.mu.Unlock()
+
+	return table.lookup(hash64(key))
+}
+
+// RingHashBalancer consistent hash ring load balancer (Ketama style)
+// Each instance owns replicas x weight/100 points on the ring, a key goes to the next point clockwise.
+type RingHashBalancer struct {
+	hashTableCache
+}
+
+// defaultRingReplicas virtual nodes of an instance of weight 100
+const defaultRingReplicas = 160
+
+// NewRingHashBalancer creates a ring hash load balancer (replicas <= 0 means 160 per instance)
+func NewRingHashBalancer(replicas int) *RingHashBalancer {
+	if replicas <= 0 {
+		replicas = defaultRingReplicas
+	}
+	b := &RingHashBalancer{}
+	b.build = func(instances []*ServiceInstance) hashTable {
+		return newHashRing(instances, replicas)
+	}
+	return b
+}
+
+// Select spreads calls without a key
+func (b *RingHashBalancer) Select(instances []*ServiceInstance) *ServiceInstance {
+	return b.selectKey(instances, "")
+}
+
+// SelectKey selects the instance owning the key on the ring
+func (b *RingHashBalancer) SelectKey(instances []*ServiceInstance, key string) *ServiceInstance {
+	return b.selectKey(instances, key)
+}
+
+// Name Load Balancer Name
+func (b *RingHashBalancer) Name() string {
+	return "ring_hash"
+}
+
+// hashRing sorted ring points
+type hashRing struct {
+	hashes    []uint64
+	instances []*ServiceInstance
+}
+
+func newHashRing(instances []*ServiceInstance, replicas int) *hashRing {
+	type point struct {
+		hash     uint64
+		instance *ServiceInstance
+	}
+	points := make([]point, 0, len(instances)*replicas)
+	for _, instance := range instances {
+		n := int(float64(replicas) * weightOf(instance) / 100)
+		if n < 1 {
+			n = 1
+		}
+		key := instanceKey(instance)
+		for i := 0; i < n; i++ {
+			points = append(points, point{hash: hash64(key + "#" + strconv.Itoa(i)), instance: instance})
+		}
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].hash < points[j].hash })
+
+	ring := &hashRing{
+		hashes:    make([]uint64, len(points)),
+		instances: make([]*ServiceInstance, len(points)),
+	}
+	for i, p := range points {
+		ring.hashes[i] = p.hash
+		ring.instances[i] = p.instance
+	}
+	return ring
+}
+
+func (r *hashRing) lookup(hash uint64) *ServiceInstance {
+	i := sort.Search(len(r.hashes), func(i int) bool { return r.hashes[i] >= hash })
+	if i == len(r.hashes) {
+		i = 0
+	}
+	return r.instances[i]
+}
+
+// MaglevBalancer Maglev consistent hashing load balancer
+// A lookup table of a prime size is filled from per-instance permutations: lookups are O(1)
+// and the keys spread evenly (weighted by instance weight).
+type MaglevBalancer struct {
+	hashTableCache
+}
+
+// defaultMaglevTableSize prime table size (Maglev paper recommends a size >> instances x 100)
+const defaultMaglevTableSize = 65537
+
+// NewMaglevBalancer creates a Maglev load balancer (<= 0 means 65537)
+// The table size must be prime for the slot search to visit every slot, other sizes are rounded up to the next prime.
+func NewMaglevBalancer(tableSize int) *MaglevBalancer {
+	if tableSize <= 0 {
+		tableSize = defaultMaglevTableSize
+	}
+	tableSize = nextPrime(tableSize)
+	b := &MaglevBalancer{}
+	b.build = func(instances []*ServiceInstance) hashTable {
+		return newMaglevTable(instances, uint64(tableSize))
+	}
+	return b
+}
+
+// nextPrime returns the smallest prime >= n
+func nextPrime(n int) int {
+	if n <= 2 {
+		return 2
+	}
+	for ; ; n++ {
+		prime := true
+		for d := 2; d*d <= n; d++ {
+			if n%d == 0 {
+				prime = false
+				break
+			}
+		}
+		if prime {
+			return n
+		}
+	}
+}
+
+// Select spreads calls without a key
+func (b *MaglevBalancer) Select(instances []*ServiceInstance) *ServiceInstance {
+	return b.selectKey(instances, "")
+}
+
+// SelectKey selects the instance of the key in the lookup table
+func (b *MaglevBalancer) SelectKey(instances []*ServiceInstance, key string) *ServiceInstance {
+	return b.selectKey(instances, key)
+}
+
+// Name Load Balancer Name
+func (b *MaglevBalancer) Name() string {
+	return "maglev"
+}
+
+// maglevTable Maglev lookup table
+type maglevTable struct {
+	entries []*ServiceInstance
+}
+
+// newMaglevTable fills the table (weighted population: an instance takes a turn each time
+// its accumulated weight share reaches its number of entries)
+func newMaglevTable(instances []*ServiceInstance, size uint64) *maglevTable {
+	n := len(instances)
+	offsets := make([]uint64, n)
+	skips := make([]uint64, n)
+	next := make([]uint64, n)
+	targets := make([]float64, n)
+	counts := make([]float64, n)
+
+	maxWeight := 0.0
+	for i, instance := range instances {
+		key := instanceKey(instance)
+		offsets[i] = hash64(key+"#offset") % size
+		skips[i] = hash64(key+"#skip")%(size-1) + 1
+		if w := weightOf(instance); w > maxWeight {
+			maxWeight = w
+		}
+	}
+
+	table := make([]int, size)
+	for i := range table {
+		table[i] = -1
+	}
+
+	filled := uint64(0)
+	for filled < size {
+		for i := 0; i < n && filled < size; i++ {
+			targets[i] += weightOf(instances[i]) / maxWeight
+			for counts[i] < targets[i] && filled < size {
+				// Next preferred free slot of instance i
+				for {
+					slot := (offsets[i] + next[i]*skips[i]) % size
+					next[i]++
+					if table[slot] < 0 {
+						table[slot] = i
+						break
+					}
+				}
+				counts[i]++
+				filled++
+			}
+		}
+	}
+
+	entries := make([]*ServiceInstance, size)
+	for slot, i := range table {
+		entries[slot] = instances[i]
+	}
+	return &maglevTable{entries: entries}
+}
+
+func (t *maglevTable) lookup(hash uint64) *ServiceInstance {
+	return t.entries[hash%uint64(len(t.entries))]
+}
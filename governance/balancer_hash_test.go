@@ -0,0 +1,121 @@
+package governance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// keyedBalancers the consistent hash balancers under test (small Maglev table for speed)
+func keyedBalancers() map[string]KeyedBalancer {
+	return map[string]KeyedBalancer{
+		"ring_hash": NewRingHashBalancer(0),
+		"maglev":    NewMaglevBalancer(1031),
+	}
+}
+
+func TestKeyedBalancers_Stable(t *testing.T) {
+	for name, lb := range keyedBalancers() {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, name, lb.Name())
+			assert.Nil(t, lb.SelectKey(nil, "user-1"))
+
+			instances := newBalancerInstances(4)
+			first := lb.SelectKey(instances, "user-1")
+
+			// The discovery order does not matter
+			reversed := []*ServiceInstance{instances[3], instances[2], instances[1], instances[0]}
+			for i := 0; i < 10; i++ {
+				assert.Equal(t, first, lb.SelectKey(reversed, "user-1"))
+			}
+
+			// Without a key the calls are spread
+			seen := map[string]bool{}
+			for i := 0; i < 8; i++ {
+				seen[lb.Select(instances).ID] = true
+			}
+			assert.Len(t, seen, 4)
+		})
+	}
+}
+
+func TestKeyedBalancers_MinimalDisruption(t *testing.T) {
+	for name, lb := range keyedBalancers() {
+		t.Run(name, func(t *testing.T) {
+			instances := newBalancerInstances(5)
+			before := make(map[string]string)
+			counts := make(map[string]int)
+			for i := 0; i < 2000; i++ {
+				key := fmt.Sprintf("key-%d", i)
+				id := lb.SelectKey(instances, key).ID
+				before[key] = id
+				counts[id]++
+			}
+
+			// Keys spread over all instances
+			for _, instance := range instances {
+				assert.Greater(t, counts[instance.ID], 200, instance.ID)
+			}
+
+			// Removing an instance moves its own keys and (Maglev only) a few others
+			removed := instances[2].ID
+			remaining := append(instances[:2:2], instances[3:]...)
+			moved := 0
+			for key, id := range before {
+				after := lb.SelectKey(remaining, key).ID
+				assert.NotEqual(t, removed, after)
+				if id != removed && id != after {
+					moved++
+				}
+			}
+			if name == "ring_hash" {
+				assert.Zero(t, moved)
+			} else {
+				assert.Less(t, moved, len(before)/20)
+			}
+		})
+	}
+}
+
+func TestKeyedBalancers_Weighted(t *testing.T) {
+	for name, lb := range keyedBalancers() {
+		t.Run(name, func(t *testing.T) {
+			instances := newBalancerInstances(2)
+			instances[0].Weight = 300
+
+			counts := make(map[string]int)
+			for i := 0; i < 4000; i++ {
+				counts[lb.SelectKey(instances, fmt.Sprintf("key-%d", i)).ID]++
+			}
+			ratio := float64(counts["inst-0"]) / float64(counts["inst-1"])
+			assert.InDelta(t, 3.0, ratio, 0.8)
+		})
+	}
+}
+
+func TestMaglevBalancer_TableSize(t *testing.T) {
+	assert.Equal(t, 2, nextPrime(1))
+	assert.Equal(t, 1009, nextPrime(1000))
+	assert.Equal(t, 1031, nextPrime(1031))
+
+	// Sizes that are too small or not prime still build a table
+	instances := newBalancerInstances(4)
+	for _, size := range []int{1, 4, 1000} {
+		done := make(chan *ServiceInstance, 1)
+		go func() { done <- NewMaglevBalancer(size).SelectKey(instances, "user-1") }()
+		select {
+		case instance := <-done:
+			assert.NotNil(t, instance, size)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("table of size %d never built", size)
+		}
+	}
+}
+
+func TestHashKeyContext(t *testing.T) {
+	assert.Empty(t, HashKeyFromContext(context.Background()))
+	assert.Equal(t, "user-1", HashKeyFromContext(WithHashKey(context.Background(), "user-1")))
+}
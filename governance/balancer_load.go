@@ -0,0 +1,264 @@
+package governance
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CallResult result of a call to an instance (balancer feedback)
+// Err is set for failures of the instance (unavailable, timeout), application errors count as successes.
+// A zero Latency without Err (e.g., a call canceled by the caller) only releases the call.
+type CallResult struct {
+	Latency time.Duration
+	Err     error
+}
+
+// FeedbackBalancer load balancer learning from call results (P2C, least-request)
+// Begin marks a call as in flight, End reports its result; every Begin must be followed by one End.
+type FeedbackBalancer interface {
+	LoadBalancer
+
+	// Begin marks a call to the instance as started
+	Begin(instance *ServiceInstance)
+
+	// End reports the result of a call started with Begin
+	End(instance *ServiceInstance, result CallResult)
+}
+
+const (
+	// defaultEWMADecay time constant of the latency EWMA (older samples weigh e^-age/decay)
+	defaultEWMADecay = 10 * time.Second
+
+	// minEWMALatency latency of instances without samples (new instances get a bounded share)
+	minEWMALatency = time.Millisecond
+
+	// failurePenalty latency recorded for a failed call (moves traffic away quickly)
+	failurePenalty = time.Second
+)
+
+// instanceLoad load of an instance: in-flight calls and EWMA latency
+type instanceLoad struct {
+	inflight int64 // atomic
+
+	mu         sync.Mutex
+	ewma       float64 // nanoseconds
+	lastSample time.Time
+}
+
+// observe adds a latency sample to the EWMA
+func (l *instanceLoad) observe(latency time.Duration, decay time.Duration, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	sample := float64(latency)
+	if l.lastSample.IsZero() {
+		l.ewma = sample
+	} else {
+		w := math.Exp(-float64(now.Sub(l.lastSample)) / float64(decay))
+		l.ewma = l.ewma*w + sample*(1-w)
+	}
+	l.lastSample = now
+}
+
+// latency returns the EWMA latency (at least minEWMALatency)
+func (l *instanceLoad) latency() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return math.Max(l.ewma, float64(minEWMALatency))
+}
+
+// loadPruneInterval minimum delay between two prunes of the departed instances
+const loadPruneInterval = 10 * time.Second
+
+// loadTracker per-instance load keyed by instance ID
+type loadTracker struct {
+	loads     sync.Map // key -> *instanceLoad
+	lastPrune int64    // UnixNano of the last prune
+}
+
+// instanceKey identifies an instance (ID, or address without ID)
+func instanceKey(instance *ServiceInstance) string {
+	if instance.ID != "" {
+		return instance.ID
+	}
+	return instance.GetAddress()
+}
+
+// get returns the load of an instance
+func (t *loadTracker) get(instance *ServiceInstance) *instanceLoad {
+	key := instanceKey(instance)
+	if load, ok := t.loads.Load(key); ok {
+		return load.(*instanceLoad)
+	}
+	load, _ := t.loads.LoadOrStore(key, &instanceLoad{})
+	return load.(*instanceLoad)
+}
+
+// track drops the load of the instances missing from the current list (at most every loadPruneInterval)
+// Instances with calls in flight are kept until their calls end.
+func (t *loadTracker) track(instances []*ServiceInstance) {
+	now := time.Now().UnixNano()
+	last := atomic.LoadInt64(&t.lastPrune)
+	if now-last < int64(loadPruneInterval) || !atomic.CompareAndSwapInt64(&t.lastPrune, last, now) {
+		return
+	}
+
+	current := make(map[string]struct{}, len(instances))
+	for _, instance := range instances {
+		current[instanceKey(instance)] = struct{}{}
+	}
+	t.loads.Range(func(key, value any) bool {
+		if _, ok := current[key.(string)]; !ok && atomic.LoadInt64(&value.(*instanceLoad).inflight) == 0 {
+			t.loads.Delete(key)
+		}
+		return true
+	})
+}
+
+// Begin marks a call to the instance as started
+func (t *loadTracker) Begin(instance *ServiceInstance) {
+	if instance == nil {
+		return
+	}
+	atomic.AddInt64(&t.get(instance).inflight, 1)
+}
+
+// end releases an in-flight call and returns the load
+func (t *loadTracker) end(instance *ServiceInstance) *instanceLoad {
+	load := t.get(instance)
+	if atomic.AddInt64(&load.inflight, -1) < 0 {
+		atomic.StoreInt64(&load.inflight, 0)
+	}
+	return load
+}
+
+// weightOf returns the weight of an instance (default 100)
+func weightOf(instance *ServiceInstance) float64 {
+	if instance.Weight <= 0 {
+		return 100
+	}
+	return float64(instance.Weight)
+}
+
+// P2CBalancer power of two choices load balancer (peak EWMA)
+// Two random instances are compared by EWMA latency x (in-flight + 1) / weight, the cheaper one wins.
+type P2CBalancer struct {
+	loadTracker
+	decay time.Duration
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// NewP2CBalancer creates a P2C load balancer (decay <= 0 means 10s)
+func NewP2CBalancer(decay time.Duration) *P2CBalancer {
+	if decay <= 0 {
+		decay = defaultEWMADecay
+	}
+	return &P2CBalancer{
+		decay: decay,
+		rand:  rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Select the cheaper of two random instances
+func (b *P2CBalancer) Select(instances []*ServiceInstance) *ServiceInstance {
+	b.track(instances)
+	switch len(instances) {
+	case 0:
+		return nil
+	case 1:
+		return instances[0]
+	}
+
+	b.mu.Lock()
+	i := b.rand.Intn(len(instances))
+	j := b.rand.Intn(len(instances) - 1)
+	b.mu.Unlock()
+	if j >= i {
+		j++
+	}
+
+	first, second := instances[i], instances[j]
+	if b.cost(second) < b.cost(first) {
+		return second
+	}
+	return first
+}
+
+// cost of sending one more call to the instance
+func (b *P2CBalancer) cost(instance *ServiceInstance) float64 {
+	load := b.get(instance)
+	inflight := float64(atomic.LoadInt64(&load.inflight))
+	return load.latency() * (inflight + 1) / weightOf(instance)
+}
+
+// End reports the result of a call (failures count as a 1s latency)
+func (b *P2CBalancer) End(instance *ServiceInstance, result CallResult) {
+	if instance == nil {
+		return
+	}
+	load := b.end(instance)
+	latency := result.Latency
+	if result.Err != nil && latency < failurePenalty {
+		latency = failurePenalty
+	}
+	if latency > 0 {
+		load.observe(latency, b.decay, time.Now())
+	}
+}
+
+// Name Load Balancer Name
+func (b *P2CBalancer) Name() string {
+	return "p2c_ewma"
+}
+
+// LeastRequestBalancer least outstanding requests load balancer
+// The instance with the fewest in-flight calls per weight wins, ties are broken round-robin.
+type LeastRequestBalancer struct {
+	loadTracker
+	counter uint64
+}
+
+// NewLeastRequestBalancer creates a least-request load balancer
+func NewLeastRequestBalancer() *LeastRequestBalancer {
+	return &LeastRequestBalancer{}
+}
+
+// Select the least loaded instance
+func (b *LeastRequestBalancer) Select(instances []*ServiceInstance) *ServiceInstance {
+	b.track(instances)
+	if len(instances) == 0 {
+		return nil
+	}
+
+	// Start the scan at a rotating offset so ties are spread
+	offset := int((atomic.AddUint64(&b.counter, 1) - 1) % uint64(len(instances)))
+
+	var best *ServiceInstance
+	bestScore := math.Inf(1)
+	for n := 0; n < len(instances); n++ {
+		instance := instances[(offset+n)%len(instances)]
+		inflight := float64(atomic.LoadInt64(&b.get(instance).inflight))
+		if score := (inflight + 1) / weightOf(instance); score < bestScore {
+			best, bestScore = instance, score
+		}
+	}
+	return best
+}
+
+// End reports the result of a call
+func (b *LeastRequestBalancer) End(instance *ServiceInstance, result CallResult) {
+	if instance == nil {
+		return
+	}
+	b.end(instance)
+}
+
+// Name Load Balancer Name
+func (b *LeastRequestBalancer) Name() string {
+	return "least_request"
+}
@@ -0,0 +1,121 @@
+package governance
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newBalancerInstances(n int) []*ServiceInstance {
+	instances := make([]*ServiceInstance, n)
+	for i := range instances {
+		instances[i] = &ServiceInstance{
+			ID:      fmt.Sprintf("inst-%d", i),
+			Address: fmt.Sprintf("10.0.0.%d", i+1),
+			Port:    9000,
+			Weight:  100,
+			Healthy: true,
+		}
+	}
+	return instances
+}
+
+func TestP2CBalancer_PrefersFastInstances(t *testing.T) {
+	lb := NewP2CBalancer(0)
+	assert.Equal(t, "p2c_ewma", lb.Name())
+	assert.Nil(t, lb.Select(nil))
+
+	instances := newBalancerInstances(2)
+	fast, slow := instances[0], instances[1]
+
+	for i := 0; i < 5; i++ {
+		lb.Begin(fast)
+		lb.End(fast, CallResult{Latency: 5 * time.Millisecond})
+		lb.Begin(slow)
+		lb.End(slow, CallResult{Latency: 200 * time.Millisecond})
+	}
+
+	// With two instances both are always compared
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, fast, lb.Select(instances))
+	}
+
+	// In-flight calls raise the cost of the fast instance
+	for i := 0; i < 50; i++ {
+		lb.Begin(fast)
+	}
+	assert.Equal(t, slow, lb.Select(instances))
+}
+
+func TestP2CBalancer_FailuresMoveTraffic(t *testing.T) {
+	lb := NewP2CBalancer(0)
+	instances := newBalancerInstances(2)
+
+	lb.Begin(instances[0])
+	lb.End(instances[0], CallResult{Latency: time.Millisecond, Err: errors.New("unavailable")})
+	lb.Begin(instances[1])
+	lb.End(instances[1], CallResult{Latency: 50 * time.Millisecond})
+
+	assert.Equal(t, instances[1], lb.Select(instances))
+
+	// A canceled call only releases the in-flight slot
+	lb.Begin(instances[1])
+	lb.End(instances[1], CallResult{})
+	assert.Equal(t, instances[1], lb.Select(instances))
+}
+
+func TestLeastRequestBalancer_Select(t *testing.T) {
+	lb := NewLeastRequestBalancer()
+	assert.Equal(t, "least_request", lb.Name())
+	assert.Nil(t, lb.Select(nil))
+
+	instances := newBalancerInstances(3)
+	lb.Begin(instances[0])
+	lb.Begin(instances[0])
+	lb.Begin(instances[1])
+
+	assert.Equal(t, instances[2], lb.Select(instances))
+
+	// Ties are spread
+	lb.Begin(instances[2])
+	lb.End(instances[0], CallResult{})
+	seen := map[string]bool{}
+	for i := 0; i < 6; i++ {
+		seen[lb.Select(instances).ID] = true
+	}
+	assert.Len(t, seen, 3)
+
+	// Weight scales the load
+	instances[0].Weight = 300
+	lb.End(instances[0], CallResult{})
+	lb.Begin(instances[0])
+	lb.Begin(instances[0])
+	assert.Equal(t, instances[0], lb.Select(instances))
+}
+
+func TestLoadTracker_PrunesDepartedInstances(t *testing.T) {
+	lb := NewLeastRequestBalancer()
+	instances := newBalancerInstances(3)
+	lb.Begin(instances[0])
+	lb.Begin(instances[1])
+	lb.End(instances[1], CallResult{})
+	assert.NotNil(t, lb.Select(instances))
+
+	// Departed instances are dropped, unless calls are still in flight
+	lb.lastPrune = 0
+	assert.Equal(t, instances[2], lb.Select(instances[2:]))
+	_, ok := lb.loads.Load(instances[0].ID)
+	assert.True(t, ok)
+	_, ok = lb.loads.Load(instances[1].ID)
+	assert.False(t, ok)
+}
+
+func TestNewLoadBalancer_Advanced(t *testing.T) {
+	assert.IsType(t, &P2CBalancer{}, NewLoadBalancer("p2c_ewma"))
+	assert.IsType(t, &LeastRequestBalancer{}, NewLoadBalancer("least_request"))
+	assert.IsType(t, &RingHashBalancer{}, NewLoadBalancer("ring_hash"))
+	assert.IsType(t, &MaglevBalancer{}, NewLoadBalancer("maglev"))
+}
@@ -1,13 +1,16 @@
 package governance
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 import "github.com/KOMKZ/go-yogan-framework/breaker"
 
 // Configuration governance component configuration
 type Config struct {
 	Enabled      bool              `mapstructure:"enabled"`       // Whether to enable
-	RegistryType string            `mapstructure:"registry_type"` // Registry center type: etcd | consul | nacos
+	RegistryType string            `mapstructure:"registry_type"` // Registry center type: etcd | consul | nacos (discovery also: static | file)
 	ServiceName  string            `mapstructure:"service_name"`  // service name
 	Protocol     string            `mapstructure:"protocol"`      // protocol type (grpc/http)
 	Version      string            `mapstructure:"version"`       // service version
@@ -18,9 +21,12 @@ type Config struct {
 	// Etcd configuration
 	Etcd EtcdRegistryConfig `mapstructure:"etcd"`
 
-	// Consul configuration (to be implemented)
+	// Consul configuration
 	Consul ConsulRegistryConfig `mapstructure:"consul"`
 
+	// Static / file discovery configuration
+	Static StaticDiscoveryConfig `mapstructure:"static"`
+
 	// Nacos configuration (to be implemented)
 	Nacos NacosRegistryConfig `mapstructure:"nacos"`
 	
@@ -46,10 +52,69 @@ type EtcdRegistryConfig struct {
 	OnRegisterFailed func(error) `mapstructure:"-"` // Register final failure callback
 }
 
-// ConsulRegistryConfig Consul registry center configuration (placeholder)
+// ConsulRegistryConfig Consul registry center configuration
 type ConsulRegistryConfig struct {
-	Address string `mapstructure:"address"` // Consul address
-	Token   string `mapstructure:"token"`   // ACL Token
+	Address    string   `mapstructure:"address"`    // Consul agent address (default 127.0.0.1:8500, http:// or https://)
+	Token      string   `mapstructure:"token"`      // ACL Token
+	Datacenter string   `mapstructure:"datacenter"` // Datacenter (optional, default the agent's)
+	Tags       []string `mapstructure:"tags"`       // Tags of the registered service
+
+	// Health check
+	CheckType               string        `mapstructure:"check_type"`                // ttl | http (default ttl)
+	CheckHTTP               string        `mapstructure:"check_http"`                // HTTP check URL (default http://{address}:{port}{health_check.path})
+	CheckInterval           time.Duration `mapstructure:"check_interval"`            // HTTP check interval (default 10s)
+	CheckTimeout            time.Duration `mapstructure:"check_timeout"`             // HTTP check timeout (default 5s)
+	DeregisterCriticalAfter time.Duration `mapstructure:"deregister_critical_after"` // Remove instances critical for this long (default 1m)
+
+	// Discovery
+	WatchTags []string      `mapstructure:"watch_tags"` // Only discover instances with all these tags
+	WaitTime  time.Duration `mapstructure:"wait_time"`  // Blocking query wait time (default 55s)
+}
+
+// Consul health check types
+const (
+	ConsulCheckTTL  = "ttl"
+	ConsulCheckHTTP = "http"
+)
+
+// ApplyDefaults applies default values
+func (c *ConsulRegistryConfig) ApplyDefaults() {
+	if c.Address == "" {
+		c.Address = "127.0.0.1:8500"
+	}
+	if c.CheckType == "" {
+		c.CheckType = ConsulCheckTTL
+	}
+	if c.CheckInterval <= 0 {
+		c.CheckInterval = 10 * time.Second
+	}
+	if c.CheckTimeout <= 0 {
+		c.CheckTimeout = 5 * time.Second
+	}
+	if c.DeregisterCriticalAfter <= 0 {
+		c.DeregisterCriticalAfter = time.Minute
+	}
+	if c.WaitTime <= 0 {
+		c.WaitTime = 55 * time.Second
+	}
+}
+
+// Validate Consul configuration
+func (c *ConsulRegistryConfig) Validate() error {
+	switch c.CheckType {
+	case "", ConsulCheckTTL, ConsulCheckHTTP:
+	default:
+		return fmt.Errorf("consul check_type must be ttl or http, got %q", c.CheckType)
+	}
+	return nil
+}
+
+// StaticDiscoveryConfig static and file-based discovery configuration
+// Service names are map keys in the configuration, keep them lowercase.
+type StaticDiscoveryConfig struct {
+	Services     map[string][]StaticInstance `mapstructure:"services"`      // Instances by service name
+	File         string                      `mapstructure:"file"`          // Discovery file (YAML/JSON with a services key)
+	PollInterval time.Duration               `mapstructure:"poll_interval"` // File check interval (default 2s)
 }
 
 // NacosRegistryConfig Nacos registry center configuration (placeholder)
@@ -0,0 +1,189 @@
+package governance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// consulClient minimal client of the Consul HTTP API (agent and health endpoints)
+type consulClient struct {
+	baseURL    string
+	token      string
+	datacenter string
+	httpClient *http.Client
+}
+
+// newConsulClient creates a Consul HTTP API client
+func newConsulClient(cfg ConsulRegistryConfig) *consulClient {
+	address := cfg.Address
+	if address == "" {
+		address = "127.0.0.1:8500"
+	}
+	if !strings.HasPrefix(address, "http://") && !strings.HasPrefix(address, "https://") {
+		address = "http://" + address
+	}
+
+	return &consulClient{
+		baseURL:    strings.TrimRight(address, "/"),
+		token:      cfg.Token,
+		datacenter: cfg.Datacenter,
+		// Blocking queries set their own deadline through the context
+		httpClient: &http.Client{},
+	}
+}
+
+// consulService service registration body (/v1/agent/service/register)
+type consulService struct {
+	ID      string              `json:"ID"`
+	Name    string              `json:"Name"`
+	Tags    []string            `json:"Tags,omitempty"`
+	Address string              `json:"Address"`
+	Port    int                 `json:"Port"`
+	Meta    map[string]string   `json:"Meta,omitempty"`
+	Weights *consulWeights      `json:"Weights,omitempty"`
+	Check   *consulServiceCheck `json:"Check,omitempty"`
+}
+
+// consulWeights DNS/SRV weights of a service
+type consulWeights struct {
+	Passing int `json:"Passing"`
+	Warning int `json:"Warning"`
+}
+
+// consulServiceCheck health check of a registered service
+type consulServiceCheck struct {
+	TTL                            string `json:"TTL,omitempty"`
+	HTTP                           string `json:"HTTP,omitempty"`
+	Interval                       string `json:"Interval,omitempty"`
+	Timeout                        string `json:"Timeout,omitempty"`
+	DeregisterCriticalServiceAfter string `json:"DeregisterCriticalServiceAfter,omitempty"`
+}
+
+// consulServiceEntry entry of /v1/health/service/{name}
+type consulServiceEntry struct {
+	Node struct {
+		Address string `json:"Address"`
+	} `json:"Node"`
+	Service struct {
+		ID      string            `json:"ID"`
+		Service string            `json:"Service"`
+		Address string            `json:"Address"`
+		Port    int               `json:"Port"`
+		Tags    []string          `json:"Tags"`
+		Meta    map[string]string `json:"Meta"`
+		Weights consulWeights     `json:"Weights"`
+	} `json:"Service"`
+	Checks []struct {
+		Status string `json:"Status"`
+	} `json:"Checks"`
+}
+
+// registerService registers a service on the local agent
+func (c *consulClient) registerService(ctx context.Context, service *consulService) error {
+	_, err := c.do(ctx, http.MethodPut, "/v1/agent/service/register", nil, service, nil)
+	return err
+}
+
+// deregisterService removes a service from the local agent
+func (c *consulClient) deregisterService(ctx context.Context, serviceID string) error {
+	_, err := c.do(ctx, http.MethodPut, "/v1/agent/service/deregister/"+url.PathEscape(serviceID), nil, nil, nil)
+	return err
+}
+
+// passTTL marks the TTL check of a service as passing
+func (c *consulClient) passTTL(ctx context.Context, serviceID string) error {
+	_, err := c.do(ctx, http.MethodPut, "/v1/agent/check/pass/"+url.PathEscape("service:"+serviceID), nil, nil, nil)
+	return err
+}
+
+// healthService queries the instances of a service
+// With index > 0 the query blocks until the index changes or the wait time elapses.
+func (c *consulClient) healthService(ctx context.Context, serviceName string, tags []string, index uint64, wait time.Duration) ([]consulServiceEntry, uint64, error) {
+	query := url.Values{}
+	for _, tag := range tags {
+		query.Add("tag", tag)
+	}
+	if index > 0 {
+		query.Set("index", strconv.FormatUint(index, 10))
+		query.Set("wait", wait.String())
+	}
+
+	var entries []consulServiceEntry
+	header, err := c.do(ctx, http.MethodGet, "/v1/health/service/"+url.PathEscape(serviceName), query, nil, &entries)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	newIndex, _ := strconv.ParseUint(header.Get("X-Consul-Index"), 10, 64)
+	return entries, newIndex, nil
+}
+
+// consulStatusError non-2xx answer of the Consul API
+type consulStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *consulStatusError) Error() string {
+	return fmt.Sprintf("consul: HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+// do sends a request to the Consul API and decodes the JSON answer into out
+func (c *consulClient) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) (http.Header, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	if c.datacenter != "" {
+		query.Set("dc", c.datacenter)
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("X-Consul-Token", c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRegistryUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, &consulStatusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(data))}
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return nil, fmt.Errorf("decode consul response: %w", err)
+		}
+	}
+	return resp.Header, nil
+}
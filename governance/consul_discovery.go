@@ -0,0 +1,186 @@
+package governance
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/logger"
+	"go.uber.org/zap"
+)
+
+// ConsulDiscovery Consul service discovery implementation
+// Watch runs blocking queries on /v1/health/service/{name} and sends the instance list on every change.
+type ConsulDiscovery struct {
+	client *consulClient
+	config ConsulRegistryConfig
+	ctx    context.Context
+	cancel context.CancelFunc
+	logger *logger.CtxZapLogger
+}
+
+// NewConsulDiscovery creates a Consul service discoverer
+func NewConsulDiscovery(cfg ConsulRegistryConfig, log *logger.CtxZapLogger) *ConsulDiscovery {
+	if log == nil {
+		log = logger.GetLogger("yogan")
+	}
+
+	cfg.ApplyDefaults()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &ConsulDiscovery{
+		client: newConsulClient(cfg),
+		config: cfg,
+		ctx:    ctx,
+		cancel: cancel,
+		logger: log,
+	}
+}
+
+// Discover service instances (healthy and unhealthy, see ServiceInstance.Healthy)
+func (d *ConsulDiscovery) Discover(ctx context.Context, serviceName string) ([]*ServiceInstance, error) {
+	entries, _, err := d.client.healthService(ctx, serviceName, d.config.WatchTags, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("Query service failed: %w", err)
+	}
+
+	instances := consulInstances(entries)
+
+	d.logger.DebugCtx(ctx, "✅ Service discovery successful",
+		zap.String("service", serviceName),
+		zap.Int("instances", len(instances)))
+
+	return instances, nil
+}
+
+// Watch for service changes
+// The channel receives the current list first, then the list after each change; it is closed by Stop.
+func (d *ConsulDiscovery) Watch(ctx context.Context, serviceName string) (<-chan []*ServiceInstance, error) {
+	entries, index, err := d.client.healthService(ctx, serviceName, d.config.WatchTags, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("Query service failed: %w", err)
+	}
+
+	watchCh := make(chan []*ServiceInstance, 10)
+	watchCh <- consulInstances(entries)
+
+	go d.watchChanges(serviceName, index, watchCh)
+
+	return watchCh, nil
+}
+
+// minWatchInterval minimum delay between two watch queries
+const minWatchInterval = time.Second
+
+// watchChanges runs blocking queries until Stop
+func (d *ConsulDiscovery) watchChanges(serviceName string, index uint64, watchCh chan []*ServiceInstance) {
+	defer close(watchCh)
+
+	d.logger.DebugCtx(d.ctx, "🔍 Starting to monitor service changes", zap.String("service", serviceName))
+
+	// A missing or zero index would make every query non-blocking
+	index = max(index, 1)
+	backoff := time.Second
+	var lastQuery time.Time
+	for {
+		// Queries the agent answers at once (e.g., after an index reset) are spaced out
+		if wait := minWatchInterval - time.Since(lastQuery); wait > 0 {
+			select {
+			case <-d.ctx.Done():
+				return
+			case <-time.After(wait):
+			}
+		}
+		lastQuery = time.Now()
+
+		// The agent answers after WaitTime at most (plus up to WaitTime/16 of jitter)
+		queryCtx, cancel := context.WithTimeout(d.ctx, d.config.WaitTime+d.config.WaitTime/16+5*time.Second)
+		entries, newIndex, err := d.client.healthService(queryCtx, serviceName, d.config.WatchTags, index, d.config.WaitTime)
+		cancel()
+
+		if d.ctx.Err() != nil {
+			d.logger.DebugCtx(context.Background(), "Stop service listener", zap.String("service", serviceName))
+			return
+		}
+		if err != nil {
+			d.logger.WarnCtx(d.ctx, "Watch query failed, will retry later",
+				zap.String("service", serviceName),
+				zap.Error(err),
+				zap.Duration("retry_after", backoff))
+			select {
+			case <-d.ctx.Done():
+				return
+			case <-time.After(backoff):
+				backoff = min(backoff*2, 30*time.Second)
+			}
+			continue
+		}
+		backoff = time.Second
+		newIndex = max(newIndex, 1)
+
+		// Same index: the wait time elapsed without changes
+		if newIndex == index {
+			continue
+		}
+		// The index went backwards (e.g., agent restart): start again from the beginning
+		if newIndex < index {
+			index = 1
+			continue
+		}
+		index = newIndex
+
+		select {
+		case watchCh <- consulInstances(entries):
+		case <-d.ctx.Done():
+			return
+		}
+	}
+}
+
+// Stop Service discovery (closes the Watch channels)
+func (d *ConsulDiscovery) Stop() {
+	d.cancel()
+	d.logger.DebugCtx(context.Background(), "✅ Service discovery has stopped")
+}
+
+// consulInstances converts health entries to service instances
+// An instance is healthy when none of its checks is critical (passing or warning).
+func consulInstances(entries []consulServiceEntry) []*ServiceInstance {
+	instances := make([]*ServiceInstance, 0, len(entries))
+	for _, entry := range entries {
+		address := entry.Service.Address
+		if address == "" {
+			address = entry.Node.Address
+		}
+
+		healthy := true
+		for _, check := range entry.Checks {
+			if check.Status != "passing" && check.Status != "warning" {
+				healthy = false
+				break
+			}
+		}
+
+		metadata := entry.Service.Meta
+		if metadata == nil {
+			metadata = make(map[string]string)
+		}
+		weight := 100 // Default weight
+		if w, err := strconv.Atoi(metadata["weight"]); err == nil && w > 0 {
+			weight = w
+		}
+
+		instances = append(instances, &ServiceInstance{
+			ID:       entry.Service.ID,
+			Service:  entry.Service.Service,
+			Address:  address,
+			Port:     entry.Service.Port,
+			Metadata: metadata,
+			Tags:     entry.Service.Tags,
+			Weight:   weight,
+			Healthy:  healthy,
+		})
+	}
+	return instances
+}
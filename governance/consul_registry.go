@@ -0,0 +1,304 @@
+package governance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/logger"
+	"go.uber.org/zap"
+)
+
+// ConsulRegistry Consul service registration implementation
+// The service is registered on the local agent with a TTL check (heartbeats sent by the registry)
+// or an HTTP check (run by the agent).
+type ConsulRegistry struct {
+	client *consulClient
+	config ConsulRegistryConfig
+
+	// service information
+	serviceInfo *ServiceInfo
+
+	// Lifecycle Management (heartbeats)
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// state management
+	mu         sync.RWMutex
+	registered bool
+
+	// Log
+	logger *logger.CtxZapLogger
+}
+
+// NewConsulRegistry creates a Consul registry
+func NewConsulRegistry(cfg ConsulRegistryConfig, log *logger.CtxZapLogger) (*ConsulRegistry, error) {
+	if log == nil {
+		log = logger.GetLogger("yogan")
+	}
+
+	cfg.ApplyDefaults()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &ConsulRegistry{
+		client: newConsulClient(cfg),
+		config: cfg,
+		ctx:    ctx,
+		cancel: cancel,
+		logger: log,
+	}, nil
+}
+
+// Register service registration
+func (r *ConsulRegistry) Register(ctx context.Context, info *ServiceInfo) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Supports idempotent re-registration: stop the old heartbeats first
+	if r.registered {
+		r.logger.WarnCtx(ctx, "Service already registered, will re-register")
+		r.cancel()
+	}
+	// The heartbeat context ends on re-registration, Deregister and Close, registering again starts a new one
+	if r.ctx.Err() != nil {
+		r.ctx, r.cancel = context.WithCancel(context.Background())
+	}
+
+	service, err := r.buildService(info)
+	if err != nil {
+		return err
+	}
+
+	if err := r.client.registerService(ctx, service); err != nil {
+		return fmt.Errorf("register consul service: %w", err)
+	}
+
+	// TTL check: the service is critical until the first heartbeat
+	if r.config.CheckType == ConsulCheckTTL {
+		if err := r.client.passTTL(ctx, info.InstanceID); err != nil {
+			r.client.deregisterService(context.Background(), info.InstanceID)
+			return fmt.Errorf("pass consul ttl check: %w", err)
+		}
+		go r.heartbeat(r.ctx, info)
+	}
+
+	r.serviceInfo = info
+	r.registered = true
+
+	r.logger.DebugCtx(ctx, "✅ Service registered to consul",
+		zap.String("service", info.ServiceName),
+		zap.String("instance", info.InstanceID),
+		zap.String("check", r.config.CheckType),
+		zap.Strings("tags", service.Tags),
+	)
+
+	return nil
+}
+
+// Unregister service
+func (r *ConsulRegistry) Deregister(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.registered {
+		return ErrNotRegistered
+	}
+
+	// Stop heartbeat
+	r.cancel()
+
+	if err := r.client.deregisterService(ctx, r.serviceInfo.InstanceID); err != nil {
+		r.logger.ErrorCtx(ctx, "Failed to deregister consul service", zap.Error(err))
+	}
+
+	r.registered = false
+
+	r.logger.DebugCtx(ctx, "✅ Service deregistered from consul",
+		zap.String("service", r.serviceInfo.ServiceName),
+		zap.String("instance", r.serviceInfo.InstanceID),
+	)
+
+	return nil
+}
+
+// UpdateMetadata Update service metadata (the agent replaces the registration)
+func (r *ConsulRegistry) UpdateMetadata(ctx context.Context, metadata map[string]string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.registered {
+		return ErrNotRegistered
+	}
+
+	// Update local metadata
+	if r.serviceInfo.Metadata == nil {
+		r.serviceInfo.Metadata = make(map[string]string)
+	}
+	for k, v := range metadata {
+		r.serviceInfo.Metadata[k] = v
+	}
+
+	service, err := r.buildService(r.serviceInfo)
+	if err != nil {
+		return err
+	}
+	if err := r.client.registerService(ctx, service); err != nil {
+		return fmt.Errorf("update consul service: %w", err)
+	}
+	if r.config.CheckType == ConsulCheckTTL {
+		// A re-registration resets the check, keep the service passing
+		if err := r.client.passTTL(ctx, r.serviceInfo.InstanceID); err != nil {
+			r.logger.WarnCtx(ctx, "Failed to pass consul ttl check", zap.Error(err))
+		}
+	}
+
+	r.logger.DebugCtx(ctx, "✅ Service metadata updated", zap.Any("metadata", metadata))
+
+	return nil
+}
+
+// Checks if the service is registered
+func (r *ConsulRegistry) IsRegistered() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.registered
+}
+
+// Close the registry (stops heartbeats, the registration expires with its check)
+func (r *ConsulRegistry) Close() error {
+	r.cancel()
+	return nil
+}
+
+// buildService builds the agent registration of a service
+func (r *ConsulRegistry) buildService(info *ServiceInfo) (*consulService, error) {
+	meta := make(map[string]string, len(info.Metadata)+2)
+	for k, v := range info.Metadata {
+		meta[k] = v
+	}
+	if info.Protocol != "" {
+		meta["protocol"] = info.Protocol
+	}
+	if info.Version != "" {
+		meta["version"] = info.Version
+	}
+
+	service := &consulService{
+		ID:      info.InstanceID,
+		Name:    info.ServiceName,
+		Tags:    r.config.Tags,
+		Address: info.Address,
+		Port:    info.Port,
+		Meta:    meta,
+	}
+	if weight, err := strconv.Atoi(meta["weight"]); err == nil && weight > 0 {
+		service.Weights = &consulWeights{Passing: weight, Warning: 1}
+	}
+
+	deregisterAfter := r.config.DeregisterCriticalAfter.String()
+	switch r.config.CheckType {
+	case ConsulCheckHTTP:
+		checkURL := r.config.CheckHTTP
+		interval := r.config.CheckInterval
+		timeout := r.config.CheckTimeout
+		if hc := info.HealthCheck; hc != nil {
+			if checkURL == "" && hc.Path != "" {
+				checkURL = "http://" + info.GetFullAddress() + hc.Path
+			}
+			if hc.Interval > 0 {
+				interval = time.Duration(hc.Interval) * time.Second
+			}
+			if hc.Timeout > 0 {
+				timeout = time.Duration(hc.Timeout) * time.Second
+			}
+		}
+		if checkURL == "" {
+			return nil, fmt.Errorf("consul http check requires check_http or health_check.path")
+		}
+		service.Check = &consulServiceCheck{
+			HTTP:                           checkURL,
+			Interval:                       interval.String(),
+			Timeout:                        timeout.String(),
+			DeregisterCriticalServiceAfter: deregisterAfter,
+		}
+	default:
+		service.Check = &consulServiceCheck{
+			TTL:                            consulTTL(info).String(),
+			DeregisterCriticalServiceAfter: deregisterAfter,
+		}
+	}
+
+	return service, nil
+}
+
+// heartbeat passes the TTL check every third of the TTL
+// When the agent no longer knows the service (e.g., agent restart), the service is registered again.
+func (r *ConsulRegistry) heartbeat(ctx context.Context, info *ServiceInfo) {
+	interval := consulTTL(info) / 3
+	if interval < time.Second {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.DebugCtx(context.Background(), "Heartbeat monitoring stopped")
+			return
+
+		case <-ticker.C:
+			passCtx, cancel := context.WithTimeout(ctx, interval)
+			err := r.client.passTTL(passCtx, info.InstanceID)
+			cancel()
+			if err == nil {
+				continue
+			}
+
+			var statusErr *consulStatusError
+			if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+				r.logger.WarnCtx(ctx, "⚠️  Service unknown to the consul agent, registering again",
+					zap.String("service", info.ServiceName))
+				if err := r.reRegister(ctx, info); err != nil {
+					r.logger.ErrorCtx(ctx, "Re-registration failed", zap.Error(err))
+				}
+				continue
+			}
+
+			r.logger.WarnCtx(ctx, "⚠️  Heartbeat failed, possible network issue",
+				zap.String("service", info.ServiceName),
+				zap.Error(err))
+		}
+	}
+}
+
+// reRegister registers the service again and passes its check
+func (r *ConsulRegistry) reRegister(ctx context.Context, info *ServiceInfo) error {
+	r.mu.RLock()
+	service, err := r.buildService(info)
+	r.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	if err := r.client.registerService(ctx, service); err != nil {
+		return err
+	}
+	return r.client.passTTL(ctx, info.InstanceID)
+}
+
+// consulTTL returns the TTL of the check (default 10s)
+func consulTTL(info *ServiceInfo) time.Duration {
+	if info.TTL <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(info.TTL) * time.Second
+}
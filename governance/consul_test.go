@@ -0,0 +1,368 @@
+package governance
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConsul in-memory Consul agent (service registration, TTL checks and blocking health queries)
+type fakeConsul struct {
+	mu       sync.Mutex
+	index    uint64
+	changed  chan struct{}
+	services map[string]*consulService
+	status   map[string]string // check status by service ID
+	passes   map[string]int    // TTL passes by service ID
+	tokens   []string
+	server   *httptest.Server
+}
+
+func newFakeConsul(t *testing.T) *fakeConsul {
+	f := &fakeConsul{
+		index:    1,
+		changed:  make(chan struct{}),
+		services: make(map[string]*consulService),
+		status:   make(map[string]string),
+		passes:   make(map[string]int),
+	}
+	f.server = httptest.NewServer(http.HandlerFunc(f.handle))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// bump increments the index and wakes the blocking queries (lock required)
+func (f *fakeConsul) bump() {
+	f.index++
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+// setStatus sets the check status of a service
+func (f *fakeConsul) setStatus(id, status string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.status[id] = status
+	f.bump()
+}
+
+func (f *fakeConsul) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.tokens = append(f.tokens, r.Header.Get("X-Consul-Token"))
+	f.mu.Unlock()
+
+	switch {
+	case r.URL.Path == "/v1/agent/service/register":
+		var service consulService
+		if err := json.NewDecoder(r.Body).Decode(&service); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.mu.Lock()
+		f.services[service.ID] = &service
+		f.status[service.ID] = "critical"
+		f.bump()
+		f.mu.Unlock()
+
+	case strings.HasPrefix(r.URL.Path, "/v1/agent/service/deregister/"):
+		id := strings.TrimPrefix(r.URL.Path, "/v1/agent/service/deregister/")
+		f.mu.Lock()
+		delete(f.services, id)
+		f.bump()
+		f.mu.Unlock()
+
+	case strings.HasPrefix(r.URL.Path, "/v1/agent/check/pass/service:"):
+		id := strings.TrimPrefix(r.URL.Path, "/v1/agent/check/pass/service:")
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if _, ok := f.services[id]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		f.passes[id]++
+		if f.status[id] != "passing" {
+			f.status[id] = "passing"
+			f.bump()
+		}
+
+	case strings.HasPrefix(r.URL.Path, "/v1/health/service/"):
+		f.handleHealth(w, r, strings.TrimPrefix(r.URL.Path, "/v1/health/service/"))
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeConsul) handleHealth(w http.ResponseWriter, r *http.Request, name string) {
+	// Blocking query: wait for a newer index or the wait time
+	if index, _ := strconv.ParseUint(r.URL.Query().Get("index"), 10, 64); index > 0 {
+		wait, _ := time.ParseDuration(r.URL.Query().Get("wait"))
+		f.mu.Lock()
+		current, changed := f.index, f.changed
+		f.mu.Unlock()
+		if current <= index {
+			select {
+			case <-changed:
+			case <-time.After(wait):
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tags := r.URL.Query()["tag"]
+	entries := []map[string]interface{}{}
+	for id, service := range f.services {
+		if service.Name != name || !hasTags(service.Tags, tags) {
+			continue
+		}
+		entries = append(entries, map[string]interface{}{
+			"Node": map[string]string{"Address": "10.0.0.1"},
+			"Service": map[string]interface{}{
+				"ID": id, "Service": service.Name, "Address": service.Address, "Port": service.Port,
+				"Tags": service.Tags, "Meta": service.Meta,
+			},
+			"Checks": []map[string]string{{"Status": "passing"}, {"Status": f.status[id]}},
+		})
+	}
+	w.Header().Set("X-Consul-Index", strconv.FormatUint(f.index, 10))
+	_ = json.NewEncoder(w).Encode(entries)
+}
+
+// hasTags reports whether all wanted tags are present
+func hasTags(tags, wanted []string) bool {
+	for _, want := range wanted {
+		found := false
+		for _, tag := range tags {
+			found = found || tag == want
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func newTestServiceInfo() *ServiceInfo {
+	return &ServiceInfo{
+		ServiceName: "order-service",
+		InstanceID:  "order-1",
+		Address:     "192.168.1.10",
+		Port:        9002,
+		Protocol:    "grpc",
+		Version:     "v1.2.0",
+		Metadata:    map[string]string{"zone": "a", "weight": "50"},
+		TTL:         3,
+	}
+}
+
+func TestConsulRegistry_RegisterTTL(t *testing.T) {
+	fake := newFakeConsul(t)
+	registry, err := NewConsulRegistry(ConsulRegistryConfig{
+		Address: fake.server.URL,
+		Token:   "secret",
+		Tags:    []string{"primary"},
+	}, nil)
+	require.NoError(t, err)
+	defer registry.Close()
+
+	require.NoError(t, registry.Register(context.Background(), newTestServiceInfo()))
+	assert.True(t, registry.IsRegistered())
+
+	fake.mu.Lock()
+	service := fake.services["order-1"]
+	assert.Equal(t, "passing", fake.status["order-1"])
+	assert.Contains(t, fake.tokens, "secret")
+	fake.mu.Unlock()
+
+	require.NotNil(t, service)
+	assert.Equal(t, "order-service", service.Name)
+	assert.Equal(t, []string{"primary"}, service.Tags)
+	assert.Equal(t, "v1.2.0", service.Meta["version"])
+	assert.Equal(t, "a", service.Meta["zone"])
+	assert.Equal(t, 50, service.Weights.Passing)
+	assert.Equal(t, "3s", service.Check.TTL)
+	assert.Equal(t, "1m0s", service.Check.DeregisterCriticalServiceAfter)
+
+	// Heartbeats keep passing the TTL check
+	assert.Eventually(t, func() bool {
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		return fake.passes["order-1"] >= 2
+	}, 3*time.Second, 50*time.Millisecond)
+
+	require.NoError(t, registry.UpdateMetadata(context.Background(), map[string]string{"zone": "b"}))
+	fake.mu.Lock()
+	assert.Equal(t, "b", fake.services["order-1"].Meta["zone"])
+	fake.mu.Unlock()
+
+	require.NoError(t, registry.Deregister(context.Background()))
+	assert.False(t, registry.IsRegistered())
+	fake.mu.Lock()
+	assert.Empty(t, fake.services)
+	fake.mu.Unlock()
+
+	assert.ErrorIs(t, registry.Deregister(context.Background()), ErrNotRegistered)
+
+	// Registering again after Deregister restarts the heartbeats
+	require.NoError(t, registry.Register(context.Background(), newTestServiceInfo()))
+	fake.mu.Lock()
+	passes := fake.passes["order-1"]
+	fake.mu.Unlock()
+	assert.Eventually(t, func() bool {
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		return fake.passes["order-1"] >= passes+2
+	}, 3*time.Second, 50*time.Millisecond)
+}
+
+func TestConsulRegistry_RegisterHTTPCheck(t *testing.T) {
+	fake := newFakeConsul(t)
+	registry, err := NewConsulRegistry(ConsulRegistryConfig{Address: fake.server.URL, CheckType: ConsulCheckHTTP}, nil)
+	require.NoError(t, err)
+	defer registry.Close()
+
+	info := newTestServiceInfo()
+	assert.Error(t, registry.Register(context.Background(), info), "http check without URL")
+
+	info.HealthCheck = &HealthCheckConfig{Enabled: true, Path: "/health", Interval: 5}
+	require.NoError(t, registry.Register(context.Background(), info))
+
+	fake.mu.Lock()
+	check := fake.services["order-1"].Check
+	fake.mu.Unlock()
+	assert.Equal(t, "http://192.168.1.10:9002/health", check.HTTP)
+	assert.Equal(t, "5s", check.Interval)
+	assert.Empty(t, check.TTL)
+}
+
+func TestConsulRegistry_ReRegistersUnknownService(t *testing.T) {
+	fake := newFakeConsul(t)
+	registry, err := NewConsulRegistry(ConsulRegistryConfig{Address: fake.server.URL}, nil)
+	require.NoError(t, err)
+	defer registry.Close()
+
+	require.NoError(t, registry.Register(context.Background(), newTestServiceInfo()))
+
+	// The agent loses the service (e.g., restart)
+	fake.mu.Lock()
+	delete(fake.services, "order-1")
+	fake.mu.Unlock()
+
+	assert.Eventually(t, func() bool {
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		return fake.services["order-1"] != nil
+	}, 3*time.Second, 50*time.Millisecond)
+}
+
+func TestConsulDiscovery_DiscoverAndWatch(t *testing.T) {
+	fake := newFakeConsul(t)
+	registry, err := NewConsulRegistry(ConsulRegistryConfig{Address: fake.server.URL, Tags: []string{"primary"}}, nil)
+	require.NoError(t, err)
+	defer registry.Close()
+	require.NoError(t, registry.Register(context.Background(), newTestServiceInfo()))
+
+	discovery := NewConsulDiscovery(ConsulRegistryConfig{
+		Address:   fake.server.URL,
+		WatchTags: []string{"primary"},
+		WaitTime:  time.Second,
+	}, nil)
+	defer discovery.Stop()
+
+	instances, err := discovery.Discover(context.Background(), "order-service")
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+	assert.Equal(t, "order-1", instances[0].ID)
+	assert.Equal(t, "192.168.1.10:9002", instances[0].GetAddress())
+	assert.Equal(t, "a", instances[0].Metadata["zone"])
+	assert.Equal(t, []string{"primary"}, instances[0].Tags)
+	assert.Equal(t, 50, instances[0].Weight)
+	assert.True(t, instances[0].Healthy)
+
+	watchCh, err := discovery.Watch(context.Background(), "order-service")
+	require.NoError(t, err)
+	initial := <-watchCh
+	require.Len(t, initial, 1)
+
+	// A critical check marks the instance unhealthy
+	fake.setStatus("order-1", "critical")
+	select {
+	case updated := <-watchCh:
+		require.Len(t, updated, 1)
+		assert.False(t, updated[0].Healthy)
+	case <-time.After(3 * time.Second):
+		t.Fatal("expected an update after the check change")
+	}
+
+	// Stop closes the channel
+	discovery.Stop()
+	assert.Eventually(t, func() bool {
+		select {
+		case _, ok := <-watchCh:
+			return !ok
+		default:
+			return false
+		}
+	}, 3*time.Second, 10*time.Millisecond)
+}
+
+func TestConsulRegistryConfig_Validate(t *testing.T) {
+	cfg := ConsulRegistryConfig{CheckType: "grpc"}
+	assert.Error(t, cfg.Validate())
+
+	cfg = ConsulRegistryConfig{}
+	cfg.ApplyDefaults()
+	assert.NoError(t, cfg.Validate())
+	assert.Equal(t, ConsulCheckTTL, cfg.CheckType)
+	assert.Equal(t, "127.0.0.1:8500", cfg.Address)
+}
+
+func TestNewServiceRegistry(t *testing.T) {
+	registry, err := NewServiceRegistry(Config{RegistryType: RegistryTypeConsul}, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &ConsulRegistry{}, registry)
+
+	_, err = NewServiceRegistry(Config{RegistryType: "nacos"}, nil)
+	assert.Error(t, err)
+
+	discovery, err := NewServiceDiscovery(Config{RegistryType: RegistryTypeConsul}, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &ConsulDiscovery{}, discovery)
+}
+
+func TestConsulDiscovery_WatchWithoutIndex(t *testing.T) {
+	// An agent (or proxy) that drops X-Consul-Index answers every query at once
+	var mu sync.Mutex
+	queries := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries++
+		mu.Unlock()
+		_, _ = w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	discovery := NewConsulDiscovery(ConsulRegistryConfig{Address: server.URL, WaitTime: time.Second}, nil)
+	defer discovery.Stop()
+
+	_, err := discovery.Watch(context.Background(), "order-service")
+	require.NoError(t, err)
+	time.Sleep(1500 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.LessOrEqual(t, queries, 4)
+}
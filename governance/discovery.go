@@ -18,13 +18,14 @@ type ServiceDiscovery interface {
 
 // ServiceInstance service instance information
 type ServiceInstance struct {
-	ID       string            `json:"id"`       // instance ID
-	Service  string            `json:"service"`  // service name
-	Address  string            `json:"address"`  // IP address
-	Port     int               `json:"port"`     // Port
-	Metadata map[string]string `json:"metadata"` // metadata
-	Weight   int               `json:"weight"`   // weight (for load balancing)
-	Healthy  bool              `json:"healthy"`  // health status
+	ID       string            `json:"id"`             // instance ID
+	Service  string            `json:"service"`        // service name
+	Address  string            `json:"address"`        // IP address
+	Port     int               `json:"port"`           // Port
+	Metadata map[string]string `json:"metadata"`       // metadata
+	Tags     []string          `json:"tags,omitempty"` // Tags (registries supporting them, e.g., Consul)
+	Weight   int               `json:"weight"`         // weight (for load balancing)
+	Healthy  bool              `json:"healthy"`        // health status
 }
 
 // GetAddress Retrieve full address
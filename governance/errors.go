@@ -41,3 +41,9 @@ var (
 	ErrHealthCheckTimeout = errors.New("health check timeout")
 )
 
+
+// Service discovery related errors
+var (
+	// ErrNoAvailableInstance No instance available (none discovered, healthy or matching the routing rules)
+	ErrNoAvailableInstance = errors.New("no available instance")
+)
@@ -210,12 +210,24 @@ func (d *EtcdDiscovery) parseServiceInstance(key, value string) (*ServiceInstanc
 	}
 
 	// Parsing successful, convert to ServiceInstance
+	metadata := info.Metadata
+	if metadata == nil {
+		metadata = make(map[string]string)
+	}
+	// The version is used by canary routing (metadata key "version")
+	if info.Version != "" && metadata["version"] == "" {
+		metadata["version"] = info.Version
+	}
+	// The registered health check path is used by the HTTP prober
+	if info.HealthCheck != nil && info.HealthCheck.Path != "" && metadata[MetadataHealthCheckPath] == "" {
+		metadata[MetadataHealthCheckPath] = info.HealthCheck.Path
+	}
 	return &ServiceInstance{
 		ID:       instanceID,
 		Service:  info.ServiceName,
 		Address:  info.Address,
 		Port:     info.Port,
-		Metadata: info.Metadata,
+		Metadata: metadata,
 		Weight:   100, // Default weight
 		Healthy:  true,
 	}, nil
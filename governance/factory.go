@@ -0,0 +1,65 @@
+package governance
+
+import (
+	"fmt"
+
+	"github.com/KOMKZ/go-yogan-framework/logger"
+)
+
+// Registry center types
+const (
+	RegistryTypeEtcd   = "etcd"
+	RegistryTypeConsul = "consul"
+	RegistryTypeStatic = "static" // Discovery only: governance.static.services
+	RegistryTypeFile   = "file"   // Discovery only: governance.static.file
+)
+
+// NewServiceRegistry creates the registry of cfg.RegistryType (default etcd)
+func NewServiceRegistry(cfg Config, log *logger.CtxZapLogger) (ServiceRegistry, error) {
+	switch cfg.RegistryType {
+	case "", RegistryTypeEtcd:
+		return NewEtcdRegistry(cfg.Etcd, log)
+	case RegistryTypeConsul:
+		return NewConsulRegistry(cfg.Consul, log)
+	case RegistryTypeStatic, RegistryTypeFile:
+		return nil, fmt.Errorf("registry type %s only supports discovery", cfg.RegistryType)
+	default:
+		return nil, fmt.Errorf("unsupported registry type: %s", cfg.RegistryType)
+	}
+}
+
+// NewServiceDiscovery creates the discoverer of cfg.RegistryType (default etcd)
+func NewServiceDiscovery(cfg Config, log *logger.CtxZapLogger) (ServiceDiscovery, error) {
+	switch cfg.RegistryType {
+	case "", RegistryTypeEtcd:
+		clientCfg := defaultEtcdClientConfig()
+		if len(cfg.Etcd.Endpoints) > 0 {
+			clientCfg.Endpoints = cfg.Etcd.Endpoints
+		}
+		if cfg.Etcd.DialTimeout > 0 {
+			clientCfg.DialTimeout = cfg.Etcd.DialTimeout
+		}
+		clientCfg.Username = cfg.Etcd.Username
+		clientCfg.Password = cfg.Etcd.Password
+
+		client, err := newEtcdClient(clientCfg, log)
+		if err != nil {
+			return nil, err
+		}
+		return NewEtcdDiscovery(client, log), nil
+	case RegistryTypeConsul:
+		if err := cfg.Consul.Validate(); err != nil {
+			return nil, err
+		}
+		return NewConsulDiscovery(cfg.Consul, log), nil
+	case RegistryTypeStatic:
+		return NewStaticDiscovery(cfg.Static.Services, log)
+	case RegistryTypeFile:
+		if cfg.Static.File == "" {
+			return nil, fmt.Errorf("governance.static.file cannot be empty")
+		}
+		return NewFileDiscovery(cfg.Static.File, cfg.Static.PollInterval, log)
+	default:
+		return nil, fmt.Errorf("unsupported registry type: %s", cfg.RegistryType)
+	}
+}
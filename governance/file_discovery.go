@@ -0,0 +1,144 @@
+package governance
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/logger"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// discoveryFile content of a discovery file (YAML or JSON)
+//
+//	services:
+//	  user-service:
+//	    - address: 10.0.0.1:9000
+//	      metadata: {zone: a}
+type discoveryFile struct {
+	Services map[string][]StaticInstance `yaml:"services"`
+}
+
+// FileDiscovery service discovery from a YAML/JSON file
+// The file is polled and the Watch channels receive the lists that changed. An invalid file keeps the last good lists.
+type FileDiscovery struct {
+	*StaticDiscovery
+
+	path     string
+	interval time.Duration
+	content  []byte
+	ctx      context.Context
+	cancel   context.CancelFunc
+	logger   *logger.CtxZapLogger
+}
+
+// NewFileDiscovery creates a file service discoverer (interval <= 0 means 2s)
+func NewFileDiscovery(path string, interval time.Duration, log *logger.CtxZapLogger) (*FileDiscovery, error) {
+	if log == nil {
+		log = logger.GetLogger("yogan")
+	}
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+
+	content, services, err := readDiscoveryFile(path)
+	if err != nil {
+		return nil, err
+	}
+	static, err := NewStaticDiscovery(services, log)
+	if err != nil {
+		return nil, fmt.Errorf("discovery file %s: %w", path, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d := &FileDiscovery{
+		StaticDiscovery: static,
+		path:            path,
+		interval:        interval,
+		content:         content,
+		ctx:             ctx,
+		cancel:          cancel,
+		logger:          log,
+	}
+	go d.watchFile()
+
+	return d, nil
+}
+
+// watchFile reloads the file when its content changes
+func (d *FileDiscovery) watchFile() {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-ticker.C:
+			d.reload()
+		}
+	}
+}
+
+// reload reads the file and applies it if the content changed
+func (d *FileDiscovery) reload() {
+	content, err := os.ReadFile(d.path)
+	if err != nil {
+		d.logger.WarnCtx(d.ctx, "⚠️  Failed to read discovery file, keeping the last instances",
+			zap.String("path", d.path), zap.Error(err))
+		return
+	}
+	if bytes.Equal(content, d.content) {
+		return
+	}
+
+	// Remember the content, an invalid file is reported once
+	d.content = content
+
+	services, err := parseDiscoveryFile(content)
+	if err == nil {
+		err = d.Update(services)
+	}
+	if err != nil {
+		d.logger.WarnCtx(d.ctx, "⚠️  Invalid discovery file, keeping the last instances",
+			zap.String("path", d.path), zap.Error(err))
+		return
+	}
+
+	d.logger.DebugCtx(d.ctx, "✅ Discovery file reloaded", zap.String("path", d.path))
+}
+
+// Stop Service discovery (stops the file watch and closes the Watch channels)
+func (d *FileDiscovery) Stop() {
+	d.cancel()
+	d.StaticDiscovery.Stop()
+}
+
+// readDiscoveryFile reads and parses a discovery file
+func readDiscoveryFile(path string) ([]byte, map[string][]StaticInstance, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read discovery file: %w", err)
+	}
+	services, err := parseDiscoveryFile(content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("discovery file %s: %w", path, err)
+	}
+	return content, services, nil
+}
+
+// parseDiscoveryFile parses YAML or JSON (JSON is valid YAML)
+// The services key is required, so a file caught in the middle of a write is not read as "no instances".
+func parseDiscoveryFile(content []byte) (map[string][]StaticInstance, error) {
+	var file discoveryFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, err
+	}
+	if file.Services == nil {
+		return nil, fmt.Errorf("missing services key")
+	}
+	return file.Services, nil
+}
@@ -0,0 +1,257 @@
+package governance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// MetadataHealthCheckPath instance metadata key overriding the HTTP health check path
+const MetadataHealthCheckPath = "health_check_path"
+
+// interval returns the check interval (default 10s)
+func (c HealthCheckConfig) interval() time.Duration {
+	if c.Interval <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(c.Interval) * time.Second
+}
+
+// timeout returns the timeout of a probe (default 3s)
+func (c HealthCheckConfig) timeout() time.Duration {
+	if c.Timeout <= 0 {
+		return 3 * time.Second
+	}
+	return time.Duration(c.Timeout) * time.Second
+}
+
+// InstanceProber probes the health of an instance
+// Return nil indicates healthy, return error indicates unhealthy
+type InstanceProber interface {
+	Probe(ctx context.Context, instance *ServiceInstance) error
+}
+
+// HTTPProber probes GET http://{address}{path}, any 2xx / 3xx status is healthy
+type HTTPProber struct {
+	path   string
+	client *http.Client
+}
+
+// NewHTTPProber creates an HTTP prober (default path /health)
+// The instance metadata health_check_path (registered HealthCheckConfig.Path) takes precedence.
+func NewHTTPProber(path string) *HTTPProber {
+	if path == "" {
+		path = "/health"
+	}
+	return &HTTPProber{path: path, client: &http.Client{}}
+}
+
+// Probe the instance
+func (p *HTTPProber) Probe(ctx context.Context, instance *ServiceInstance) error {
+	path := p.path
+	if custom := instance.Metadata[MetadataHealthCheckPath]; custom != "" {
+		path = custom
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+instance.GetAddress()+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("health check status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// GRPCProber probes the standard gRPC health service (grpc.health.v1)
+type GRPCProber struct {
+	service string
+}
+
+// NewGRPCProber creates a gRPC prober (empty service: overall server health)
+func NewGRPCProber(service string) *GRPCProber {
+	return &GRPCProber{service: service}
+}
+
+// Probe the instance
+func (p *GRPCProber) Probe(ctx context.Context, instance *ServiceInstance) error {
+	conn, err := grpc.NewClient(instance.GetAddress(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: p.service})
+	if err != nil {
+		return err
+	}
+	if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		return fmt.Errorf("health check status %s", resp.Status)
+	}
+	return nil
+}
+
+// ActiveHealthChecker periodically probes the discovered instances
+// Instances are healthy until a probe fails; a later successful probe makes them healthy again.
+type ActiveHealthChecker struct {
+	config HealthCheckConfig
+	prober InstanceProber
+	logger *logger.CtxZapLogger
+
+	mu        sync.RWMutex
+	targets   map[string][]*ServiceInstance // key: service name
+	unhealthy map[string]error              // key: instanceKey
+	onChange  func()
+
+	kick   chan struct{}
+	once   sync.Once
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewActiveHealthChecker creates an active health checker (probing starts with the first SetInstances)
+func NewActiveHealthChecker(cfg HealthCheckConfig, prober InstanceProber, log *logger.CtxZapLogger) *ActiveHealthChecker {
+	if log == nil {
+		log = logger.GetLogger("yogan")
+	}
+	if prober == nil {
+		prober = NewHTTPProber(cfg.Path)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ActiveHealthChecker{
+		config:    cfg,
+		prober:    prober,
+		logger:    log,
+		targets:   make(map[string][]*ServiceInstance),
+		unhealthy: make(map[string]error),
+		kick:      make(chan struct{}, 1),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// SetOnChange sets the callback invoked when the health of an instance changes
+func (c *ActiveHealthChecker) SetOnChange(fn func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onChange = fn
+}
+
+// SetInstances sets the instances of a service to probe (new instances are probed right away)
+func (c *ActiveHealthChecker) SetInstances(service string, instances []*ServiceInstance) {
+	c.mu.Lock()
+	known := make(map[string]bool, len(c.targets[service]))
+	for _, instance := range c.targets[service] {
+		known[instanceKey(instance)] = true
+	}
+	added := false
+	for _, instance := range instances {
+		if !known[instanceKey(instance)] {
+			added = true
+		}
+	}
+	c.targets[service] = instances
+	c.mu.Unlock()
+
+	c.once.Do(func() { go c.loop() })
+	if !added {
+		return
+	}
+	select {
+	case c.kick <- struct{}{}:
+	default:
+	}
+}
+
+// IsHealthy reports whether the last probe of an instance succeeded
+func (c *ActiveHealthChecker) IsHealthy(instance *ServiceInstance) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, failed := c.unhealthy[instanceKey(instance)]
+	return !failed
+}
+
+// Stop probing
+func (c *ActiveHealthChecker) Stop() {
+	c.cancel()
+}
+
+// loop probes on every interval and on instance updates
+func (c *ActiveHealthChecker) loop() {
+	ticker := time.NewTicker(c.config.interval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+		case <-c.kick:
+		}
+		c.probeAll()
+	}
+}
+
+// probeAll probes every instance concurrently and applies the results
+func (c *ActiveHealthChecker) probeAll() {
+	c.mu.RLock()
+	var instances []*ServiceInstance
+	for _, targets := range c.targets {
+		instances = append(instances, targets...)
+	}
+	c.mu.RUnlock()
+
+	results := make([]error, len(instances))
+	var wg sync.WaitGroup
+	for i, instance := range instances {
+		wg.Add(1)
+		go func(i int, instance *ServiceInstance) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(c.ctx, c.config.timeout())
+			defer cancel()
+			results[i] = c.prober.Probe(ctx, instance)
+		}(i, instance)
+	}
+	wg.Wait()
+	if c.ctx.Err() != nil {
+		return
+	}
+
+	c.mu.Lock()
+	changed := false
+	unhealthy := make(map[string]error, len(c.unhealthy))
+	for i, instance := range instances {
+		key := instanceKey(instance)
+		_, wasUnhealthy := c.unhealthy[key]
+		if results[i] != nil {
+			unhealthy[key] = results[i]
+		}
+		if (results[i] != nil) != wasUnhealthy {
+			changed = true
+			c.logger.InfoCtx(c.ctx, "Instance health changed",
+				zap.String("service", instance.Service),
+				zap.String("instance", key),
+				zap.Bool("healthy", results[i] == nil),
+				zap.Error(results[i]))
+		}
+	}
+	c.unhealthy = unhealthy
+	onChange := c.onChange
+	c.mu.Unlock()
+
+	if changed && onChange != nil {
+		onChange()
+	}
+}
@@ -0,0 +1,151 @@
+package governance
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// serverInstance returns the static instance of a test server
+func serverInstance(id, address string) StaticInstance {
+	return StaticInstance{ID: id, Address: strings.TrimPrefix(address, "http://")}
+}
+
+func TestHTTPProber_Probe(t *testing.T) {
+	var status int32 = http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ready" && r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(int(atomic.LoadInt32(&status)))
+	}))
+	defer server.Close()
+
+	host, port, _ := net.SplitHostPort(strings.TrimPrefix(server.URL, "http://"))
+	portNum, _ := strconv.Atoi(port)
+	instance := &ServiceInstance{Address: host, Port: portNum}
+
+	prober := NewHTTPProber("")
+	assert.NoError(t, prober.Probe(context.Background(), instance))
+
+	atomic.StoreInt32(&status, http.StatusServiceUnavailable)
+	assert.Error(t, prober.Probe(context.Background(), instance))
+
+	// The registered path takes precedence
+	atomic.StoreInt32(&status, http.StatusOK)
+	assert.Error(t, NewHTTPProber("/missing").Probe(context.Background(), instance))
+	instance.Metadata = map[string]string{MetadataHealthCheckPath: "/ready"}
+	assert.NoError(t, NewHTTPProber("/missing").Probe(context.Background(), instance))
+}
+
+func TestGRPCProber_Probe(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	addr := listener.Addr().(*net.TCPAddr)
+	instance := &ServiceInstance{Address: "127.0.0.1", Port: addr.Port}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	assert.NoError(t, NewGRPCProber("").Probe(ctx, instance))
+
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	assert.Error(t, NewGRPCProber("").Probe(ctx, instance))
+}
+
+func TestHealthAwareDiscovery_ActiveChecks(t *testing.T) {
+	var healthy int32 = 1
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&healthy) == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer failing.Close()
+	stable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer stable.Close()
+
+	static, err := NewStaticDiscovery(map[string][]StaticInstance{
+		"user-service": {serverInstance("failing", failing.URL), serverInstance("stable", stable.URL)},
+	}, nil)
+	require.NoError(t, err)
+
+	checker := NewActiveHealthChecker(HealthCheckConfig{Enabled: true, Interval: 1}, nil, nil)
+	discovery := NewHealthAwareDiscovery(static, checker, nil, nil)
+	defer discovery.Stop()
+
+	watchCh, err := discovery.Watch(context.Background(), "user-service")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"failing": true, "stable": true}, healthByID(<-watchCh))
+
+	atomic.StoreInt32(&healthy, 0)
+	waitHealth(t, watchCh, map[string]bool{"failing": false, "stable": true})
+
+	instances, err := discovery.Discover(context.Background(), "user-service")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"failing": false, "stable": true}, healthByID(instances))
+
+	atomic.StoreInt32(&healthy, 1)
+	waitHealth(t, watchCh, map[string]bool{"failing": true, "stable": true})
+}
+
+func TestHealthAwareDiscovery_Outliers(t *testing.T) {
+	static, err := NewStaticDiscovery(map[string][]StaticInstance{
+		"user-service": {{ID: "a", Address: "10.0.0.1:80"}, {ID: "b", Address: "10.0.0.2:80"}},
+	}, nil)
+	require.NoError(t, err)
+
+	discovery := NewHealthAwareDiscovery(static, nil, NewOutlierDetector(OutlierConfig{ConsecutiveErrors: 2}), nil)
+	defer discovery.Stop()
+
+	watchCh, err := discovery.Watch(context.Background(), "user-service")
+	require.NoError(t, err)
+	instances := <-watchCh
+
+	discovery.Report(instances[0], true)
+	discovery.Report(instances[0], true)
+	waitHealth(t, watchCh, map[string]bool{"a": false, "b": true})
+	assert.True(t, instances[0].Healthy, "the discovered instances are not modified")
+}
+
+// healthByID returns the health of the instances by ID
+func healthByID(instances []*ServiceInstance) map[string]bool {
+	health := make(map[string]bool, len(instances))
+	for _, instance := range instances {
+		health[instance.ID] = instance.Healthy
+	}
+	return health
+}
+
+// waitHealth waits for a watched list with the expected health
+func waitHealth(t *testing.T, watchCh <-chan []*ServiceInstance, expected map[string]bool) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case instances := <-watchCh:
+			if assert.ObjectsAreEqual(expected, healthByID(instances)) {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for %v", expected)
+		}
+	}
+}
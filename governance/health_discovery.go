@@ -0,0 +1,166 @@
+package governance
+
+import (
+	"context"
+	"sync"
+
+	"github.com/KOMKZ/go-yogan-framework/logger"
+)
+
+// OutlierReporter receives the outcome of the calls to discovered instances
+type OutlierReporter interface {
+	// Report records a call; failed is a 5xx / Unavailable response or a transport error
+	Report(instance *ServiceInstance, failed bool)
+}
+
+// HealthAwareDiscovery applies active health checks and outlier ejection to a discoverer
+// Instances failing their probe or ejected are returned with Healthy=false, which hides them from
+// every balancer, selector and router. Watch also sends a new list when the health of an instance changes.
+type HealthAwareDiscovery struct {
+	discovery ServiceDiscovery
+	checker   *ActiveHealthChecker // Optional
+	outliers  *OutlierDetector     // Optional
+	logger    *logger.CtxZapLogger
+
+	mu       sync.Mutex
+	watchers map[chan struct{}]struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewHealthAwareDiscovery wraps a discoverer (checker and outliers may be nil)
+func NewHealthAwareDiscovery(discovery ServiceDiscovery, checker *ActiveHealthChecker, outliers *OutlierDetector, log *logger.CtxZapLogger) *HealthAwareDiscovery {
+	if log == nil {
+		log = logger.GetLogger("yogan")
+	}
+	d := &HealthAwareDiscovery{
+		discovery: discovery,
+		checker:   checker,
+		outliers:  outliers,
+		logger:    log,
+		watchers:  make(map[chan struct{}]struct{}),
+		done:      make(chan struct{}),
+	}
+	if checker != nil {
+		checker.SetOnChange(d.notify)
+	}
+	if outliers != nil {
+		outliers.SetOnChange(d.notify)
+	}
+	return d
+}
+
+// Discover service instances (with the health applied)
+func (d *HealthAwareDiscovery) Discover(ctx context.Context, serviceName string) ([]*ServiceInstance, error) {
+	instances, err := d.discovery.Discover(ctx, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	d.track(serviceName, instances)
+	return d.apply(instances), nil
+}
+
+// Watch for service changes (the current list is sent first)
+func (d *HealthAwareDiscovery) Watch(ctx context.Context, serviceName string) (<-chan []*ServiceInstance, error) {
+	latest, err := d.discovery.Discover(ctx, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	updates, err := d.discovery.Watch(ctx, serviceName)
+	if err != nil {
+		return nil, err
+	}
+	d.track(serviceName, latest)
+
+	changed := make(chan struct{}, 1)
+	d.mu.Lock()
+	d.watchers[changed] = struct{}{}
+	d.mu.Unlock()
+
+	watchCh := make(chan []*ServiceInstance, 1)
+	sendLatest(watchCh, d.apply(latest))
+
+	go func() {
+		defer func() {
+			d.mu.Lock()
+			delete(d.watchers, changed)
+			d.mu.Unlock()
+			close(watchCh)
+		}()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-d.done:
+				return
+			case instances, ok := <-updates:
+				if !ok {
+					return
+				}
+				latest = instances
+				d.track(serviceName, latest)
+			case <-changed:
+			}
+			sendLatest(watchCh, d.apply(latest))
+		}
+	}()
+
+	return watchCh, nil
+}
+
+// Report records the outcome of a call (outlier detection)
+func (d *HealthAwareDiscovery) Report(instance *ServiceInstance, failed bool) {
+	if d.outliers != nil {
+		d.outliers.Report(instance, failed)
+	}
+}
+
+// Stop the discoverer, the health checks and the watches
+func (d *HealthAwareDiscovery) Stop() {
+	d.stopOnce.Do(func() {
+		close(d.done)
+		if d.checker != nil {
+			d.checker.Stop()
+		}
+		d.discovery.Stop()
+	})
+}
+
+// track hands the instances of a service to the checker and the detector
+func (d *HealthAwareDiscovery) track(serviceName string, instances []*ServiceInstance) {
+	if d.checker != nil {
+		d.checker.SetInstances(serviceName, instances)
+	}
+	if d.outliers != nil {
+		d.outliers.SetInstances(serviceName, instances)
+	}
+}
+
+// apply returns the instances with Healthy=false for the failed and ejected ones (copies, the input is unchanged)
+func (d *HealthAwareDiscovery) apply(instances []*ServiceInstance) []*ServiceInstance {
+	result := make([]*ServiceInstance, len(instances))
+	for i, instance := range instances {
+		result[i] = instance
+		if !instance.Healthy {
+			continue
+		}
+		if (d.checker != nil && !d.checker.IsHealthy(instance)) || (d.outliers != nil && d.outliers.IsEjected(instance)) {
+			hidden := *instance
+			hidden.Healthy = false
+			result[i] = &hidden
+		}
+	}
+	return result
+}
+
+// notify wakes up the watches after a health change
+func (d *HealthAwareDiscovery) notify() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for changed := range d.watchers {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -129,12 +129,21 @@ func (b *WeightedBalancer) Name() string {
 }
 
 // Create load balancer according to name
+// round_robin | random | weighted | p2c_ewma | least_request | ring_hash | maglev
 func NewLoadBalancer(name string) LoadBalancer {
 	switch name {
 	case "random":
 		return NewRandomBalancer()
 	case "weighted":
 		return NewWeightedBalancer()
+	case "p2c_ewma":
+		return NewP2CBalancer(0)
+	case "least_request":
+		return NewLeastRequestBalancer()
+	case "ring_hash":
+		return NewRingHashBalancer(0)
+	case "maglev":
+		return NewMaglevBalancer(0)
 	case "round_robin", "":
 		return NewRoundRobinBalancer()
 	default:
@@ -0,0 +1,185 @@
+package governance
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// OutlierConfig passive outlier detection
+// An instance is ejected after ConsecutiveErrors failed calls (5xx / Unavailable). Each new ejection of the
+// same instance doubles the ejection time, up to MaxEjectionTime; at most MaxEjectionPercent of the
+// instances of a service are ejected at the same time.
+type OutlierConfig struct {
+	Enabled            bool          `mapstructure:"enabled"`              // Whether outlier detection is enabled
+	ConsecutiveErrors  int           `mapstructure:"consecutive_errors"`   // Consecutive failures before ejection (default 5)
+	BaseEjectionTime   time.Duration `mapstructure:"base_ejection_time"`   // First ejection time (default 30s)
+	MaxEjectionTime    time.Duration `mapstructure:"max_ejection_time"`    // Maximum ejection time (default 300s)
+	MaxEjectionPercent int           `mapstructure:"max_ejection_percent"` // Maximum share of ejected instances (default 50)
+}
+
+// ApplyDefaults applies default values
+func (c *OutlierConfig) ApplyDefaults() {
+	if c.ConsecutiveErrors <= 0 {
+		c.ConsecutiveErrors = 5
+	}
+	if c.BaseEjectionTime <= 0 {
+		c.BaseEjectionTime = 30 * time.Second
+	}
+	if c.MaxEjectionTime <= 0 {
+		c.MaxEjectionTime = 300 * time.Second
+	}
+	if c.MaxEjectionPercent <= 0 {
+		c.MaxEjectionPercent = 50
+	}
+}
+
+// Validate outlier detection configuration
+func (c *OutlierConfig) Validate() error {
+	if c.ConsecutiveErrors < 0 {
+		return fmt.Errorf("outlier consecutive_errors cannot be negative")
+	}
+	if c.MaxEjectionPercent < 0 || c.MaxEjectionPercent > 100 {
+		return fmt.Errorf("outlier max_ejection_percent must be between 0 and 100, got %d", c.MaxEjectionPercent)
+	}
+	if c.BaseEjectionTime > 0 && c.MaxEjectionTime > 0 && c.MaxEjectionTime < c.BaseEjectionTime {
+		return fmt.Errorf("outlier max_ejection_time cannot be less than base_ejection_time")
+	}
+	return nil
+}
+
+// outlierState call history of an instance
+type outlierState struct {
+	service      string
+	failures     int       // Consecutive failures
+	ejections    int       // Ejections so far (grows the ejection time)
+	ejectedUntil time.Time // Zero when not ejected
+	lastEjection time.Time
+}
+
+// OutlierDetector ejects instances with consecutive failures
+type OutlierDetector struct {
+	config OutlierConfig
+
+	mu       sync.Mutex
+	states   map[string]*outlierState // key: instanceKey
+	totals   map[string]int           // Instances of each service (SetInstances)
+	onChange func()
+	now      func() time.Time
+}
+
+// NewOutlierDetector creates an outlier detector
+func NewOutlierDetector(cfg OutlierConfig) *OutlierDetector {
+	cfg.ApplyDefaults()
+	return &OutlierDetector{
+		config: cfg,
+		states: make(map[string]*outlierState),
+		totals: make(map[string]int),
+		now:    time.Now,
+	}
+}
+
+// SetOnChange sets the callback invoked when an instance is ejected or returns
+func (d *OutlierDetector) SetOnChange(fn func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onChange = fn
+}
+
+// SetInstances sets the current instances of a service (base of MaxEjectionPercent)
+// The history of the instances that left the service is dropped.
+func (d *OutlierDetector) SetInstances(service string, instances []*ServiceInstance) {
+	current := make(map[string]struct{}, len(instances))
+	for _, instance := range instances {
+		current[instanceKey(instance)] = struct{}{}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.totals[service] = len(instances)
+	for key, state := range d.states {
+		if _, ok := current[key]; !ok && state.service == service {
+			delete(d.states, key)
+		}
+	}
+}
+
+// Report records the outcome of a call to an instance
+func (d *OutlierDetector) Report(instance *ServiceInstance, failed bool) {
+	if instance == nil {
+		return
+	}
+
+	d.mu.Lock()
+	key := instanceKey(instance)
+	state, ok := d.states[key]
+	if !ok {
+		state = &outlierState{service: instance.Service}
+		d.states[key] = state
+	}
+	if !failed {
+		state.failures = 0
+		d.mu.Unlock()
+		return
+	}
+
+	state.failures++
+	now := d.now()
+	if state.failures < d.config.ConsecutiveErrors || now.Before(state.ejectedUntil) || !d.canEject(state.service, now) {
+		d.mu.Unlock()
+		return
+	}
+
+	// An instance that stayed healthy for the maximum ejection time starts over
+	if !state.lastEjection.IsZero() && now.Sub(state.lastEjection) > d.config.MaxEjectionTime+d.ejectionTime(state.ejections) {
+		state.ejections = 0
+	}
+	state.ejections++
+	ejection := d.ejectionTime(state.ejections)
+	state.ejectedUntil = now.Add(ejection)
+	state.lastEjection = now
+	state.failures = 0
+	onChange := d.onChange
+	d.mu.Unlock()
+
+	if onChange != nil {
+		onChange()
+		// Notify again when the instance returns
+		time.AfterFunc(ejection, onChange)
+	}
+}
+
+// IsEjected reports whether an instance is ejected
+func (d *OutlierDetector) IsEjected(instance *ServiceInstance) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	state, ok := d.states[instanceKey(instance)]
+	return ok && d.now().Before(state.ejectedUntil)
+}
+
+// ejectionTime returns the ejection time of the n-th ejection (doubling, capped)
+func (d *OutlierDetector) ejectionTime(n int) time.Duration {
+	ejection := d.config.BaseEjectionTime
+	for i := 1; i < n && ejection < d.config.MaxEjectionTime; i++ {
+		ejection *= 2
+	}
+	if ejection > d.config.MaxEjectionTime {
+		ejection = d.config.MaxEjectionTime
+	}
+	return ejection
+}
+
+// canEject reports whether one more instance of the service may be ejected (lock required)
+func (d *OutlierDetector) canEject(service string, now time.Time) bool {
+	total := d.totals[service]
+	if total == 0 {
+		return false
+	}
+	ejected := 0
+	for _, state := range d.states {
+		if state.service == service && now.Before(state.ejectedUntil) {
+			ejected++
+		}
+	}
+	return (ejected+1)*100 <= total*d.config.MaxEjectionPercent
+}
@@ -0,0 +1,106 @@
+package governance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestOutlierDetector creates a detector with a manual clock
+func newTestOutlierDetector(cfg OutlierConfig, total int) (*OutlierDetector, *time.Time) {
+	now := time.Unix(1700000000, 0)
+	detector := NewOutlierDetector(cfg)
+	detector.now = func() time.Time { return now }
+	instances := make([]*ServiceInstance, total)
+	for i := range instances {
+		instances[i] = &ServiceInstance{ID: string(rune('a' + i)), Service: "user-service"}
+	}
+	detector.SetInstances("user-service", instances)
+	return detector, &now
+}
+
+func TestOutlierDetector_Ejection(t *testing.T) {
+	detector, now := newTestOutlierDetector(OutlierConfig{
+		ConsecutiveErrors: 3,
+		BaseEjectionTime:  10 * time.Second,
+		MaxEjectionTime:   25 * time.Second,
+	}, 2)
+	instance := &ServiceInstance{ID: "a", Service: "user-service"}
+
+	// A success resets the consecutive failures
+	detector.Report(instance, true)
+	detector.Report(instance, true)
+	detector.Report(instance, false)
+	detector.Report(instance, true)
+	detector.Report(instance, true)
+	assert.False(t, detector.IsEjected(instance))
+
+	detector.Report(instance, true)
+	assert.True(t, detector.IsEjected(instance))
+
+	*now = now.Add(10 * time.Second)
+	assert.False(t, detector.IsEjected(instance))
+
+	// The second ejection lasts twice as long
+	for i := 0; i < 3; i++ {
+		detector.Report(instance, true)
+	}
+	*now = now.Add(19 * time.Second)
+	assert.True(t, detector.IsEjected(instance))
+	*now = now.Add(time.Second)
+	assert.False(t, detector.IsEjected(instance))
+
+	// Capped by the maximum ejection time
+	for i := 0; i < 3; i++ {
+		detector.Report(instance, true)
+	}
+	*now = now.Add(25 * time.Second)
+	assert.False(t, detector.IsEjected(instance))
+}
+
+func TestOutlierDetector_MaxEjectionPercent(t *testing.T) {
+	detector, _ := newTestOutlierDetector(OutlierConfig{ConsecutiveErrors: 1, MaxEjectionPercent: 50}, 4)
+
+	instances := []*ServiceInstance{
+		{ID: "a", Service: "user-service"},
+		{ID: "b", Service: "user-service"},
+		{ID: "c", Service: "user-service"},
+	}
+	for _, instance := range instances {
+		detector.Report(instance, true)
+	}
+	assert.True(t, detector.IsEjected(instances[0]))
+	assert.True(t, detector.IsEjected(instances[1]))
+	assert.False(t, detector.IsEjected(instances[2]), "at most half of the instances are ejected")
+
+	// The only instance of a service is never ejected
+	single, _ := newTestOutlierDetector(OutlierConfig{ConsecutiveErrors: 1}, 1)
+	single.Report(instances[0], true)
+	assert.False(t, single.IsEjected(instances[0]))
+}
+
+func TestOutlierConfig_Validate(t *testing.T) {
+	assert.NoError(t, (&OutlierConfig{}).Validate())
+	assert.Error(t, (&OutlierConfig{MaxEjectionPercent: 150}).Validate())
+	assert.Error(t, (&OutlierConfig{ConsecutiveErrors: -1}).Validate())
+	assert.Error(t, (&OutlierConfig{BaseEjectionTime: time.Minute, MaxEjectionTime: time.Second}).Validate())
+}
+
+func TestOutlierDetector_SetInstancesPrunes(t *testing.T) {
+	detector, _ := newTestOutlierDetector(OutlierConfig{ConsecutiveErrors: 1, MaxEjectionPercent: 50}, 2)
+	a := &ServiceInstance{ID: "a", Service: "user-service"}
+	b := &ServiceInstance{ID: "b", Service: "user-service"}
+	other := &ServiceInstance{ID: "x", Service: "order-service"}
+	detector.Report(a, true)
+	detector.Report(b, false)
+	detector.Report(other, false)
+	assert.True(t, detector.IsEjected(a))
+
+	// Instances that left the service are forgotten, other services are untouched
+	detector.SetInstances("user-service", []*ServiceInstance{b})
+	assert.False(t, detector.IsEjected(a))
+	assert.Len(t, detector.states, 2)
+	assert.Contains(t, detector.states, "b")
+	assert.Contains(t, detector.states, "x")
+}
@@ -47,10 +47,10 @@ type ServiceInfo struct {
 
 // HealthCheckConfig Health check configuration
 type HealthCheckConfig struct {
-	Enabled  bool   `json:"enabled"`  // Whether health checks are enabled
-	Interval int    `json:"interval"` // Check interval (seconds)
-	Timeout  int    `json:"timeout"`  // timeout in seconds
-	Path     string `json:"path"`     // HTTP health check path (such as "/health")
+	Enabled  bool   `json:"enabled" mapstructure:"enabled"`   // Whether health checks are enabled
+	Interval int    `json:"interval" mapstructure:"interval"` // Check interval (seconds)
+	Timeout  int    `json:"timeout" mapstructure:"timeout"`   // timeout in seconds
+	Path     string `json:"path" mapstructure:"path"`         // HTTP health check path (such as "/health")
 }
 
 // GetFullAddress Obtain full address (address:port)
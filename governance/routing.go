@@ -0,0 +1,248 @@
+package governance
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RoutingConfig metadata-aware routing rules of a client
+// Rules apply in order: a matching subset pins the request, otherwise the canary split applies;
+// zone affinity then narrows the result to the local zone.
+type RoutingConfig struct {
+	Zone    ZoneAffinityConfig `mapstructure:"zone"`    // Prefer instances of the caller zone
+	Canary  CanaryConfig       `mapstructure:"canary"`  // Send a share of the traffic to a version
+	Subsets []SubsetRule       `mapstructure:"subsets"` // Pin requests carrying a header / metadata key
+}
+
+// ZoneAffinityConfig zone affinity with spillover
+// The local zone is used while it has at least MinInstances healthy instances and
+// MinHealthyPercent of its instances are healthy, otherwise the traffic spills over to all zones.
+type ZoneAffinityConfig struct {
+	Local             string  `mapstructure:"local"`               // Caller zone (empty disables zone affinity)
+	MetadataKey       string  `mapstructure:"metadata_key"`        // Instance metadata key of the zone (default zone)
+	MinInstances      int     `mapstructure:"min_instances"`       // Minimum healthy local instances (default 1)
+	MinHealthyPercent float64 `mapstructure:"min_healthy_percent"` // Minimum healthy share of the local instances (default 50)
+}
+
+// CanaryConfig version canary
+// Percent of the requests go to the instances of Version, the others to the remaining instances.
+// With a routing key (see WithHashKey) the same key always gets the same side.
+type CanaryConfig struct {
+	Version     string  `mapstructure:"version"`      // Canary version (empty disables the canary)
+	Percent     float64 `mapstructure:"percent"`      // Share of the requests in percent (0-100)
+	MetadataKey string  `mapstructure:"metadata_key"` // Instance metadata key of the version (default version)
+}
+
+// SubsetRule pins the requests carrying a header (HTTP) or metadata key (gRPC) to a subset
+// The subset is the instances matching Metadata and, with MetadataKey, whose MetadataKey equals the header value.
+type SubsetRule struct {
+	Name        string            `mapstructure:"name"`         // Rule name (default subset-{index})
+	Header      string            `mapstructure:"header"`       // Request header / metadata key
+	Value       string            `mapstructure:"value"`        // Header value to match (empty: any value)
+	Metadata    map[string]string `mapstructure:"metadata"`     // Instance metadata of the subset
+	MetadataKey string            `mapstructure:"metadata_key"` // Instance metadata key that must equal the header value
+	Fallback    bool              `mapstructure:"fallback"`     // Use the other routes when the subset has no healthy instance
+}
+
+// ApplyDefaults applies default values
+func (c *RoutingConfig) ApplyDefaults() {
+	if c.Zone.MetadataKey == "" {
+		c.Zone.MetadataKey = "zone"
+	}
+	if c.Zone.MinInstances <= 0 {
+		c.Zone.MinInstances = 1
+	}
+	if c.Zone.MinHealthyPercent <= 0 {
+		c.Zone.MinHealthyPercent = 50
+	}
+	if c.Canary.MetadataKey == "" {
+		c.Canary.MetadataKey = "version"
+	}
+	for i := range c.Subsets {
+		if c.Subsets[i].Name == "" {
+			c.Subsets[i].Name = fmt.Sprintf("subset-%d", i)
+		}
+		c.Subsets[i].Header = strings.ToLower(c.Subsets[i].Header)
+	}
+}
+
+// Validate routing configuration
+func (c *RoutingConfig) Validate() error {
+	if c.Canary.Percent < 0 || c.Canary.Percent > 100 {
+		return fmt.Errorf("canary percent must be between 0 and 100, got %v", c.Canary.Percent)
+	}
+	if c.Zone.MinHealthyPercent < 0 || c.Zone.MinHealthyPercent > 100 {
+		return fmt.Errorf("zone min_healthy_percent must be between 0 and 100, got %v", c.Zone.MinHealthyPercent)
+	}
+	for i, rule := range c.Subsets {
+		if rule.Header == "" {
+			return fmt.Errorf("routing subset %d: header cannot be empty", i)
+		}
+		if len(rule.Metadata) == 0 && rule.MetadataKey == "" {
+			return fmt.Errorf("routing subset %d: metadata or metadata_key is required", i)
+		}
+	}
+	return nil
+}
+
+// IsEnabled reports whether any rule is configured
+func (c *RoutingConfig) IsEnabled() bool {
+	return c.Zone.Local != "" || c.Canary.Version != "" || len(c.Subsets) > 0
+}
+
+// RouteRequest request attributes used by the routing rules
+type RouteRequest struct {
+	Attributes map[string]string // Request headers / gRPC metadata (lowercase keys)
+	Key        string            // Routing key of the canary split (empty: random)
+}
+
+// SubsetMatch subset selected for a request
+type SubsetMatch struct {
+	Rule  *SubsetRule
+	Value string // Header value of the request
+}
+
+// Router applies the routing rules to instance lists
+type Router struct {
+	config RoutingConfig
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// NewRouter creates a router (the configuration must be valid)
+func NewRouter(cfg RoutingConfig) *Router {
+	cfg.ApplyDefaults()
+	return &Router{
+		config: cfg,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Config returns the routing configuration (with defaults)
+func (r *Router) Config() RoutingConfig {
+	return r.config
+}
+
+// MatchSubset returns the first subset rule matching the request attributes
+func (r *Router) MatchSubset(attributes map[string]string) (SubsetMatch, bool) {
+	for i := range r.config.Subsets {
+		rule := &r.config.Subsets[i]
+		value, ok := attributes[rule.Header]
+		if !ok || value == "" || (rule.Value != "" && rule.Value != value) {
+			continue
+		}
+		return SubsetMatch{Rule: rule, Value: value}, true
+	}
+	return SubsetMatch{}, false
+}
+
+// Route returns the healthy instances a request may use
+// An empty result means no instance matches (a pinned subset without fallback).
+func (r *Router) Route(instances []*ServiceInstance, req RouteRequest) []*ServiceInstance {
+	if match, ok := r.MatchSubset(req.Attributes); ok {
+		subset := filterInstances(instances, func(instance *ServiceInstance) bool {
+			return instance.Healthy && match.contains(instance)
+		})
+		if len(subset) > 0 || !match.Rule.Fallback {
+			return r.preferZone(subset, instances)
+		}
+	}
+
+	healthy := filterInstances(instances, func(instance *ServiceInstance) bool { return instance.Healthy })
+	return r.preferZone(r.splitCanary(healthy, req.Key), instances)
+}
+
+// contains reports whether the instance belongs to the subset
+func (m SubsetMatch) contains(instance *ServiceInstance) bool {
+	for k, v := range m.Rule.Metadata {
+		if instance.Metadata[k] != v {
+			return false
+		}
+	}
+	if m.Rule.MetadataKey != "" && instance.Metadata[m.Rule.MetadataKey] != m.Value {
+		return false
+	}
+	return true
+}
+
+// splitCanary keeps the canary or the stable instances of a request
+// When one side has no healthy instance the other side takes all the traffic.
+func (r *Router) splitCanary(healthy []*ServiceInstance, key string) []*ServiceInstance {
+	canary := r.config.Canary
+	if canary.Version == "" {
+		return healthy
+	}
+
+	var canaries, stable []*ServiceInstance
+	for _, instance := range healthy {
+		if instance.Metadata[canary.MetadataKey] == canary.Version {
+			canaries = append(canaries, instance)
+		} else {
+			stable = append(stable, instance)
+		}
+	}
+	if len(canaries) == 0 || len(stable) == 0 {
+		return healthy
+	}
+
+	if r.percentile(key) < canary.Percent {
+		return canaries
+	}
+	return stable
+}
+
+// percentile returns the position of a request in [0, 100), stable for a key
+func (r *Router) percentile(key string) float64 {
+	if key != "" {
+		return float64(hash64(key)%10000) / 100
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rand.Float64() * 100
+}
+
+// preferZone narrows the candidates to the local zone unless it needs to spill over
+// The spillover decision looks at all the instances of the local zone (healthy or not).
+func (r *Router) preferZone(candidates, all []*ServiceInstance) []*ServiceInstance {
+	zone := r.config.Zone
+	if zone.Local == "" || len(candidates) == 0 {
+		return candidates
+	}
+
+	total, healthy := 0, 0
+	for _, instance := range all {
+		if instance.Metadata[zone.MetadataKey] != zone.Local {
+			continue
+		}
+		total++
+		if instance.Healthy {
+			healthy++
+		}
+	}
+	if healthy < zone.MinInstances || float64(healthy)*100 < float64(total)*zone.MinHealthyPercent {
+		return candidates
+	}
+
+	local := filterInstances(candidates, func(instance *ServiceInstance) bool {
+		return instance.Metadata[zone.MetadataKey] == zone.Local
+	})
+	if len(local) == 0 {
+		return candidates
+	}
+	return local
+}
+
+// filterInstances returns the instances matching keep
+func filterInstances(instances []*ServiceInstance, keep func(*ServiceInstance) bool) []*ServiceInstance {
+	filtered := make([]*ServiceInstance, 0, len(instances))
+	for _, instance := range instances {
+		if keep(instance) {
+			filtered = append(filtered, instance)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,116 @@
+package governance
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// routedInstance creates an instance with metadata
+func routedInstance(id string, healthy bool, metadata map[string]string) *ServiceInstance {
+	return &ServiceInstance{ID: id, Address: "10.0.0.1", Port: 9000, Healthy: healthy, Metadata: metadata}
+}
+
+// routedIDs returns the IDs of routed instances
+func routedIDs(instances []*ServiceInstance) []string {
+	ids := make([]string, 0, len(instances))
+	for _, instance := range instances {
+		ids = append(ids, instance.ID)
+	}
+	return ids
+}
+
+func TestRouter_ZoneAffinity(t *testing.T) {
+	router := NewRouter(RoutingConfig{Zone: ZoneAffinityConfig{Local: "a", MinInstances: 2}})
+
+	instances := []*ServiceInstance{
+		routedInstance("a1", true, map[string]string{"zone": "a"}),
+		routedInstance("a2", true, map[string]string{"zone": "a"}),
+		routedInstance("a3", false, map[string]string{"zone": "a"}),
+		routedInstance("b1", true, map[string]string{"zone": "b"}),
+	}
+	assert.Equal(t, []string{"a1", "a2"}, routedIDs(router.Route(instances, RouteRequest{})))
+
+	// Below the minimum healthy instances: spill over to all zones
+	instances[1].Healthy = false
+	assert.Equal(t, []string{"a1", "b1"}, routedIDs(router.Route(instances, RouteRequest{})))
+
+	// Below the minimum healthy percent
+	router = NewRouter(RoutingConfig{Zone: ZoneAffinityConfig{Local: "a", MinHealthyPercent: 60}})
+	assert.Equal(t, []string{"a1", "b1"}, routedIDs(router.Route(instances, RouteRequest{})))
+}
+
+func TestRouter_Canary(t *testing.T) {
+	router := NewRouter(RoutingConfig{Canary: CanaryConfig{Version: "v2", Percent: 20}})
+
+	instances := []*ServiceInstance{
+		routedInstance("stable", true, map[string]string{"version": "v1"}),
+		routedInstance("canary", true, map[string]string{"version": "v2"}),
+	}
+
+	canary := 0
+	for i := 0; i < 10000; i++ {
+		routed := router.Route(instances, RouteRequest{Key: fmt.Sprintf("user-%d", i)})
+		require.Len(t, routed, 1)
+		if routed[0].ID == "canary" {
+			canary++
+		}
+	}
+	assert.InDelta(t, 2000, canary, 300)
+
+	// The same key always gets the same side
+	first := router.Route(instances, RouteRequest{Key: "user-42"})[0].ID
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, first, router.Route(instances, RouteRequest{Key: "user-42"})[0].ID)
+	}
+
+	// Without canary instances the stable ones take all the traffic
+	instances[1].Healthy = false
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, []string{"stable"}, routedIDs(router.Route(instances, RouteRequest{})))
+	}
+}
+
+func TestRouter_Subsets(t *testing.T) {
+	router := NewRouter(RoutingConfig{Subsets: []SubsetRule{
+		{Header: "X-Env", Value: "staging", Metadata: map[string]string{"env": "staging"}},
+		{Header: "x-tenant", MetadataKey: "tenant", Fallback: true},
+	}})
+
+	instances := []*ServiceInstance{
+		routedInstance("prod", true, map[string]string{"env": "prod"}),
+		routedInstance("staging", true, map[string]string{"env": "staging"}),
+		routedInstance("acme", true, map[string]string{"env": "prod", "tenant": "acme"}),
+	}
+
+	assert.Equal(t, []string{"staging"},
+		routedIDs(router.Route(instances, RouteRequest{Attributes: map[string]string{"x-env": "staging"}})))
+	assert.Equal(t, []string{"acme"},
+		routedIDs(router.Route(instances, RouteRequest{Attributes: map[string]string{"x-tenant": "acme"}})))
+	assert.Len(t, router.Route(instances, RouteRequest{Attributes: map[string]string{"x-env": "dev"}}), 3)
+
+	// Fallback: an unknown tenant uses the other routes
+	assert.Len(t, router.Route(instances, RouteRequest{Attributes: map[string]string{"x-tenant": "other"}}), 3)
+
+	// No fallback: an empty subset matches nothing
+	instances[1].Healthy = false
+	assert.Empty(t, router.Route(instances, RouteRequest{Attributes: map[string]string{"x-env": "staging"}}))
+
+	match, ok := router.MatchSubset(map[string]string{"x-tenant": "acme"})
+	require.True(t, ok)
+	assert.Equal(t, "subset-1", match.Rule.Name)
+	assert.Equal(t, "acme", match.Value)
+}
+
+func TestRoutingConfig_Validate(t *testing.T) {
+	assert.NoError(t, (&RoutingConfig{}).Validate())
+	assert.False(t, (&RoutingConfig{}).IsEnabled())
+	assert.True(t, (&RoutingConfig{Canary: CanaryConfig{Version: "v2"}}).IsEnabled())
+
+	assert.Error(t, (&RoutingConfig{Canary: CanaryConfig{Version: "v2", Percent: 120}}).Validate())
+	assert.Error(t, (&RoutingConfig{Zone: ZoneAffinityConfig{Local: "a", MinHealthyPercent: -1}}).Validate())
+	assert.Error(t, (&RoutingConfig{Subsets: []SubsetRule{{Metadata: map[string]string{"env": "staging"}}}}).Validate())
+	assert.Error(t, (&RoutingConfig{Subsets: []SubsetRule{{Header: "x-env"}}}).Validate())
+}
@@ -0,0 +1,208 @@
+package governance
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/KOMKZ/go-yogan-framework/logger"
+	"go.uber.org/zap"
+)
+
+// StaticInstance service instance declared in configuration or in a discovery file
+type StaticInstance struct {
+	ID       string            `mapstructure:"id" yaml:"id"`             // Instance ID (default address:port)
+	Address  string            `mapstructure:"address" yaml:"address"`   // Host or host:port
+	Port     int               `mapstructure:"port" yaml:"port"`         // Port (optional when the address contains it)
+	Weight   int               `mapstructure:"weight" yaml:"weight"`     // Weight (default 100)
+	Healthy  *bool             `mapstructure:"healthy" yaml:"healthy"`   // Health status (default true)
+	Metadata map[string]string `mapstructure:"metadata" yaml:"metadata"` // metadata
+	Tags     []string          `mapstructure:"tags" yaml:"tags"`         // tags
+}
+
+// toServiceInstance converts the declaration to a service instance
+func (s StaticInstance) toServiceInstance(serviceName string) (*ServiceInstance, error) {
+	address, port := s.Address, s.Port
+	if host, portStr, err := net.SplitHostPort(address); err == nil {
+		p, err := strconv.Atoi(portStr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPort, s.Address)
+		}
+		address, port = host, p
+	}
+	if address == "" {
+		return nil, fmt.Errorf("%w: service %s", ErrInvalidAddress, serviceName)
+	}
+	if port <= 0 || port > 65535 {
+		return nil, fmt.Errorf("%w: %s:%d", ErrInvalidPort, address, port)
+	}
+
+	id := s.ID
+	if id == "" {
+		id = net.JoinHostPort(address, strconv.Itoa(port))
+	}
+	weight := s.Weight
+	if weight <= 0 {
+		weight = 100 // Default weight
+	}
+	healthy := s.Healthy == nil || *s.Healthy
+
+	metadata := make(map[string]string, len(s.Metadata))
+	for k, v := range s.Metadata {
+		metadata[k] = v
+	}
+
+	return &ServiceInstance{
+		ID:       id,
+		Service:  serviceName,
+		Address:  address,
+		Port:     port,
+		Metadata: metadata,
+		Tags:     append([]string(nil), s.Tags...),
+		Weight:   weight,
+		Healthy:  healthy,
+	}, nil
+}
+
+// StaticDiscovery service discovery from a fixed instance list (no registry needed)
+// Update replaces the list at runtime (config reload), Watch channels receive the lists that changed.
+type StaticDiscovery struct {
+	mu       sync.RWMutex
+	services map[string][]*ServiceInstance
+	watchers map[string][]chan []*ServiceInstance
+	stopped  bool
+	logger   *logger.CtxZapLogger
+}
+
+// NewStaticDiscovery creates a static service discoverer
+func NewStaticDiscovery(services map[string][]StaticInstance, log *logger.CtxZapLogger) (*StaticDiscovery, error) {
+	if log == nil {
+		log = logger.GetLogger("yogan")
+	}
+
+	d := &StaticDiscovery{
+		services: make(map[string][]*ServiceInstance),
+		watchers: make(map[string][]chan []*ServiceInstance),
+		logger:   log,
+	}
+	if err := d.Update(services); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Discover service instances (an unknown service has no instances)
+func (d *StaticDiscovery) Discover(ctx context.Context, serviceName string) ([]*ServiceInstance, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.services[serviceName], nil
+}
+
+// Watch for service changes
+// The channel receives the current list first, then the list after each change; it is closed by Stop
+// or when ctx is done. A slow reader only gets the latest list.
+func (d *StaticDiscovery) Watch(ctx context.Context, serviceName string) (<-chan []*ServiceInstance, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	watchCh := make(chan []*ServiceInstance, 1)
+	if d.stopped || ctx.Err() != nil {
+		close(watchCh)
+		return watchCh, nil
+	}
+
+	watchCh <- d.services[serviceName]
+	d.watchers[serviceName] = append(d.watchers[serviceName], watchCh)
+	context.AfterFunc(ctx, func() { d.unwatch(serviceName, watchCh) })
+	return watchCh, nil
+}
+
+// unwatch removes and closes a watch channel (already closed after Stop)
+func (d *StaticDiscovery) unwatch(serviceName string, watchCh chan []*ServiceInstance) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stopped {
+		return
+	}
+	watchers := d.watchers[serviceName]
+	for i, ch := range watchers {
+		if ch == watchCh {
+			watchers = append(watchers[:i:i], watchers[i+1:]...)
+			break
+		}
+	}
+	if len(watchers) == 0 {
+		delete(d.watchers, serviceName)
+	} else {
+		d.watchers[serviceName] = watchers
+	}
+	close(watchCh)
+}
+
+// Update replaces the instance lists and notifies the watchers of the services that changed
+// The lists are left untouched when a declaration is invalid.
+func (d *StaticDiscovery) Update(services map[string][]StaticInstance) error {
+	converted := make(map[string][]*ServiceInstance, len(services))
+	for name, declared := range services {
+		instances := make([]*ServiceInstance, 0, len(declared))
+		for _, s := range declared {
+			instance, err := s.toServiceInstance(name)
+			if err != nil {
+				return err
+			}
+			instances = append(instances, instance)
+		}
+		converted[name] = instances
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	old := d.services
+	d.services = converted
+	for name, watchers := range d.watchers {
+		if reflect.DeepEqual(old[name], converted[name]) {
+			continue
+		}
+		d.logger.DebugCtx(context.Background(), "🔄 Static service instances changed",
+			zap.String("service", name),
+			zap.Int("instances", len(converted[name])))
+		for _, watchCh := range watchers {
+			sendLatest(watchCh, converted[name])
+		}
+	}
+	return nil
+}
+
+// Stop Service discovery (closes the Watch channels)
+func (d *StaticDiscovery) Stop() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.stopped {
+		return
+	}
+	d.stopped = true
+	for _, watchers := range d.watchers {
+		for _, watchCh := range watchers {
+			close(watchCh)
+		}
+	}
+	d.watchers = nil
+}
+
+// sendLatest replaces the pending list of a watch channel (buffer of 1) with the latest one
+func sendLatest(watchCh chan []*ServiceInstance, instances []*ServiceInstance) {
+	select {
+	case <-watchCh:
+	default:
+	}
+	select {
+	case watchCh <- instances:
+	default:
+	}
+}
@@ -0,0 +1,165 @@
+package governance
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticDiscovery_Discover(t *testing.T) {
+	unhealthy := false
+	discovery, err := NewStaticDiscovery(map[string][]StaticInstance{
+		"user-service": {
+			{Address: "10.0.0.1:9000", Metadata: map[string]string{"zone": "a"}, Tags: []string{"v1"}},
+			{ID: "user-2", Address: "10.0.0.2", Port: 9000, Weight: 30, Healthy: &unhealthy},
+		},
+	}, nil)
+	require.NoError(t, err)
+	defer discovery.Stop()
+
+	instances, err := discovery.Discover(context.Background(), "user-service")
+	require.NoError(t, err)
+	require.Len(t, instances, 2)
+
+	assert.Equal(t, "10.0.0.1:9000", instances[0].ID)
+	assert.Equal(t, "user-service", instances[0].Service)
+	assert.Equal(t, "10.0.0.1:9000", instances[0].GetAddress())
+	assert.Equal(t, 100, instances[0].Weight)
+	assert.True(t, instances[0].Healthy)
+	assert.Equal(t, "a", instances[0].Metadata["zone"])
+	assert.Equal(t, []string{"v1"}, instances[0].Tags)
+
+	assert.Equal(t, "user-2", instances[1].ID)
+	assert.Equal(t, 30, instances[1].Weight)
+	assert.False(t, instances[1].Healthy)
+
+	instances, err = discovery.Discover(context.Background(), "unknown")
+	assert.NoError(t, err)
+	assert.Empty(t, instances)
+}
+
+func TestStaticDiscovery_InvalidInstance(t *testing.T) {
+	_, err := NewStaticDiscovery(map[string][]StaticInstance{"user-service": {{Address: "10.0.0.1"}}}, nil)
+	assert.ErrorIs(t, err, ErrInvalidPort)
+
+	_, err = NewStaticDiscovery(map[string][]StaticInstance{"user-service": {{Port: 9000}}}, nil)
+	assert.ErrorIs(t, err, ErrInvalidAddress)
+}
+
+func TestStaticDiscovery_Watch(t *testing.T) {
+	discovery, err := NewStaticDiscovery(map[string][]StaticInstance{
+		"user-service":  {{Address: "10.0.0.1:9000"}},
+		"order-service": {{Address: "10.0.0.9:9000"}},
+	}, nil)
+	require.NoError(t, err)
+
+	watchCh, err := discovery.Watch(context.Background(), "user-service")
+	require.NoError(t, err)
+	require.Len(t, <-watchCh, 1)
+
+	// Changing another service does not notify
+	require.NoError(t, discovery.Update(map[string][]StaticInstance{
+		"user-service":  {{Address: "10.0.0.1:9000"}},
+		"order-service": {{Address: "10.0.0.8:9000"}},
+	}))
+	select {
+	case <-watchCh:
+		t.Fatal("unexpected update")
+	default:
+	}
+
+	// An invalid update keeps the current lists
+	assert.Error(t, discovery.Update(map[string][]StaticInstance{"user-service": {{Address: "bad"}}}))
+
+	require.NoError(t, discovery.Update(map[string][]StaticInstance{
+		"user-service": {{Address: "10.0.0.1:9000"}, {Address: "10.0.0.2:9000"}},
+	}))
+	require.Len(t, <-watchCh, 2)
+
+	discovery.Stop()
+	_, ok := <-watchCh
+	assert.False(t, ok, "Stop closes the channel")
+}
+
+func TestStaticDiscovery_WatchCanceled(t *testing.T) {
+	discovery, err := NewStaticDiscovery(map[string][]StaticInstance{
+		"user-service": {{Address: "10.0.0.1:9000"}},
+	}, nil)
+	require.NoError(t, err)
+	defer discovery.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	watchCh, err := discovery.Watch(ctx, "user-service")
+	require.NoError(t, err)
+	require.Len(t, <-watchCh, 1)
+
+	// Canceling the watch closes the channel and forgets it
+	cancel()
+	_, ok := <-watchCh
+	assert.False(t, ok)
+	discovery.mu.Lock()
+	assert.Empty(t, discovery.watchers)
+	discovery.mu.Unlock()
+}
+
+func TestFileDiscovery_Watch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "services.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+services:
+  user-service:
+    - address: 10.0.0.1:9000
+      metadata:
+        zone: a
+`), 0o644))
+
+	discovery, err := NewFileDiscovery(path, 20*time.Millisecond, nil)
+	require.NoError(t, err)
+	defer discovery.Stop()
+
+	watchCh, err := discovery.Watch(context.Background(), "user-service")
+	require.NoError(t, err)
+	initial := <-watchCh
+	require.Len(t, initial, 1)
+	assert.Equal(t, "a", initial[0].Metadata["zone"])
+
+	// An invalid file keeps the last instances
+	require.NoError(t, os.WriteFile(path, []byte("services: ["), 0o644))
+	time.Sleep(100 * time.Millisecond)
+	instances, _ := discovery.Discover(context.Background(), "user-service")
+	assert.Len(t, instances, 1)
+
+	// JSON is accepted as well
+	require.NoError(t, os.WriteFile(path, []byte(`{"services": {"user-service": [
+		{"address": "10.0.0.1", "port": 9000},
+		{"address": "10.0.0.2", "port": 9000, "weight": 10}
+	]}}`), 0o644))
+	select {
+	case updated := <-watchCh:
+		require.Len(t, updated, 2)
+		assert.Equal(t, 10, updated[1].Weight)
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected an update after the file change")
+	}
+}
+
+func TestNewServiceDiscovery_Static(t *testing.T) {
+	discovery, err := NewServiceDiscovery(Config{
+		RegistryType: RegistryTypeStatic,
+		Static: StaticDiscoveryConfig{
+			Services: map[string][]StaticInstance{"user-service": {{Address: "10.0.0.1:9000"}}},
+		},
+	}, nil)
+	require.NoError(t, err)
+	assert.IsType(t, &StaticDiscovery{}, discovery)
+
+	_, err = NewServiceDiscovery(Config{RegistryType: RegistryTypeFile}, nil)
+	assert.Error(t, err, "file mode requires governance.static.file")
+
+	_, err = NewServiceRegistry(Config{RegistryType: RegistryTypeStatic}, nil)
+	assert.Error(t, err, "static mode only supports discovery")
+}
@@ -0,0 +1,253 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/governance"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/status"
+)
+
+// SelectorBalancerName gRPC load balancing policy choosing each call's instance with the client selector
+// It is the policy of the discovery connections without load_balance. Each call goes through the routing
+// rules of the client (outgoing metadata and governance.WithHashKey), then the selector; the call
+// results feed FeedbackSelector and the outlier detection.
+const SelectorBalancerName = "yogan_selector"
+
+func init() {
+	balancer.Register(selectorBalancerBuilder{})
+}
+
+// pickConfigKey resolver state attribute key of the pick configuration
+type pickConfigKey struct{}
+
+// pickConfig per-call selection of a client
+type pickConfig struct {
+	selector InstanceSelector
+	router   *governance.Router
+	hashKey  string
+	outliers governance.OutlierReporter
+}
+
+// resolvedInstancesKey resolver state attribute key of the discovered instances
+type resolvedInstancesKey struct{}
+
+// resolvedInstances every discovered instance of an update, healthy or not (zone spillover)
+type resolvedInstances struct {
+	all []*governance.ServiceInstance
+}
+
+// selectorBalancerBuilder builds the yogan_selector balancers
+type selectorBalancerBuilder struct{}
+
+// Name returns the policy name
+func (selectorBalancerBuilder) Name() string {
+	return SelectorBalancerName
+}
+
+// Build creates a balancer (the base balancer manages the sub-connections)
+func (selectorBalancerBuilder) Build(cc balancer.ClientConn, opts balancer.BuildOptions) balancer.Balancer {
+	pb := &selectorPickerBuilder{}
+	return &selectorBalancer{
+		Balancer: base.NewBalancerBuilder(SelectorBalancerName, pb, base.Config{HealthCheck: true}).Build(cc, opts),
+		pb:       pb,
+	}
+}
+
+// selectorBalancer hands the resolver state attributes to its picker builder
+type selectorBalancer struct {
+	balancer.Balancer
+	pb *selectorPickerBuilder
+}
+
+// UpdateClientConnState records the pick configuration, then updates the sub-connections
+func (b *selectorBalancer) UpdateClientConnState(s balancer.ClientConnState) error {
+	b.pb.update(s.ResolverState.Attributes.Value(pickConfigKey{}), s.ResolverState.Attributes.Value(resolvedInstancesKey{}))
+	return b.Balancer.UpdateClientConnState(s)
+}
+
+// selectorPickerBuilder builds pickers with the latest pick configuration
+type selectorPickerBuilder struct {
+	mu        sync.Mutex
+	config    *pickConfig
+	instances *resolvedInstances
+}
+
+// update records the pick configuration and the discovered instances
+func (pb *selectorPickerBuilder) update(config, instances any) {
+	pb.mu.Lock()
+	defer pb.mu.Unlock()
+	if c, ok := config.(*pickConfig); ok {
+		pb.config = c
+	}
+	if i, ok := instances.(*resolvedInstances); ok {
+		pb.instances = i
+	}
+}
+
+// Build creates the picker of the ready sub-connections
+func (pb *selectorPickerBuilder) Build(info base.PickerBuildInfo) balancer.Picker {
+	if len(info.ReadySCs) == 0 {
+		return base.NewErrPicker(balancer.ErrNoSubConnAvailable)
+	}
+
+	pb.mu.Lock()
+	config, resolved := pb.config, pb.instances
+	pb.mu.Unlock()
+	if config == nil {
+		config = &pickConfig{selector: &FirstHealthySelector{}}
+	}
+
+	p := &selectorPicker{config: config, subConns: make(map[string]balancer.SubConn, len(info.ReadySCs))}
+	unresolved := make(map[string]resolver.Address, len(info.ReadySCs))
+	for sc, scInfo := range info.ReadySCs {
+		p.subConns[scInfo.Address.Addr] = sc
+		unresolved[scInfo.Address.Addr] = scInfo.Address
+	}
+
+	// Ready instances in discovery order (selectors such as "first" depend on it), with the metadata of
+	// the last update: sub-connections keep the address attributes they were created with
+	if resolved != nil {
+		p.all = resolved.all
+		for _, instance := range resolved.all {
+			if _, ok := unresolved[instance.GetAddress()]; ok {
+				p.ready = append(p.ready, instance)
+				delete(unresolved, instance.GetAddress())
+			}
+		}
+	}
+	for _, addr := range unresolved {
+		if instance := InstanceFromAddress(addr); instance != nil {
+			p.ready = append(p.ready, instance)
+		}
+	}
+	return p
+}
+
+// selectorPicker picks the sub-connection of each call
+type selectorPicker struct {
+	config   *pickConfig
+	all      []*governance.ServiceInstance // Every discovered instance (routing)
+	ready    []*governance.ServiceInstance // Instances with a ready sub-connection
+	subConns map[string]balancer.SubConn   // key: instance address
+}
+
+// Pick chooses the instance of a call
+func (p *selectorPicker) Pick(info balancer.PickInfo) (balancer.PickResult, error) {
+	candidates := p.ready
+	if p.config.router != nil {
+		candidates = p.route(info.Ctx)
+		if len(candidates) == 0 {
+			return balancer.PickResult{}, status.Error(codes.Unavailable, "no service instance matches the routing rules")
+		}
+	}
+
+	key := governance.HashKeyFromContext(info.Ctx)
+	if key == "" {
+		key = p.config.hashKey
+	}
+	var selected *governance.ServiceInstance
+	if keyed, ok := p.config.selector.(KeyedSelector); ok && key != "" {
+		selected = keyed.SelectKey(candidates, key)
+	} else {
+		selected = p.config.selector.Select(candidates)
+	}
+	if selected == nil {
+		// ErrNoSubConnAvailable would block the call until the next picker, the selector may never choose one
+		return balancer.PickResult{}, status.Error(codes.Unavailable, "no service instance selected")
+	}
+
+	result := balancer.PickResult{SubConn: p.subConns[selected.GetAddress()]}
+	feedback, _ := p.config.selector.(FeedbackSelector)
+	if feedback == nil && p.config.outliers == nil {
+		return result, nil
+	}
+
+	if feedback != nil {
+		feedback.Begin(selected)
+	}
+	start := time.Now()
+	result.Done = func(done balancer.DoneInfo) {
+		if feedback != nil {
+			feedback.End(selected, callResult(time.Since(start), done.Err))
+		}
+		if p.config.outliers != nil && !errors.Is(done.Err, context.Canceled) && status.Code(done.Err) != codes.Canceled {
+			p.config.outliers.Report(selected, isOutlierFailure(done.Err))
+		}
+	}
+	return result, nil
+}
+
+// route applies the routing rules of the client to the ready instances
+func (p *selectorPicker) route(ctx context.Context) []*governance.ServiceInstance {
+	all := p.all
+	if all == nil {
+		all = p.ready
+	}
+	routed := p.config.router.Route(all, governance.RouteRequest{
+		Attributes: outgoingAttributes(ctx),
+		Key:        governance.HashKeyFromContext(ctx),
+	})
+
+	candidates := make([]*governance.ServiceInstance, 0, len(routed))
+	for _, instance := range routed {
+		if _, ok := p.subConns[instance.GetAddress()]; ok {
+			candidates = append(candidates, instance)
+		}
+	}
+	return candidates
+}
+
+// outgoingAttributes returns the outgoing metadata of a call as routing attributes
+func outgoingAttributes(ctx context.Context) map[string]string {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok {
+		return nil
+	}
+	attributes := make(map[string]string, len(md))
+	for key, values := range md {
+		if len(values) > 0 {
+			attributes[key] = values[0]
+		}
+	}
+	return attributes
+}
+
+// callResult converts a call outcome to selector feedback
+// Unavailable, DeadlineExceeded and ResourceExhausted are failures of the instance; calls canceled
+// by the caller (e.g., a losing hedge) carry no latency sample.
+func callResult(latency time.Duration, err error) governance.CallResult {
+	if err == nil {
+		return governance.CallResult{Latency: latency}
+	}
+	if errors.Is(err, context.Canceled) {
+		return governance.CallResult{}
+	}
+
+	switch status.Code(err) {
+	case codes.Canceled:
+		return governance.CallResult{}
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return governance.CallResult{Latency: latency, Err: err}
+	default:
+		return governance.CallResult{Latency: latency}
+	}
+}
+
+// isOutlierFailure reports whether a call failure counts toward the ejection of the instance
+// (Unavailable and server faults, the gRPC counterparts of 5xx responses)
+func isOutlierFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Internal, codes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
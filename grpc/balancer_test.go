@@ -0,0 +1,260 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/governance"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/attributes"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/balancer/base"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/status"
+)
+
+// recordingSelector FeedbackSelector recording the feedback
+type recordingSelector struct {
+	FirstHealthySelector
+	begins  int
+	results []governance.CallResult
+}
+
+func (s *recordingSelector) Begin(instance *governance.ServiceInstance) {
+	s.begins++
+}
+
+func (s *recordingSelector) End(instance *governance.ServiceInstance, result governance.CallResult) {
+	s.results = append(s.results, result)
+}
+
+// recordingReporter OutlierReporter recording the reports
+type recordingReporter struct {
+	failed []bool
+}
+
+func (r *recordingReporter) Report(instance *governance.ServiceInstance, failed bool) {
+	r.failed = append(r.failed, failed)
+}
+
+// fakeSubConn sub-connection of a picker test
+type fakeSubConn struct {
+	balancer.SubConn
+	id string
+}
+
+// buildTestPicker builds a picker over ready instances
+func buildTestPicker(config *pickConfig, instances ...*governance.ServiceInstance) balancer.Picker {
+	pb := &selectorPickerBuilder{}
+	pb.update(config, &resolvedInstances{all: instances})
+
+	info := base.PickerBuildInfo{ReadySCs: make(map[balancer.SubConn]base.SubConnInfo)}
+	for _, instance := range instances {
+		if !instance.Healthy {
+			continue
+		}
+		info.ReadySCs[&fakeSubConn{id: instance.ID}] = base.SubConnInfo{Address: resolver.Address{
+			Addr:               instance.GetAddress(),
+			BalancerAttributes: attributes.New(instanceAttributeKey{}, instanceAttribute{instance: instance}),
+		}}
+	}
+	return pb.Build(info)
+}
+
+// pickID picks a sub-connection and returns its instance ID
+func pickID(t *testing.T, picker balancer.Picker, ctx context.Context) string {
+	result, err := picker.Pick(balancer.PickInfo{FullMethodName: "/test.Service/Get", Ctx: ctx})
+	require.NoError(t, err)
+	if result.Done != nil {
+		result.Done(balancer.DoneInfo{})
+	}
+	return result.SubConn.(*fakeSubConn).id
+}
+
+func TestSelectorPicker_Feedback(t *testing.T) {
+	selector := &recordingSelector{}
+	reporter := &recordingReporter{}
+	picker := buildTestPicker(&pickConfig{selector: selector, outliers: reporter},
+		&governance.ServiceInstance{ID: "inst-1", Address: "10.0.0.1", Port: 9000, Healthy: true})
+
+	call := func(err error) {
+		result, pickErr := picker.Pick(balancer.PickInfo{Ctx: context.Background()})
+		require.NoError(t, pickErr)
+		time.Sleep(time.Millisecond)
+		result.Done(balancer.DoneInfo{Err: err})
+	}
+	call(nil)
+	call(status.Error(codes.Unavailable, "down"))
+	call(status.Error(codes.NotFound, "missing"))
+	call(status.Error(codes.Canceled, "hedge lost"))
+
+	assert.Equal(t, 4, selector.begins)
+	require.Len(t, selector.results, 4)
+	assert.NoError(t, selector.results[0].Err)
+	assert.Greater(t, selector.results[0].Latency, time.Duration(0))
+	assert.Error(t, selector.results[1].Err, "unavailable is an instance failure")
+	assert.NoError(t, selector.results[2].Err, "application errors count as successes")
+	assert.Equal(t, governance.CallResult{}, selector.results[3], "canceled calls carry no sample")
+
+	assert.Equal(t, []bool{false, true, false}, reporter.failed, "canceled calls are not reported")
+}
+
+func TestSelectorPicker_Routing(t *testing.T) {
+	router := governance.NewRouter(governance.RoutingConfig{
+		Subsets: []governance.SubsetRule{{Header: "x-version", MetadataKey: "version"}},
+	})
+	instances := []*governance.ServiceInstance{
+		{ID: "v1", Address: "10.0.0.1", Port: 9000, Healthy: true, Metadata: map[string]string{"version": "v1"}},
+		{ID: "v2", Address: "10.0.0.2", Port: 9000, Healthy: true, Metadata: map[string]string{"version": "v2"}},
+	}
+	picker := buildTestPicker(&pickConfig{selector: NewInstanceSelector("round_robin"), router: router}, instances...)
+
+	// Without metadata the calls are spread over both instances
+	seen := map[string]int{}
+	for i := 0; i < 4; i++ {
+		seen[pickID(t, picker, context.Background())]++
+	}
+	assert.Equal(t, map[string]int{"v1": 2, "v2": 2}, seen)
+
+	// The subset pins each call carrying the metadata
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-version", "v2")
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, "v2", pickID(t, picker, ctx))
+	}
+
+	_, err := picker.Pick(balancer.PickInfo{Ctx: metadata.AppendToOutgoingContext(context.Background(), "x-version", "v3")})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestSelectorPicker_CurrentMetadata(t *testing.T) {
+	router := governance.NewRouter(governance.RoutingConfig{
+		Subsets: []governance.SubsetRule{{Header: "x-version", MetadataKey: "version"}},
+	})
+	old := &governance.ServiceInstance{ID: "inst-1", Address: "10.0.0.1", Port: 9000, Healthy: true, Metadata: map[string]string{"version": "v1"}}
+	picker := buildTestPicker(&pickConfig{selector: NewFirstHealthySelector(), router: router}, old)
+
+	// The sub-connection keeps the address of v1, the resolver state carries v2
+	current := *old
+	current.Metadata = map[string]string{"version": "v2"}
+	pb := &selectorPickerBuilder{}
+	pb.update(&pickConfig{selector: NewFirstHealthySelector(), router: router}, &resolvedInstances{all: []*governance.ServiceInstance{&current}})
+	ready := picker.(*selectorPicker).subConns[old.GetAddress()]
+	picker = pb.Build(base.PickerBuildInfo{ReadySCs: map[balancer.SubConn]base.SubConnInfo{
+		ready: {Address: resolver.Address{
+			Addr:               old.GetAddress(),
+			BalancerAttributes: attributes.New(instanceAttributeKey{}, instanceAttribute{instance: old}),
+		}},
+	}})
+
+	// Selectors see the current metadata (e.g., weights), routing follows it
+	readyInstances := picker.(*selectorPicker).ready
+	require.Len(t, readyInstances, 1)
+	assert.Equal(t, "v2", readyInstances[0].Metadata["version"])
+	assert.Equal(t, "inst-1", pickID(t, picker, metadata.AppendToOutgoingContext(context.Background(), "x-version", "v2")))
+	_, err := picker.Pick(balancer.PickInfo{Ctx: metadata.AppendToOutgoingContext(context.Background(), "x-version", "v1")})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestSelectorPicker_SubsetFallback(t *testing.T) {
+	instances := []*governance.ServiceInstance{
+		{ID: "stable", Address: "10.0.0.1", Port: 9000, Healthy: true, Metadata: map[string]string{"track": "stable"}},
+		{ID: "canary", Address: "10.0.0.2", Port: 9000, Healthy: false, Metadata: map[string]string{"track": "canary"}},
+	}
+	canary := metadata.AppendToOutgoingContext(context.Background(), "x-canary", "1")
+
+	// The canary subset has no ready sub-connection
+	strict := governance.NewRouter(governance.RoutingConfig{
+		Subsets: []governance.SubsetRule{{Header: "x-canary", Metadata: map[string]string{"track": "canary"}}},
+	})
+	picker := buildTestPicker(&pickConfig{selector: NewFirstHealthySelector(), router: strict}, instances...)
+	_, err := picker.Pick(balancer.PickInfo{Ctx: canary})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, "stable", pickID(t, picker, context.Background()))
+
+	// With fallback the call uses the other instances
+	fallback := governance.NewRouter(governance.RoutingConfig{
+		Subsets: []governance.SubsetRule{{Header: "x-canary", Metadata: map[string]string{"track": "canary"}, Fallback: true}},
+	})
+	picker = buildTestPicker(&pickConfig{selector: NewFirstHealthySelector(), router: fallback}, instances...)
+	assert.Equal(t, "stable", pickID(t, picker, canary))
+}
+
+func TestSelectorPicker_OutlierReports(t *testing.T) {
+	reporter := &recordingReporter{}
+	picker := buildTestPicker(&pickConfig{selector: NewFirstHealthySelector(), outliers: reporter},
+		&governance.ServiceInstance{ID: "inst-1", Address: "10.0.0.1", Port: 9000, Healthy: true})
+
+	for _, err := range []error{
+		nil,
+		status.Error(codes.Internal, "panic"),
+		status.Error(codes.DataLoss, "corrupt"),
+		status.Error(codes.DeadlineExceeded, "slow"),
+		status.Error(codes.InvalidArgument, "bad request"),
+		context.Canceled,
+	} {
+		result, pickErr := picker.Pick(balancer.PickInfo{Ctx: context.Background()})
+		require.NoError(t, pickErr)
+		require.NotNil(t, result.Done, "outlier detection needs the call results")
+		result.Done(balancer.DoneInfo{Err: err})
+	}
+
+	// Server faults count toward the ejection, canceled calls are not reported
+	assert.Equal(t, []bool{false, true, true, false, false}, reporter.failed)
+}
+
+// noneSelector selector that never chooses an instance
+type noneSelector struct{}
+
+func (noneSelector) Select(instances []*governance.ServiceInstance) *governance.ServiceInstance {
+	return nil
+}
+
+func TestSelectorPicker_NoSelection(t *testing.T) {
+	picker := buildTestPicker(&pickConfig{selector: noneSelector{}},
+		&governance.ServiceInstance{ID: "inst-1", Address: "10.0.0.1", Port: 9000, Healthy: true})
+
+	// The call fails instead of waiting for a picker that would choose the same way
+	_, err := picker.Pick(balancer.PickInfo{Ctx: context.Background()})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	// Without ready sub-connections the call waits for the next picker
+	picker = buildTestPicker(&pickConfig{selector: NewFirstHealthySelector()},
+		&governance.ServiceInstance{ID: "inst-1", Address: "10.0.0.1", Port: 9000, Healthy: false})
+	_, err = picker.Pick(balancer.PickInfo{Ctx: context.Background()})
+	assert.ErrorIs(t, err, balancer.ErrNoSubConnAvailable)
+}
+
+func TestSelectorPicker_HashKey(t *testing.T) {
+	instances := []*governance.ServiceInstance{
+		{ID: "a", Address: "10.0.0.1", Port: 9000, Healthy: true},
+		{ID: "b", Address: "10.0.0.2", Port: 9000, Healthy: true},
+		{ID: "c", Address: "10.0.0.3", Port: 9000, Healthy: true},
+	}
+	picker := buildTestPicker(&pickConfig{selector: NewInstanceSelector("ring_hash"), hashKey: "caller-1"}, instances...)
+
+	// The configured key and the key of a call always pick the same instance
+	first := pickID(t, picker, context.Background())
+	keyed := pickID(t, picker, governance.WithHashKey(context.Background(), "user-42"))
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, first, pickID(t, picker, context.Background()))
+		assert.Equal(t, keyed, pickID(t, picker, governance.WithHashKey(context.Background(), "user-42")))
+	}
+
+	// The first selector keeps the discovery order
+	picker = buildTestPicker(&pickConfig{selector: NewFirstHealthySelector()}, instances...)
+	assert.Equal(t, "a", pickID(t, picker, context.Background()))
+}
+
+func TestClientManager_SelectorConfig(t *testing.T) {
+	cfg := ClientConfig{DiscoveryMode: "static", ServiceName: "user-service", Selector: "ring_hash", HashKey: "caller-1"}
+	clientMgr := NewClientManager(map[string]ClientConfig{"user": cfg}, nil)
+
+	assert.IsType(t, &LoadBalancerSelector{}, clientMgr.selectorFor("user"))
+	assert.IsType(t, &FirstHealthySelector{}, clientMgr.selectorFor("other"))
+
+	assert.Error(t, (&ClientConfig{Target: "127.0.0.1:9000", Selector: "unknown"}).Validate())
+}
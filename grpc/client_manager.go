@@ -3,6 +3,7 @@ package grpc
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -10,10 +11,12 @@ import (
 	"github.com/KOMKZ/go-yogan-framework/governance"
 	"github.com/KOMKZ/go-yogan-framework/limiter"
 	"github.com/KOMKZ/go-yogan-framework/logger"
+	"github.com/KOMKZ/go-yogan-framework/retry"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/attributes"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
@@ -24,42 +27,125 @@ type ClientManager struct {
 	timeouts       map[string]time.Duration // timeout configuration for each client
 	mu             sync.RWMutex
 	logger         *logger.CtxZapLogger
-	discovery      *governance.EtcdDiscovery // Service Discoverer (optional)
-	selector       InstanceSelector          // Instance selector (optional, default FirstHealthy)
-	breaker        *breaker.Manager          // circuit breaker (optional)
-	limiter        *limiter.Manager          // 🎯 Speed Limit Manager (optional)
-	tracerProvider trace.TracerProvider      // 🎯 OpenTelemetry TracerProvider (optional)
-	// Watch related
-	watchCtx    context.Context
-	watchCancel context.CancelFunc
-	watchWg     sync.WaitGroup
+	discovery      governance.ServiceDiscovery                 // Service Discoverer (optional, etcd or consul)
+	discoveries    map[string]governance.ServiceDiscovery      // Discoverers by discovery_mode (take precedence over discovery)
+	selector       InstanceSelector                            // Instance selector (optional, default FirstHealthy)
+	selectors      map[string]InstanceSelector                 // Selectors of the clients with a selector configured
+	routers        map[string]*governance.Router               // Routing rules of the clients with routing configured
+	healthMu       sync.Mutex                                  // Guards healths (created on first use)
+	healths        map[string]*governance.HealthAwareDiscovery // Health-aware discoverers of the clients with health checks / outlier detection
+	breaker        *breaker.Manager                            // circuit breaker (optional)
+	limiter        *limiter.Manager                            // 🎯 Speed Limit Manager (optional)
+	tracerProvider trace.TracerProvider                        // 🎯 OpenTelemetry TracerProvider (optional)
+	hedges         map[string]*retry.HedgePolicy               // Hedge policies of the clients with hedging enabled
+	retries        *retry.Registry                             // Named retry policies (optional)
 }
 
 // Create client manager
 func NewClientManager(configs map[string]ClientConfig, log *logger.CtxZapLogger) *ClientManager {
-	ctx, cancel := context.WithCancel(context.Background())
-
 	// Precompute the timeout for each client
 	timeouts := make(map[string]time.Duration)
+	hedges := make(map[string]*retry.HedgePolicy)
+	selectors := make(map[string]InstanceSelector)
+	routers := make(map[string]*governance.Router)
 	for name, cfg := range configs {
 		timeouts[name] = time.Duration(cfg.GetTimeout()) * time.Second
+		if cfg.Hedging.Enabled {
+			hedges[name] = newHedgePolicy(cfg.Hedging)
+		}
+		if cfg.Selector != "" {
+			selectors[name] = NewInstanceSelector(cfg.Selector)
+		}
+		if cfg.Routing.IsEnabled() {
+			routers[name] = governance.NewRouter(cfg.Routing)
+		}
 	}
 
 	return &ClientManager{
-		configs:     configs,
-		conns:       make(map[string]*grpc.ClientConn),
-		timeouts:    timeouts,
-		logger:      log,
-		watchCtx:    ctx,
-		watchCancel: cancel,
+		configs:   configs,
+		conns:     make(map[string]*grpc.ClientConn),
+		timeouts:  timeouts,
+		hedges:    hedges,
+		selectors: selectors,
+		routers:   routers,
+		healths:   make(map[string]*governance.HealthAwareDiscovery),
+		logger:    log,
 	}
 }
 
 // SetDiscovery set service discoverer (component layer injection)
-func (m *ClientManager) SetDiscovery(discovery *governance.EtcdDiscovery) {
+// Any governance.ServiceDiscovery works, e.g., governance.NewEtcdDiscovery or governance.NewConsulDiscovery.
+func (m *ClientManager) SetDiscovery(discovery governance.ServiceDiscovery) {
 	m.discovery = discovery
 }
 
+// SetModeDiscovery set the discoverer of a discovery_mode (e.g., "static", "file")
+// Clients whose mode has no discoverer fall back to SetDiscovery.
+func (m *ClientManager) SetModeDiscovery(mode string, discovery governance.ServiceDiscovery) {
+	if m.discoveries == nil {
+		m.discoveries = make(map[string]governance.ServiceDiscovery)
+	}
+	m.discoveries[mode] = discovery
+}
+
+// discoveryFor returns the discoverer of a client (nil in direct mode or when none is set)
+func (m *ClientManager) discoveryFor(cfg ClientConfig) governance.ServiceDiscovery {
+	mode := cfg.GetMode()
+	if mode == "direct" {
+		return nil
+	}
+	if discovery, ok := m.discoveries[mode]; ok {
+		return discovery
+	}
+	return m.discovery
+}
+
+// healthDiscoveryFor returns the discoverer of a client with its health checks and outlier ejection applied
+// Clients without health_check / outlier use discoveryFor unchanged.
+func (m *ClientManager) healthDiscoveryFor(clientName string, cfg ClientConfig) governance.ServiceDiscovery {
+	discovery := m.discoveryFor(cfg)
+	if discovery == nil || (!cfg.HealthCheck.Enabled && !cfg.Outlier.Enabled) {
+		return discovery
+	}
+
+	m.healthMu.Lock()
+	defer m.healthMu.Unlock()
+	if health, ok := m.healths[clientName]; ok {
+		return health
+	}
+
+	var checker *governance.ActiveHealthChecker
+	if cfg.HealthCheck.Enabled {
+		checker = governance.NewActiveHealthChecker(cfg.HealthCheck, governance.NewGRPCProber(""), m.logger)
+	}
+	var outliers *governance.OutlierDetector
+	if cfg.Outlier.Enabled {
+		outliers = governance.NewOutlierDetector(cfg.Outlier)
+	}
+	// The discoverer is shared by the clients: stopping the client health must not stop it
+	health := governance.NewHealthAwareDiscovery(sharedDiscovery{discovery}, checker, outliers, m.logger)
+	m.healths[clientName] = health
+	return health
+}
+
+// outlierReporter returns the outlier detection of a client (nil when disabled)
+func (m *ClientManager) outlierReporter(clientName string) governance.OutlierReporter {
+	m.healthMu.Lock()
+	defer m.healthMu.Unlock()
+	if health, ok := m.healths[clientName]; ok && m.configs[clientName].Outlier.Enabled {
+		return health
+	}
+	return nil
+}
+
+// sharedDiscovery discoverer whose Stop is left to its owner
+type sharedDiscovery struct {
+	governance.ServiceDiscovery
+}
+
+// Stop does nothing
+func (sharedDiscovery) Stop() {}
+
 // SetSelector Sets the instance selector (optional, defaults to FirstHealthy)
 func (m *ClientManager) SetSelector(selector InstanceSelector) {
 	m.selector = selector
@@ -109,6 +195,11 @@ func (m *ClientManager) SetMetricsHandler(handler interface{}) {
 	m.logger.DebugCtx(ctx, "✅ Metrics StatsHandler set in ClientManager (placeholder)")
 }
 
+// SetRetryRegistry sets the named retry policies used by clients with retry_policy
+func (m *ClientManager) SetRetryRegistry(registry *retry.Registry) {
+	m.retries = registry
+}
+
 // GetLimiter obtain speed limit manager
 func (m *ClientManager) GetLimiter() *limiter.Manager {
 	return m.limiter
@@ -122,6 +213,14 @@ func (m *ClientManager) getSelector() InstanceSelector {
 	return m.selector
 }
 
+// selectorFor returns the selector of a client (its own selector, or the manager selector)
+func (m *ClientManager) selectorFor(serviceName string) InstanceSelector {
+	if selector, ok := m.selectors[serviceName]; ok {
+		return selector
+	}
+	return m.getSelector()
+}
+
 // PreConnect asynchronously pre-connects all configured clients (supports service discovery and direct connection)
 func (m *ClientManager) PreConnect(timeout time.Duration) {
 	ctx := context.Background()
@@ -159,29 +258,34 @@ func (m *ClientManager) PreConnect(timeout time.Duration) {
 // Public method: Eliminate duplicate code (DRY principle)
 // ========================================
 
-// discover and select healthy instance
-// Return: instance address, error message
-func (m *ClientManager) discoverHealthyInstance(ctx context.Context, serviceName string) (string, error) {
-	if m.discovery == nil {
-		return "", fmt.Errorf("Service discovery not initialized")
+// resolverFor returns the resolver of a discovery client (nil in direct mode or without discoverer)
+// The resolver sends every healthy instance to the connection; the pick configuration carries the
+// selector, routing rules and outlier detection of the client to the yogan_selector balancer.
+func (m *ClientManager) resolverFor(clientName string, cfg ClientConfig) *ResolverBuilder {
+	if cfg.ServiceName == "" {
+		return nil
 	}
-
-	instances, err := m.discovery.Discover(ctx, serviceName)
-	if err != nil {
-		return "", fmt.Errorf("Service discovery query failed: %w", err)
+	discovery := m.healthDiscoveryFor(clientName, cfg)
+	if discovery == nil {
+		return nil
 	}
 
-	if len(instances) == 0 {
-		return "", fmt.Errorf("Service instance not found: %s", serviceName)
-	}
+	builder := NewResolverBuilder(discovery, m.logger)
+	builder.attributes = attributes.New(pickConfigKey{}, &pickConfig{
+		selector: m.selectorFor(clientName),
+		router:   m.routers[clientName],
+		hashKey:  cfg.HashKey,
+		outliers: m.outlierReporter(clientName),
+	})
+	return builder
+}
 
-	// Use the injected selector to choose an instance
-	selected := m.getSelector().Select(instances)
-	if selected == nil {
-		return "", fmt.Errorf("No healthy service instances: %s", serviceName)
+// dialTarget returns the dial target of a client: yogan:///{service_name} with a discoverer, the target otherwise
+func (m *ClientManager) dialTarget(cfg ClientConfig) string {
+	if cfg.ServiceName != "" && m.discoveryFor(cfg) != nil {
+		return ResolverScheme + ":///" + cfg.ServiceName
 	}
-
-	return selected.GetAddress(), nil
+	return cfg.Target
 }
 
 // dialWithOptions establishes a gRPC connection (reuses dialing logic)
@@ -213,55 +317,49 @@ func (m *ClientManager) dialWithOptions(ctx context.Context, serviceName, target
 		UnaryClientRateLimitInterceptor(m, serviceName),       // Speed limit check
 		UnaryClientBreakerInterceptor(m, serviceName),         // 3️⃣ Circuit breaker
 		UnaryClientTimeoutInterceptor(timeout, clientLogger),  // 4️⃣ Timeout control
+		UnaryClientRetryInterceptor(m, serviceName),           // Named retry policy (within the timeout)
+		UnaryClientHedgeInterceptor(m, serviceName),           // Hedged requests (within the timeout)
 		UnaryClientLoggerInterceptor(clientLogger, enableLog), // 5️⃣ Logging (configurable)
+		UnaryClientPushbackInterceptor(),                      // Expose the server retry pushback in errors
 	}
 	opts = append(opts, grpc.WithChainUnaryInterceptor(interceptors...))
 
-	// 3. Service discovery pattern adds load balancing configuration
-	if cfg.LoadBalance != "" {
+	// 3. Service discovery pattern: resolve all the instances, balance each call
+	// (load_balance: a gRPC policy such as round_robin, default: the client selector)
+	policy := cfg.LoadBalance
+	if strings.HasPrefix(targetAddr, ResolverScheme+":") {
+		if builder := m.resolverFor(serviceName, cfg); builder != nil {
+			opts = append(opts, grpc.WithResolvers(builder))
+			if policy == "" {
+				policy = SelectorBalancerName
+			}
+		}
+	}
+	if policy != "" {
 		opts = append(opts, grpc.WithDefaultServiceConfig(
-			fmt.Sprintf(`{"loadBalancingPolicy":"%s"}`, cfg.LoadBalance)))
+			fmt.Sprintf(`{"loadBalancingPolicy":"%s"}`, policy)))
 	}
 
 	return grpc.DialContext(ctx, targetAddr, opts...)
 }
 
 // preConnectWithDiscovery service discovery mode pre-connection
-// ✅ After refactoring: Watch starts independently and pre-connects as best effort
+// The resolver of the connection discovers and watches the instances; the pre-connection is best effort.
 func (m *ClientManager) preConnectWithDiscovery(serviceName string, cfg ClientConfig, timeout time.Duration) {
-	// Step 1: Unconditionally start Watch listening (independent lifecycle)
-	m.startWatchForever(serviceName, cfg)
-
-	// ✅ Step 2: Attempt a pre-connection (best effort, failure does not impact Watch)
-	m.tryPreConnect(serviceName, cfg, timeout)
-}
-
-// tryPreConnect attempts to establish a pre-connection (single responsibility: connection establishment)
-func (m *ClientManager) tryPreConnect(serviceName string, cfg ClientConfig, timeout time.Duration) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	// 1. Discover healthy instances
-	targetAddr, err := m.discoverHealthyInstance(ctx, cfg.ServiceName)
-	if err != nil {
-		m.logger.WarnCtx(ctx, "⚠️  Pre-connection failed (service discovery), will auto-retry at runtime",
-			zap.String("service", serviceName),
-			zap.String("target_service", cfg.ServiceName),
-			zap.Error(err))
-		return
-	}
-
-	// Establish connection
+	targetAddr := m.dialTarget(cfg)
 	conn, err := m.dialWithOptions(ctx, serviceName, targetAddr, cfg)
 	if err != nil {
-		m.logger.WarnCtx(ctx, "⚠️  Pre-connection failed (connection establishment), will auto-retry at runtime",
+		m.logger.WarnCtx(ctx, "⚠️  Pre-connection failed, will auto-retry at runtime",
 			zap.String("service", serviceName),
 			zap.String("target", targetAddr),
 			zap.Error(err))
 		return
 	}
 
-	// 3. Cache connection
+	// Cache connection
 	m.mu.Lock()
 	m.conns[serviceName] = conn
 	m.mu.Unlock()
@@ -273,79 +371,6 @@ func (m *ClientManager) tryPreConnect(serviceName string, cfg ClientConfig, time
 		zap.String("load_balance", cfg.LoadBalance))
 }
 
-// startWatchForever starts watch listening (never gives up, auto retries)
-func (m *ClientManager) startWatchForever(serviceName string, cfg ClientConfig) {
-	if m.discovery == nil || cfg.ServiceName == "" {
-		return
-	}
-
-	m.watchWg.Add(1)
-	go func() {
-		defer m.watchWg.Done()
-
-		backoff := time.Second
-		maxBackoff := 30 * time.Second
-
-		for {
-			select {
-			case <-m.watchCtx.Done():
-				return
-			default:
-				// Try to start the Watch loop
-				err := m.runWatchLoop(serviceName, cfg)
-				if err != nil {
-					m.logger.WarnCtx(context.Background(),
-						"⚠️  Watch interrupted, will retry later",
-						zap.String("service", serviceName),
-						zap.String("target_service", cfg.ServiceName),
-						zap.Error(err),
-						zap.Duration("retry_after", backoff))
-
-					// Exponential backoff retry
-					select {
-					case <-m.watchCtx.Done():
-						return
-					case <-time.After(backoff):
-						backoff = min(backoff*2, maxBackoff)
-					}
-				} else {
-					// Normal exit, reset backoff
-					backoff = time.Second
-				}
-			}
-		}
-	}()
-}
-
-// runWatchLoop runs one Watch loop (single responsibility)
-func (m *ClientManager) runWatchLoop(serviceName string, cfg ClientConfig) error {
-	ctx := context.Background()
-
-	watchCh, err := m.discovery.Watch(ctx, cfg.ServiceName)
-	if err != nil {
-		return fmt.Errorf("Failed to start Watch: %w", err)
-	}
-
-	m.logger.DebugCtx(ctx, "🔍 Service instance watch started",
-		zap.String("service", serviceName),
-		zap.String("target_service", cfg.ServiceName))
-
-	for {
-		select {
-		case <-m.watchCtx.Done():
-			return nil // normal exit
-
-		case instances, ok := <-watchCh:
-			if !ok {
-				return fmt.Errorf("WatchWatch channel closed")
-			}
-
-			// Handle instance update
-			m.handleInstancesUpdate(serviceName, cfg, instances)
-		}
-	}
-}
-
 // preConnectDirect Direct connection mode pre-connection
 func (m *ClientManager) preConnectDirect(serviceName string, cfg ClientConfig, timeout time.Duration) {
 	ctx := context.Background()
@@ -374,6 +399,8 @@ func (m *ClientManager) preConnectDirect(serviceName string, cfg ClientConfig, t
 }
 
 // GetConn obtain client connection (runtime call)
+// Routing subsets are applied to each call by the balancer of the connection (outgoing metadata),
+// so every call of a client shares this connection.
 func (m *ClientManager) GetConn(serviceName string) (*grpc.ClientConn, error) {
 	// Check if configuration exists
 	cfg, ok := m.configs[serviceName]
@@ -409,19 +436,8 @@ func (m *ClientManager) connectOnDemand(serviceName string, cfg ClientConfig) (*
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	var targetAddr string
-	var err error
-
-	// 🎯 Service discovery pattern: Reuse discoverHealthyInstance
-	if cfg.DiscoveryMode != "" && cfg.ServiceName != "" && m.discovery != nil {
-		targetAddr, err = m.discoverHealthyInstance(ctx, cfg.ServiceName)
-		if err != nil {
-			return nil, fmt.Errorf("Service discovery failed: %w", err)
-		}
-	} else {
-		// Direct connection mode
-		targetAddr = cfg.Target
-	}
+	// 🎯 Service discovery pattern: yogan:///{service_name}, direct connection mode: target
+	targetAddr := m.dialTarget(cfg)
 
 	// ✅ Reuse dialWithOptions to establish connection
 	conn, err := m.dialWithOptions(ctx, serviceName, targetAddr, cfg)
@@ -440,49 +456,16 @@ func (m *ClientManager) connectOnDemand(serviceName string, cfg ClientConfig) (*
 	return conn, nil
 }
 
-// handleInstancesUpdate Handle instance list update
-// ✅ Simplified strategy: do not proactively reconnect; rely on connectOnDemand retries when getting a connection
-// Reason: To avoid frequent reconnection triggered by Watch, causing connection jitter
-func (m *ClientManager) handleInstancesUpdate(serviceName string, cfg ClientConfig, instances []*governance.ServiceInstance) {
-	ctx := context.Background()
-
-	m.logger.DebugCtx(ctx, "🔄 Service instance list updated",
-		zap.String("service", serviceName),
-		zap.Int("instances", len(instances)))
-
-	// 🎯 Strategy: Record the number of healthy instances, do not proactively reconnect
-	// If the current connection fails, the next GetConn will automatically trigger a connectOnDemand to reconnect to a new instance.
-
-	healthyCount := 0
-	for _, inst := range instances {
-		if inst.Healthy {
-			healthyCount++
-		}
-	}
-
-	if healthyCount == 0 {
-		m.logger.WarnCtx(ctx, "⚠️  No healthy instances currently, waiting for service recovery",
-			zap.String("service", serviceName))
-	} else {
-		m.logger.InfoCtx(ctx, "✅ Healthy instances available",
-			zap.String("service", serviceName),
-			zap.Int("healthy_count", healthyCount))
-	}
-
-	// Optional optimization: detect if currently connected instances are offline and disconnect in advance
-	// This way, the next GetConn will trigger a connectOnDemand reconnection to the new instance
-	// TODO: Implement connection health check (if necessary)
-}
-
-// reconnect to new instance
-
 // Close all client connections
 func (m *ClientManager) Close() {
 	ctx := context.Background()
 
-	// Stop all Watch listeners
-	m.watchCancel()
-	m.watchWg.Wait()
+	// Stop the health checks
+	m.healthMu.Lock()
+	for _, health := range m.healths {
+		health.Stop()
+	}
+	m.healthMu.Unlock()
 
 	m.mu.Lock()
 	defer m.mu.Unlock()
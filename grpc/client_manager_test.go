@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/KOMKZ/go-yogan-framework/governance"
 	"github.com/KOMKZ/go-yogan-framework/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -263,3 +264,36 @@ func startTestGRPCServer(t *testing.T) *Server {
 	return server
 }
 
+// TestClientManager_GetConn_StaticDiscovery connection through the discoverer of the static mode
+func TestClientManager_GetConn_StaticDiscovery(t *testing.T) {
+	log := logger.GetLogger("grpc_test")
+
+	server := startTestGRPCServer(t)
+	defer server.Stop(context.Background())
+
+	discovery, err := governance.NewStaticDiscovery(map[string][]governance.StaticInstance{
+		"test-service": {{Address: fmt.Sprintf("127.0.0.1:%d", server.Port)}},
+	}, log)
+	require.NoError(t, err)
+	defer discovery.Stop()
+
+	configs := map[string]ClientConfig{
+		"static-service": {DiscoveryMode: "static", ServiceName: "test-service", Timeout: 5},
+		"etcd-service":   {DiscoveryMode: "etcd", ServiceName: "test-service", Timeout: 5},
+	}
+	manager := NewClientManager(configs, log)
+	manager.SetModeDiscovery("static", discovery)
+	defer manager.Close()
+
+	conn, err := manager.GetConn("static-service")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.Status)
+
+	// Other modes do not use the static discoverer
+	assert.Nil(t, manager.discoveryFor(configs["etcd-service"]))
+}
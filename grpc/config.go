@@ -1,6 +1,11 @@
 package grpc
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/governance"
+)
 
 // Configure gRPC component configuration (Phase One: Basic Functionality)
 type Config struct {
@@ -17,6 +22,15 @@ type ServerConfig struct {
 	EnableReflect bool            `mapstructure:"enable_reflect"` // Enable reflection (for convenient debugging)
 	EnableLog     *bool           `mapstructure:"enable_log"`     // Enable interceptor logging (nil=default true, false=disable)
 	Registry      RegistryConfig  `mapstructure:"registry"`       // Service registration configuration
+	RateLimit     RateLimitConfig `mapstructure:"rate_limit"`     // Inbound rate limiting (backed by the limiter component)
+}
+
+// RateLimitConfig inbound rate limiting configuration of the server
+// Resources are full method names (e.g., "/auth.AuthService/Login"), limits are configured in the limiter component
+type RateLimitConfig struct {
+	Enabled     bool     `mapstructure:"enabled"`      // Whether inbound rate limiting is enabled
+	CallerKey   string   `mapstructure:"caller_key"`   // Per-caller key: "" (method only), "peer" (client IP), "jwt_subject" (verified token subject), "metadata:<key>" (only for metadata set by a trusted gateway)
+	SkipMethods []string `mapstructure:"skip_methods"` // Full method names that are not rate limited
 }
 
 // Returns whether logging is enabled (default true)
@@ -43,12 +57,58 @@ type ClientConfig struct {
 	Timeout int    `mapstructure:"timeout"` // Timeout duration in seconds (default 5 seconds)
 	
 	// Mode 2: Service Discovery Mode
-	DiscoveryMode string `mapstructure:"discovery_mode"` // Discover pattern: "direct" | "etcd"
-	ServiceName   string `mapstructure:"service_name"`   // Service name (for etcd service discovery)
-	LoadBalance   string `mapstructure:"load_balance"`   // Load balancing: "round_robin" | "random"
+	DiscoveryMode string `mapstructure:"discovery_mode"` // Discover pattern: "direct" | "etcd" | "consul" | "static" | "file"
+	ServiceName   string `mapstructure:"service_name"`   // Service name (for service discovery)
+	LoadBalance   string `mapstructure:"load_balance"`   // gRPC balancing policy of discovery connections (e.g., "round_robin"); default: yogan_selector (per-call Selector)
+	Selector      string `mapstructure:"selector"`       // Instance selection: "first" | "round_robin" | "random" | "weighted" | "p2c_ewma" | "least_request" | "ring_hash" | "maglev" (default: SetSelector)
+	HashKey       string `mapstructure:"hash_key"`       // Default ring_hash / maglev key of calls without governance.WithHashKey (a fixed value sends all of them to one instance)
 	
 	// log configuration
 	EnableLog *bool `mapstructure:"enable_log"` // Enable interceptor logs (nil=default true, false=disable)
+	
+	// Named retry policy (retry.policies), resolved at each call
+	RetryPolicy string `mapstructure:"retry_policy"`
+	
+	// Hedged requests (only for idempotent methods)
+	Hedging HedgingConfig `mapstructure:"hedging"`
+	
+	// Metadata-aware routing (discovery modes): zone affinity, version canary, subsets
+	Routing governance.RoutingConfig `mapstructure:"routing"`
+	
+	// Active health checks of the discovered instances (gRPC health service)
+	HealthCheck governance.HealthCheckConfig `mapstructure:"health_check"`
+	
+	// Passive outlier ejection of the discovered instances
+	Outlier governance.OutlierConfig `mapstructure:"outlier"`
+}
+
+// HedgingConfig hedged requests of a client
+// When a call has not answered after the delay, the same call is sent again and the first success wins.
+// Unavailable failures start the next attempt at once, other failures are returned.
+type HedgingConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`      // Whether hedging is enabled
+	MaxAttempts int           `mapstructure:"max_attempts"` // Maximum parallel attempts, including the first one (default 2)
+	Delay       time.Duration `mapstructure:"delay"`        // Hedge delay (default 100ms, used until the percentile is known)
+	Percentile  float64       `mapstructure:"percentile"`   // Latency percentile used as the delay (e.g., 0.95, 0 = fixed delay)
+	BudgetRatio float64       `mapstructure:"budget_ratio"` // Hedges allowed per request (default 0.1)
+	Methods     []string      `mapstructure:"methods"`      // Full method names to hedge (empty = all methods)
+}
+
+// Validate hedging configuration
+func (c *HedgingConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.MaxAttempts < 0 || c.MaxAttempts == 1 {
+		return fmt.Errorf("hedging.max_attempts must be at least 2")
+	}
+	if c.Percentile < 0 || c.Percentile >= 1 {
+		return fmt.Errorf("hedging.percentile must be between 0 and 1")
+	}
+	if c.BudgetRatio < 0 || c.BudgetRatio > 1 {
+		return fmt.Errorf("hedging.budget_ratio must be between 0 and 1")
+	}
+	return nil
 }
 
 // GetTimeout returns the timeout duration in seconds (default 5 seconds)
@@ -95,7 +155,23 @@ func (c *ClientConfig) Validate() error {
 		if c.ServiceName == "" {
 			return fmt.Errorf("etcd etcd mode service_name cannot be empty service_name etcd mode service_name cannot be empty")
 		}
+	} else if mode == "consul" || mode == "static" || mode == "file" {
+		if c.ServiceName == "" {
+			return fmt.Errorf("%s mode service_name cannot be empty", mode)
+		}
+	} else {
+		return fmt.Errorf("unsupported discovery_mode: %s", mode)
+	}
+
+	if c.Selector != "" && !isKnownSelector(c.Selector) {
+		return fmt.Errorf("unsupported selector: %s", c.Selector)
+	}
+	if err := c.Routing.Validate(); err != nil {
+		return err
+	}
+	if err := c.Outlier.Validate(); err != nil {
+		return err
 	}
 	
-	return nil
+	return c.Hedging.Validate()
 }
@@ -146,6 +146,44 @@ func TestClientConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "consul 模式配置正确",
+			config: ClientConfig{
+				DiscoveryMode: "consul",
+				ServiceName:   "test-service",
+			},
+			wantErr: false,
+		},
+		{
+			name: "consul 模式缺少 service_name",
+			config: ClientConfig{
+				DiscoveryMode: "consul",
+			},
+			wantErr: true,
+		},
+		{
+			name: "static 模式配置正确",
+			config: ClientConfig{
+				DiscoveryMode: "static",
+				ServiceName:   "test-service",
+			},
+			wantErr: false,
+		},
+		{
+			name: "file 模式缺少 service_name",
+			config: ClientConfig{
+				DiscoveryMode: "file",
+			},
+			wantErr: true,
+		},
+		{
+			name: "不支持的发现模式",
+			config: ClientConfig{
+				DiscoveryMode: "nacos",
+				ServiceName:   "test-service",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
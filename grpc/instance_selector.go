@@ -12,6 +12,20 @@ type InstanceSelector interface {
 	Select(instances []*governance.ServiceInstance) *governance.ServiceInstance
 }
 
+// FeedbackSelector selector learning from call results (optional extension of InstanceSelector)
+// The client calls Begin before each call to the selected instance and End with its result.
+type FeedbackSelector interface {
+	InstanceSelector
+	Begin(instance *governance.ServiceInstance)
+	End(instance *governance.ServiceInstance, result governance.CallResult)
+}
+
+// KeyedSelector selector choosing by request key (optional extension of InstanceSelector)
+type KeyedSelector interface {
+	InstanceSelector
+	SelectKey(instances []*governance.ServiceInstance, key string) *governance.ServiceInstance
+}
+
 // FirstHealthySelector selects the first healthy instance (default strategy)
 // Applicable scenario: simple scenarios, quick response, stateless
 type FirstHealthySelector struct{}
@@ -38,7 +52,7 @@ type LoadBalancerSelector struct {
 }
 
 // Create load balancer selector
-// strategy: "round_robin" | "random" | "weighted"
+// strategy: "round_robin" | "random" | "weighted" | "p2c_ewma" | "least_request" | "ring_hash" | "maglev"
 func NewLoadBalancerSelector(strategy string) *LoadBalancerSelector {
 	return &LoadBalancerSelector{
 		balancer: governance.NewLoadBalancer(strategy),
@@ -48,19 +62,60 @@ func NewLoadBalancerSelector(strategy string) *LoadBalancerSelector {
 // Select instances using load balancing algorithm
 // Automatically filter unhealthy instances
 func (s *LoadBalancerSelector) Select(instances []*governance.ServiceInstance) *governance.ServiceInstance {
-	// Filter healthy instances
+	healthy := filterHealthy(instances)
+	if len(healthy) == 0 {
+		return nil
+	}
+
+	return s.balancer.Select(healthy)
+}
+
+// SelectKey selects by key with consistent hash balancers (other balancers ignore the key)
+func (s *LoadBalancerSelector) SelectKey(instances []*governance.ServiceInstance, key string) *governance.ServiceInstance {
+	keyed, ok := s.balancer.(governance.KeyedBalancer)
+	if !ok {
+		return s.Select(instances)
+	}
+
+	healthy := filterHealthy(instances)
+	if len(healthy) == 0 {
+		return nil
+	}
+	return keyed.SelectKey(healthy, key)
+}
+
+// Begin forwards the start of a call to feedback balancers
+func (s *LoadBalancerSelector) Begin(instance *governance.ServiceInstance) {
+	if feedback, ok := s.balancer.(governance.FeedbackBalancer); ok {
+		feedback.Begin(instance)
+	}
+}
+
+// End forwards the result of a call to feedback balancers
+func (s *LoadBalancerSelector) End(instance *governance.ServiceInstance, result governance.CallResult) {
+	if feedback, ok := s.balancer.(governance.FeedbackBalancer); ok {
+		feedback.End(instance, result)
+	}
+}
+
+// filterHealthy returns the healthy instances
+func filterHealthy(instances []*governance.ServiceInstance) []*governance.ServiceInstance {
 	healthy := make([]*governance.ServiceInstance, 0, len(instances))
 	for _, inst := range instances {
 		if inst.Healthy {
 			healthy = append(healthy, inst)
 		}
 	}
+	return healthy
+}
 
-	if len(healthy) == 0 {
-		return nil
+// isKnownSelector reports whether NewInstanceSelector supports the strategy
+func isKnownSelector(strategy string) bool {
+	switch strategy {
+	case "first", "round_robin", "random", "weighted", "p2c_ewma", "least_request", "ring_hash", "maglev":
+		return true
 	}
-
-	return s.balancer.Select(healthy)
+	return false
 }
 
 // NewInstanceSelector creates selector based on strategy name (factory method)
@@ -68,7 +123,7 @@ func NewInstanceSelector(strategy string) InstanceSelector {
 	switch strategy {
 	case "first", "":
 		return NewFirstHealthySelector()
-	case "round_robin", "random", "weighted":
+	case "round_robin", "random", "weighted", "p2c_ewma", "least_request", "ring_hash", "maglev":
 		return NewLoadBalancerSelector(strategy)
 	default:
 		// Unknown strategy, fallback to the first healthy instance
@@ -297,3 +297,32 @@ func BenchmarkSelectors(b *testing.B) {
 	})
 }
 
+
+// TestLoadBalancerSelector_Feedback feedback and key selection of advanced strategies
+func TestLoadBalancerSelector_Feedback(t *testing.T) {
+	instances := createTestInstances()
+
+	selector := NewLoadBalancerSelector("least_request")
+	var _ FeedbackSelector = selector
+	var _ KeyedSelector = selector
+
+	// Busy instances are avoided, unhealthy ones are never selected
+	selector.Begin(instances[0])
+	selector.Begin(instances[1])
+	selected := selector.Select(instances)
+	require.NotNil(t, selected)
+	assert.Equal(t, "192.168.1.4:9000", selected.Address)
+
+	selector.End(instances[0], governance.CallResult{})
+	selector.End(instances[1], governance.CallResult{})
+
+	// Key selection is stable with hash strategies
+	hashSelector := NewInstanceSelector("maglev").(KeyedSelector)
+	first := hashSelector.SelectKey(instances, "user-1")
+	require.NotNil(t, first)
+	assert.True(t, first.Healthy)
+	assert.Equal(t, first, hashSelector.SelectKey(instances, "user-1"))
+
+	// Other strategies ignore the key
+	assert.NotNil(t, NewLoadBalancerSelector("round_robin").SelectKey(instances, "user-1"))
+}
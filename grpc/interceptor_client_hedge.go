@@ -0,0 +1,83 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/retry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+)
+
+// newHedgePolicy builds the hedge policy of a client
+// Each client has its own budget, hedges never exceed BudgetRatio of its calls.
+func newHedgePolicy(cfg HedgingConfig) *retry.HedgePolicy {
+	ratio := cfg.BudgetRatio
+	if ratio == 0 {
+		ratio = 0.1
+	}
+	opts := []retry.HedgeOption{
+		retry.HedgeMaxAttempts(cfg.MaxAttempts),
+		retry.HedgeDelay(cfg.Delay),
+		retry.HedgeCondition(retry.RetryOnGRPCCodes(codes.Unavailable)),
+		retry.HedgeBudget(retry.NewBudgetManager(ratio, time.Minute)),
+	}
+	if cfg.Percentile > 0 {
+		opts = append(opts, retry.HedgePercentile(cfg.Percentile, 0))
+	}
+	return retry.NewHedgePolicy(opts...)
+}
+
+// hedgePolicy returns the hedge policy of a method, nil when it is not hedged
+func (m *ClientManager) hedgePolicy(serviceName, method string) *retry.HedgePolicy {
+	policy := m.hedges[serviceName]
+	if policy == nil {
+		return nil
+	}
+	methods := m.configs[serviceName].Hedging.Methods
+	if len(methods) == 0 {
+		return policy
+	}
+	for _, name := range methods {
+		if name == method {
+			return policy
+		}
+	}
+	return nil
+}
+
+// UnaryClientHedgeInterceptor client hedged requests interceptor
+//
+// When the call has not answered after the hedge delay, the same call is sent again on the connection;
+// the first success is copied into the reply and the other attempts are cancelled.
+// Each attempt decodes into its own reply message, so only protobuf replies are hedged.
+//
+// Parameters:
+// - clientMgr: Client manager (holds the hedge policies of grpc.clients.*.hedging)
+// - serviceName: service name (name configured in grpc.clients)
+func UnaryClientHedgeInterceptor(clientMgr *ClientManager, serviceName string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		policy := clientMgr.hedgePolicy(serviceName, method)
+		target, ok := reply.(proto.Message)
+		if policy == nil || !ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		winner, err := retry.HedgeWithData(ctx, policy, func(ctx context.Context) (proto.Message, error) {
+			attemptReply := target.ProtoReflect().New().Interface()
+			if err := invoker(ctx, method, req, attemptReply, cc, opts...); err != nil {
+				return nil, err
+			}
+			return attemptReply, nil
+		})
+		if err != nil {
+			// Return the status of the downstream, not the aggregated error
+			return retry.LastAttemptError(err)
+		}
+
+		proto.Reset(target)
+		proto.Merge(target, winner)
+		return nil
+	}
+}
@@ -0,0 +1,95 @@
+package grpc
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// newHedgeClientManager creates a manager hedging "test-service" after 10ms
+func newHedgeClientManager(methods ...string) *ClientManager {
+	return NewClientManager(map[string]ClientConfig{
+		"test-service": {
+			Target: "127.0.0.1:9000",
+			Hedging: HedgingConfig{
+				Enabled:     true,
+				Delay:       10 * time.Millisecond,
+				BudgetRatio: 1,
+				Methods:     methods,
+			},
+		},
+	}, logger.GetLogger("test"))
+}
+
+func TestUnaryClientHedgeInterceptor(t *testing.T) {
+	clientMgr := newHedgeClientManager()
+	interceptor := UnaryClientHedgeInterceptor(clientMgr, "test-service")
+
+	// Warm the budget up: one call gives one hedge
+	noop := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return nil
+	}
+	require.NoError(t, interceptor(context.Background(), "/test.Service/Get", nil, &wrapperspb.StringValue{}, nil, noop))
+
+	var calls int32
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			<-ctx.Done()
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		reply.(*wrapperspb.StringValue).Value = "hedged"
+		return nil
+	}
+
+	reply := &wrapperspb.StringValue{}
+	err := interceptor(context.Background(), "/test.Service/Get", nil, reply, nil, invoker)
+	require.NoError(t, err)
+	assert.Equal(t, "hedged", reply.GetValue())
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestUnaryClientHedgeInterceptor_ReturnsStatus(t *testing.T) {
+	interceptor := UnaryClientHedgeInterceptor(newHedgeClientManager(), "test-service")
+
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return status.Error(codes.InvalidArgument, "bad request")
+	}
+
+	err := interceptor(context.Background(), "/test.Service/Get", nil, &wrapperspb.StringValue{}, nil, invoker)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "bad request", status.Convert(err).Message())
+}
+
+func TestUnaryClientHedgeInterceptor_MethodFilter(t *testing.T) {
+	interceptor := UnaryClientHedgeInterceptor(newHedgeClientManager("/test.Service/Get"), "test-service")
+
+	var calls int32
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(30 * time.Millisecond)
+		return nil
+	}
+
+	// Methods not listed are never hedged
+	for i := 0; i < 3; i++ {
+		require.NoError(t, interceptor(context.Background(), "/test.Service/Create", nil, &wrapperspb.StringValue{}, nil, invoker))
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestHedgingConfig_Validate(t *testing.T) {
+	assert.NoError(t, (&HedgingConfig{}).Validate())
+	assert.NoError(t, (&HedgingConfig{Enabled: true, Percentile: 0.95}).Validate())
+	assert.Error(t, (&HedgingConfig{Enabled: true, MaxAttempts: 1}).Validate())
+	assert.Error(t, (&HedgingConfig{Enabled: true, Percentile: 95}).Validate())
+	assert.Error(t, (&HedgingConfig{Enabled: true, BudgetRatio: 2}).Validate())
+}
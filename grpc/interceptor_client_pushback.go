@@ -0,0 +1,70 @@
+package grpc
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/retry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// pushbackTrailer trailer of the server retry pushback (gRFC A6)
+const pushbackTrailer = "grpc-retry-pushback-ms"
+
+// PushbackError gRPC error carrying the server retry pushback
+// It keeps the status of the call (status.FromError, status.Code) and exposes the pushback
+// to the retry backoff (retry.HintOf).
+type PushbackError struct {
+	Err   error         // Error of the call
+	Delay time.Duration // Delay asked by the server
+	Stop  bool          // The server asked not to retry (negative or invalid pushback)
+}
+
+func (e *PushbackError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error of the call
+func (e *PushbackError) Unwrap() error {
+	return e.Err
+}
+
+// GRPCStatus returns the status of the call
+func (e *PushbackError) GRPCStatus() *status.Status {
+	return status.Convert(e.Err)
+}
+
+// RetryHint returns the server pushback
+func (e *PushbackError) RetryHint() (retry.RetryHint, bool) {
+	return retry.RetryHint{Delay: e.Delay, Stop: e.Stop}, true
+}
+
+// UnaryClientPushbackInterceptor client retry pushback interceptor
+// Failed calls answered with a grpc-retry-pushback-ms trailer return a PushbackError.
+func UnaryClientPushbackInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var trailer metadata.MD
+		err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Trailer(&trailer))...)
+		if err == nil {
+			return nil
+		}
+		return withPushback(err, trailer)
+	}
+}
+
+// withPushback wraps the error of a call with the pushback of its trailer
+func withPushback(err error, trailer metadata.MD) error {
+	values := trailer.Get(pushbackTrailer)
+	if len(values) == 0 {
+		return err
+	}
+	ms, parseErr := strconv.Atoi(strings.TrimSpace(values[0]))
+	if parseErr != nil || ms < 0 {
+		return &PushbackError{Err: err, Stop: true}
+	}
+	return &PushbackError{Err: err, Delay: time.Duration(ms) * time.Millisecond}
+}
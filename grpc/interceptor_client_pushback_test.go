@@ -0,0 +1,63 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// pushbackInvoker fails with Unavailable and the given pushback trailer
+func pushbackInvoker(pushback string) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		for _, opt := range opts {
+			if trailer, ok := opt.(grpc.TrailerCallOption); ok && pushback != "" {
+				*trailer.TrailerAddr = metadata.Pairs(pushbackTrailer, pushback)
+			}
+		}
+		return status.Error(codes.Unavailable, "overloaded")
+	}
+}
+
+func TestUnaryClientPushbackInterceptor(t *testing.T) {
+	interceptor := UnaryClientPushbackInterceptor()
+
+	err := interceptor(context.Background(), "/test.Service/Get", nil, nil, nil, pushbackInvoker("250"))
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	hint, ok := retry.HintOf(err)
+	require.True(t, ok)
+	assert.Equal(t, 250*time.Millisecond, hint.Delay)
+	assert.False(t, hint.Stop)
+
+	// Negative pushback: do not retry
+	err = interceptor(context.Background(), "/test.Service/Get", nil, nil, nil, pushbackInvoker("-1"))
+	hint, ok = retry.HintOf(err)
+	require.True(t, ok)
+	assert.True(t, hint.Stop)
+
+	// No trailer: the error is unchanged
+	err = interceptor(context.Background(), "/test.Service/Get", nil, nil, nil, pushbackInvoker(""))
+	_, ok = retry.HintOf(err)
+	assert.False(t, ok)
+	assert.Equal(t, "overloaded", status.Convert(err).Message())
+}
+
+func TestPushbackStopsRetries(t *testing.T) {
+	interceptor := UnaryClientPushbackInterceptor()
+	calls := 0
+
+	err := retry.Do(context.Background(), func() error {
+		calls++
+		return interceptor(context.Background(), "/test.Service/Get", nil, nil, nil, pushbackInvoker("-1"))
+	}, retry.GRPCDefaults...)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
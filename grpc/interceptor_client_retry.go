@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/KOMKZ/go-yogan-framework/retry"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// retryPolicy returns the named retry policy of a client, nil when it has none
+func (m *ClientManager) retryPolicy(serviceName string) (*retry.Policy, error) {
+	name := m.configs[serviceName].RetryPolicy
+	if name == "" || m.retries == nil {
+		return nil, nil
+	}
+	policy, ok := m.retries.Get(name)
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "%v: %s", retry.ErrPolicyNotFound, name)
+	}
+	return policy, nil
+}
+
+// UnaryClientRetryInterceptor client named retry policy interceptor
+//
+// The policy (grpc.clients.*.retry_policy) is resolved at each call, so a reload of
+// retry.policies applies to the next calls. Each attempt is limited by the policy timeout,
+// and attempts are recorded under the full method name.
+//
+// Parameters:
+// - clientMgr: Client manager (holds the retry registry)
+// - serviceName: service name (name configured in grpc.clients)
+func UnaryClientRetryInterceptor(clientMgr *ClientManager, serviceName string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		policy, err := clientMgr.retryPolicy(serviceName)
+		if err != nil {
+			return err
+		}
+		if policy == nil {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		timeout := policy.Config().Timeout
+		err = retry.Do(ctx, func() error {
+			attemptCtx := ctx
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				attemptCtx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+			// Drop what a failed attempt may have decoded
+			if msg, ok := reply.(proto.Message); ok {
+				proto.Reset(msg)
+			}
+			return invoker(attemptCtx, method, req, reply, cc, opts...)
+		}, append(policy.OptionsWithoutTimeout(), retry.Operation(method))...)
+		if err != nil {
+			// Return the status of the downstream, not the aggregated error
+			return retry.LastAttemptError(err)
+		}
+		return nil
+	}
+}
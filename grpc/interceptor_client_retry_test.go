@@ -0,0 +1,79 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/logger"
+	"github.com/KOMKZ/go-yogan-framework/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestUnaryClientRetryInterceptor(t *testing.T) {
+	registry, err := retry.NewRegistry(retry.RegistryConfig{Policies: map[string]retry.PolicyConfig{
+		"idempotent-read": {MaxAttempts: 3, Backoff: retry.BackoffNone, GRPCCodes: []string{"UNAVAILABLE"}, Timeout: 20 * time.Millisecond},
+	}})
+	require.NoError(t, err)
+
+	clientMgr := NewClientManager(map[string]ClientConfig{
+		"test-service": {Target: "127.0.0.1:9000", RetryPolicy: "idempotent-read"},
+	}, logger.GetLogger("test"))
+	clientMgr.SetRetryRegistry(registry)
+	interceptor := UnaryClientRetryInterceptor(clientMgr, "test-service")
+
+	// Unavailable is retried, each attempt has its own deadline
+	calls := 0
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline)
+		if calls < 3 {
+			reply.(*wrapperspb.StringValue).Value = "partial"
+			return status.Error(codes.Unavailable, "down")
+		}
+		return nil
+	}
+	reply := &wrapperspb.StringValue{}
+	require.NoError(t, interceptor(context.Background(), "/test.Service/Get", nil, reply, nil, invoker))
+	assert.Equal(t, 3, calls)
+	assert.Empty(t, reply.GetValue())
+
+	// Other codes are returned as is
+	calls = 0
+	err = interceptor(context.Background(), "/test.Service/Get", nil, &wrapperspb.StringValue{}, nil,
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			calls++
+			return status.Error(codes.InvalidArgument, "bad request")
+		})
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The policy is resolved at each call
+	require.NoError(t, registry.Reload(retry.RegistryConfig{Policies: map[string]retry.PolicyConfig{
+		"other": {},
+	}}))
+	err = interceptor(context.Background(), "/test.Service/Get", nil, &wrapperspb.StringValue{}, nil, invoker)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestUnaryClientRetryInterceptor_NoPolicy(t *testing.T) {
+	clientMgr := NewClientManager(map[string]ClientConfig{
+		"test-service": {Target: "127.0.0.1:9000"},
+	}, logger.GetLogger("test"))
+	interceptor := UnaryClientRetryInterceptor(clientMgr, "test-service")
+
+	calls := 0
+	err := interceptor(context.Background(), "/test.Service/Get", nil, nil, nil,
+		func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			calls++
+			return status.Error(codes.Unavailable, "down")
+		})
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
@@ -0,0 +1,235 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/jwt"
+	"github.com/KOMKZ/go-yogan-framework/limiter"
+	"github.com/KOMKZ/go-yogan-framework/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// RetryAfterMetadataKey trailer of rejected calls, seconds until a retry may succeed
+	RetryAfterMetadataKey = "retry-after"
+
+	// RetryPushbackMetadataKey trailer of rejected calls honored by gRPC client retries (milliseconds)
+	RetryPushbackMetadataKey = "grpc-retry-pushback-ms"
+)
+
+// ServerRateLimitOptions server rate limiting interceptor options
+type ServerRateLimitOptions struct {
+	// Manager rate limiter manager (required)
+	Manager *limiter.Manager
+
+	// CallerKeyFunc optional per-caller key, the resource becomes {fullMethod}:{caller}
+	// Calls with an empty caller key are limited by the method resource
+	CallerKeyFunc func(ctx context.Context) string
+
+	// SkipMethods full method names that are not rate limited (e.g., health checks)
+	SkipMethods []string
+
+	// Logger optional logger
+	Logger *logger.CtxZapLogger
+}
+
+// UnaryServerRateLimitInterceptor server rate limiting interceptor for inbound unary calls
+//
+// Resource name: {fullMethod} or {fullMethod}:{caller} (e.g., "/auth.AuthService/Login:user-1"),
+// configured resources may use patterns like "/auth.AuthService/*".
+// Resources with the concurrency and latency algorithms hold their permit until the handler returns,
+// the latency algorithm tunes its limit from the handler latency.
+// Rejected calls get codes.ResourceExhausted with retry-after and grpc-retry-pushback-ms trailers.
+func UnaryServerRateLimitInterceptor(opts ServerRateLimitOptions) grpc.UnaryServerInterceptor {
+	guard := newServerRateLimitGuard(opts)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		resource, ok, err := guard.acquire(ctx, info.FullMethod, func(md metadata.MD) {
+			_ = grpc.SetTrailer(ctx, md)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return handler(ctx, req)
+		}
+		// Deferred so that the permit is returned when the handler panics (recovered by an earlier interceptor)
+		start := time.Now()
+		defer func() { guard.complete(ctx, resource, start, err) }()
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerRateLimitInterceptor server rate limiting interceptor for inbound streams
+// A stream counts as one request when it is opened, concurrency permits are held until the stream ends
+func StreamServerRateLimitInterceptor(opts ServerRateLimitOptions) grpc.StreamServerInterceptor {
+	guard := newServerRateLimitGuard(opts)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		ctx := ss.Context()
+		resource, ok, err := guard.acquire(ctx, info.FullMethod, ss.SetTrailer)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return handler(srv, ss)
+		}
+		start := time.Now()
+		defer func() { guard.complete(ctx, resource, start, err) }()
+		return handler(srv, ss)
+	}
+}
+
+// serverRateLimitGuard shared logic of the unary and stream interceptors
+type serverRateLimitGuard struct {
+	opts ServerRateLimitOptions
+	skip map[string]bool
+}
+
+func newServerRateLimitGuard(opts ServerRateLimitOptions) *serverRateLimitGuard {
+	if opts.Manager == nil {
+		panic("ServerRateLimitOptions.Manager cannot be nil")
+	}
+	if opts.Logger == nil {
+		opts.Logger = logger.GetLogger("yogan")
+	}
+	skip := make(map[string]bool, len(opts.SkipMethods))
+	for _, method := range opts.SkipMethods {
+		skip[method] = true
+	}
+	return &serverRateLimitGuard{opts: opts, skip: skip}
+}
+
+// acquire checks the rate limit of a call, returns the resource and whether a permit was taken
+// setTrailer receives the retry metadata of rejected calls
+func (g *serverRateLimitGuard) acquire(ctx context.Context, fullMethod string, setTrailer func(metadata.MD)) (string, bool, error) {
+	if !g.opts.Manager.IsEnabled() || g.skip[fullMethod] {
+		return "", false, nil
+	}
+
+	resource := fullMethod
+	if g.opts.CallerKeyFunc != nil {
+		if caller := g.opts.CallerKeyFunc(ctx); caller != "" {
+			resource = fullMethod + ":" + caller
+		}
+	}
+
+	resp, err := g.opts.Manager.Check(ctx, resource, 1)
+	if err != nil {
+		// Rate limiter errors must not break the service, allow the call
+		g.opts.Logger.WarnCtx(ctx, "⚠️  Rate limit check failed, allowing request",
+			zap.String("method", fullMethod),
+			zap.String("resource", resource),
+			zap.Error(err))
+		return "", false, nil
+	}
+
+	if !resp.Allowed {
+		g.opts.Logger.WarnCtx(ctx, "🚫 Inbound request rate limited",
+			zap.String("method", fullMethod),
+			zap.String("resource", resource),
+			zap.Duration("retry_after", resp.RetryAfter))
+		setTrailer(retryMetadata(resp.RetryAfter))
+		return "", false, status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s", fullMethod)
+	}
+	return resource, true, nil
+}
+
+// complete returns the permit of a completed call with its latency (no-op for algorithms without permits)
+// DeadlineExceeded, Unavailable and ResourceExhausted count as dropped (overload), canceled calls are not sampled
+func (g *serverRateLimitGuard) complete(ctx context.Context, resource string, start time.Time, callErr error) {
+	releaseCtx := context.WithoutCancel(ctx)
+	var err error
+	switch status.Code(callErr) {
+	case codes.Canceled:
+		err = g.opts.Manager.Release(releaseCtx, resource, 1)
+	case codes.DeadlineExceeded, codes.Unavailable, codes.ResourceExhausted:
+		err = g.opts.Manager.Complete(releaseCtx, resource, 1, time.Since(start), true)
+	default:
+		err = g.opts.Manager.Complete(releaseCtx, resource, 1, time.Since(start), false)
+	}
+	if err != nil {
+		g.opts.Logger.WarnCtx(ctx, "⚠️  Rate limit release failed",
+			zap.String("resource", resource),
+			zap.Error(err))
+	}
+}
+
+// retryMetadata builds the retry hints of a rejected call
+func retryMetadata(retryAfter time.Duration) metadata.MD {
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return metadata.Pairs(
+		RetryAfterMetadataKey, strconv.FormatInt(seconds, 10),
+		RetryPushbackMetadataKey, strconv.FormatInt(retryAfter.Milliseconds(), 10),
+	)
+}
+
+// CallerKeyFromMetadata uses an incoming metadata value as the caller key (e.g., "x-api-key")
+// Clients can set any metadata, use it only for values set or checked by a trusted gateway
+func CallerKeyFromMetadata(key string) func(ctx context.Context) string {
+	key = strings.ToLower(key)
+	return func(ctx context.Context) string {
+		return incomingMetadata(ctx, key)
+	}
+}
+
+// CallerKeyFromJWTSubject uses the subject of the verified bearer token in the authorization metadata as the caller key
+// Calls without a valid token get an empty key and are limited by the method resource
+func CallerKeyFromJWTSubject(tokenManager jwt.TokenManager) func(ctx context.Context) string {
+	return func(ctx context.Context) string {
+		claims, err := verifyBearerToken(ctx, tokenManager)
+		if err != nil || claims == nil {
+			return ""
+		}
+		return claims.Subject
+	}
+}
+
+// verifyBearerToken verifies the bearer token of the authorization metadata (nil claims without a token)
+func verifyBearerToken(ctx context.Context, tokenManager jwt.TokenManager) (*jwt.Claims, error) {
+	token, found := strings.CutPrefix(incomingMetadata(ctx, "authorization"), "Bearer ")
+	if !found || token == "" {
+		return nil, nil
+	}
+	if tokenManager == nil {
+		return nil, errors.New("jwt token manager not set")
+	}
+	return tokenManager.VerifyToken(ctx, token)
+}
+
+// incomingMetadata returns the first incoming metadata value of a key
+func incomingMetadata(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// CallerKeyFromPeer uses the peer IP address as the caller key
+func CallerKeyFromPeer(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	addr := p.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
@@ -0,0 +1,233 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/jwt"
+	"github.com/KOMKZ/go-yogan-framework/limiter"
+	"github.com/KOMKZ/go-yogan-framework/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func newServerLimiter(t *testing.T, resources map[string]limiter.ResourceConfig) *limiter.Manager {
+	mgr, err := limiter.NewManagerWithLogger(limiter.Config{
+		Enabled:   true,
+		StoreType: "memory",
+		Resources: resources,
+	}, logger.GetLogger("test"), nil, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { mgr.Close() })
+	return mgr
+}
+
+// fakeServerStream minimal grpc.ServerStream recording trailers
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	trailer metadata.MD
+}
+
+func (s *fakeServerStream) Context() context.Context { return s.ctx }
+func (s *fakeServerStream) SetTrailer(md metadata.MD) {
+	s.trailer = metadata.Join(s.trailer, md)
+}
+
+func TestUnaryServerRateLimitInterceptor_PerCaller(t *testing.T) {
+	mgr := newServerLimiter(t, map[string]limiter.ResourceConfig{
+		"/test.Service/*": {Algorithm: "token_bucket", Rate: 1, Capacity: 1},
+	})
+	interceptor := UnaryServerRateLimitInterceptor(ServerRateLimitOptions{
+		Manager:       mgr,
+		CallerKeyFunc: CallerKeyFromPeer,
+	})
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Get"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	callerCtx := func(addr string) context.Context {
+		tcpAddr, err := net.ResolveTCPAddr("tcp", addr)
+		require.NoError(t, err)
+		return peer.NewContext(context.Background(), &peer.Peer{Addr: tcpAddr})
+	}
+
+	_, err := interceptor(callerCtx("10.0.0.1:5000"), nil, info, handler)
+	require.NoError(t, err)
+
+	// The client port does not matter, the caller is its address
+	_, err = interceptor(callerCtx("10.0.0.1:5001"), nil, info, handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Another caller has its own quota
+	_, err = interceptor(callerCtx("10.0.0.2:5000"), nil, info, handler)
+	assert.NoError(t, err)
+
+	// Unconfigured methods are not rate limited
+	for i := 0; i < 3; i++ {
+		_, err = interceptor(callerCtx("10.0.0.1:5000"), nil, &grpc.UnaryServerInfo{FullMethod: "/other.Service/Get"}, handler)
+		assert.NoError(t, err)
+	}
+}
+
+func TestStreamServerRateLimitInterceptor_Concurrency(t *testing.T) {
+	mgr := newServerLimiter(t, map[string]limiter.ResourceConfig{
+		"/test.Service/Watch": {Algorithm: "concurrency", MaxConcurrency: 1},
+	})
+	interceptor := StreamServerRateLimitInterceptor(ServerRateLimitOptions{Manager: mgr})
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Watch", IsServerStream: true}
+
+	// While the first stream runs, a second one is rejected with retry hints
+	var inner error
+	var innerStream *fakeServerStream
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		innerStream = &fakeServerStream{ctx: context.Background()}
+		inner = interceptor(nil, innerStream, info, func(interface{}, grpc.ServerStream) error { return nil })
+		return nil
+	}
+	require.NoError(t, interceptor(nil, &fakeServerStream{ctx: context.Background()}, info, handler))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(inner))
+	assert.Equal(t, []string{"1"}, innerStream.trailer.Get(RetryAfterMetadataKey))
+	assert.NotEmpty(t, innerStream.trailer.Get(RetryPushbackMetadataKey))
+
+	// The permit is released when the stream ends
+	assert.NoError(t, interceptor(nil, &fakeServerStream{ctx: context.Background()}, info,
+		func(interface{}, grpc.ServerStream) error { return nil }))
+}
+
+func TestServer_InboundRateLimit(t *testing.T) {
+	mgr := newServerLimiter(t, map[string]limiter.ResourceConfig{
+		"/grpc.health.v1.Health/Check": {Algorithm: "token_bucket", Rate: 1, Capacity: 1},
+	})
+
+	server := NewServer(ServerConfig{
+		Enabled:     true,
+		MaxRecvSize: 4,
+		MaxSendSize: 4,
+		RateLimit:   RateLimitConfig{Enabled: true},
+	}, logger.GetLogger("grpc_test"))
+	server.SetLimiter(mgr)
+	grpc_health_v1.RegisterHealthServer(server.GetGRPCServer(), health.NewServer())
+	require.NoError(t, server.Start(context.Background()))
+	defer server.Stop(context.Background())
+
+	conn, err := grpc.NewClient(fmt.Sprintf("127.0.0.1:%d", server.Port),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client := grpc_health_v1.NewHealthClient(conn)
+
+	_, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	var trailer metadata.MD
+	_, err = client.Check(ctx, &grpc_health_v1.HealthCheckRequest{}, grpc.Trailer(&trailer))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, []string{"1"}, trailer.Get(RetryAfterMetadataKey))
+}
+
+// fakeTokenManager accepts the token "valid" for subject user-1
+type fakeTokenManager struct {
+	jwt.TokenManager
+	verified *int
+}
+
+func (m fakeTokenManager) VerifyToken(ctx context.Context, token string) (*jwt.Claims, error) {
+	if m.verified != nil {
+		*m.verified++
+	}
+	if token != "valid" {
+		return nil, jwt.ErrTokenInvalid
+	}
+	return &jwt.Claims{Subject: "user-1"}, nil
+}
+
+func TestCallerKeyFromJWTSubject(t *testing.T) {
+	withAuth := func(value string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", value))
+	}
+
+	verified := 0
+	keyFunc := CallerKeyFromJWTSubject(fakeTokenManager{verified: &verified})
+	assert.Equal(t, "user-1", keyFunc(withAuth("Bearer valid")))
+	assert.Equal(t, 1, verified)
+
+	// Forged or missing tokens give no caller key
+	assert.Empty(t, keyFunc(withAuth("Bearer forged")))
+	assert.Empty(t, keyFunc(withAuth("valid")))
+	assert.Empty(t, keyFunc(context.Background()))
+
+	// Without a token manager no token is trusted
+	assert.Empty(t, CallerKeyFromJWTSubject(nil)(withAuth("Bearer valid")))
+	_, err := verifyBearerToken(withAuth("Bearer valid"), nil)
+	assert.Error(t, err)
+}
+
+func TestCallerKeyFromMetadata(t *testing.T) {
+	keyFunc := CallerKeyFromMetadata("X-Api-Key")
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", "tenant-1"))
+	assert.Equal(t, "tenant-1", keyFunc(ctx))
+	assert.Empty(t, keyFunc(context.Background()))
+}
+
+func TestUnaryServerRateLimitInterceptor_HandlerPanic(t *testing.T) {
+	mgr := newServerLimiter(t, map[string]limiter.ResourceConfig{
+		"/test.Service/Get": {Algorithm: "concurrency", MaxConcurrency: 1},
+	})
+	interceptor := UnaryServerRateLimitInterceptor(ServerRateLimitOptions{Manager: mgr})
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Get"}
+
+	// The recovery interceptor sits earlier in the chain, the permit must still be returned
+	func() {
+		defer func() { assert.NotNil(t, recover()) }()
+		_, _ = interceptor(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+			panic("boom")
+		})
+	}()
+
+	_, err := interceptor(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestUnaryServerRateLimitInterceptor_Latency(t *testing.T) {
+	mgr := newServerLimiter(t, map[string]limiter.ResourceConfig{
+		"/test.Service/Get": {Algorithm: "latency", MinLimit: 1, InitLimit: 1, SampleWindow: time.Nanosecond},
+	})
+	interceptor := UnaryServerRateLimitInterceptor(ServerRateLimitOptions{Manager: mgr})
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Get"}
+
+	// The permit is held while the handler runs
+	var inner error
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		_, inner = interceptor(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+			return "ok", nil
+		})
+		return "ok", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(inner))
+
+	// Overload errors count as dropped, the permit is returned either way
+	for i := 0; i < 3; i++ {
+		_, err = interceptor(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+			return nil, status.Error(codes.Unavailable, "overloaded")
+		})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	}
+	assert.Equal(t, map[string]int64{"/test.Service/Get": 1}, mgr.CurrentLimits())
+}
@@ -0,0 +1,209 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/governance"
+	"github.com/KOMKZ/go-yogan-framework/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/attributes"
+	"google.golang.org/grpc/resolver"
+)
+
+// ResolverScheme scheme of the discovery targets (e.g., yogan:///user-service)
+const ResolverScheme = "yogan"
+
+// instanceAttributeKey address attribute key of the discovered instance
+type instanceAttributeKey struct{}
+
+// instanceAttribute discovered instance of an address (balancer attribute)
+// Equal compares the instance address only, so a metadata change never changes the address identity
+// and the instance keeps its sub-connection; the current metadata comes with the resolver state.
+type instanceAttribute struct {
+	instance *governance.ServiceInstance
+}
+
+// Equal reports whether two attributes describe the same instance address
+func (a instanceAttribute) Equal(o any) bool {
+	other, ok := o.(instanceAttribute)
+	return ok && a.instance.GetAddress() == other.instance.GetAddress()
+}
+
+// InstanceFromAddress returns the discovered instance of a resolved address (nil for other addresses)
+// The instance metadata (zone, version, ...) is available to balancers through it. Balancers keeping
+// their sub-connections across updates may hold the instance of an earlier update; the yogan_selector
+// balancer reads the current instances from the resolver state.
+func InstanceFromAddress(addr resolver.Address) *governance.ServiceInstance {
+	if attr, ok := addr.BalancerAttributes.Value(instanceAttributeKey{}).(instanceAttribute); ok {
+		return attr.instance
+	}
+	return nil
+}
+
+// ResolverBuilder resolves yogan:///{service} targets with a governance.ServiceDiscovery
+// Every healthy instance becomes an address, so the balancer of the connection spreads the calls
+// across all of them; the address list follows the Watch updates of the discoverer.
+type ResolverBuilder struct {
+	discovery  governance.ServiceDiscovery
+	logger     *logger.CtxZapLogger
+	attributes *attributes.Attributes // Resolver state attributes (selector balancer configuration)
+}
+
+// NewResolverBuilder creates a resolver builder (register it with grpc.WithResolvers)
+func NewResolverBuilder(discovery governance.ServiceDiscovery, log *logger.CtxZapLogger) *ResolverBuilder {
+	if log == nil {
+		log = logger.GetLogger("yogan")
+	}
+	return &ResolverBuilder{discovery: discovery, logger: log}
+}
+
+// Scheme returns the resolver scheme
+func (b *ResolverBuilder) Scheme() string {
+	return ResolverScheme
+}
+
+// Build starts resolving a target
+func (b *ResolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, opts resolver.BuildOptions) (resolver.Resolver, error) {
+	serviceName := target.Endpoint()
+	if serviceName == "" {
+		return nil, fmt.Errorf("resolver: target %s has no service name", target.String())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &discoveryResolver{
+		builder:     b,
+		serviceName: serviceName,
+		cc:          cc,
+		ctx:         ctx,
+		cancel:      cancel,
+		resolveNow:  make(chan struct{}, 1),
+	}
+	go r.run()
+	return r, nil
+}
+
+// discoveryResolver resolver of one target
+type discoveryResolver struct {
+	builder     *ResolverBuilder
+	serviceName string
+	cc          resolver.ClientConn
+	ctx         context.Context
+	cancel      context.CancelFunc
+	resolveNow  chan struct{}
+}
+
+// ResolveNow asks for a new discovery (e.g., after a connection failure)
+func (r *discoveryResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.resolveNow <- struct{}{}:
+	default:
+	}
+}
+
+// Close stops resolving
+func (r *discoveryResolver) Close() {
+	r.cancel()
+}
+
+// run discovers the instances, then follows the Watch updates (the watch restarts with backoff)
+func (r *discoveryResolver) run() {
+	r.discover()
+
+	backoff := time.Second
+	maxBackoff := 30 * time.Second
+	for {
+		watchCh, err := r.builder.discovery.Watch(r.ctx, r.serviceName)
+		if err == nil {
+			backoff = time.Second
+			err = r.follow(watchCh)
+		}
+		if r.ctx.Err() != nil {
+			return
+		}
+		r.builder.logger.WarnCtx(r.ctx, "⚠️  Watch interrupted, will retry later",
+			zap.String("service", r.serviceName),
+			zap.Error(err),
+			zap.Duration("retry_after", backoff))
+
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-r.resolveNow:
+			r.discover()
+		case <-time.After(backoff):
+			backoff = min(backoff*2, maxBackoff)
+		}
+	}
+}
+
+// follow applies the updates of a watch until it ends
+func (r *discoveryResolver) follow(watchCh <-chan []*governance.ServiceInstance) error {
+	for {
+		select {
+		case <-r.ctx.Done():
+			return nil
+		case <-r.resolveNow:
+			r.discover()
+		case instances, ok := <-watchCh:
+			if !ok {
+				return fmt.Errorf("watch channel closed")
+			}
+			r.update(instances)
+		}
+	}
+}
+
+// discover resolves the instances once
+func (r *discoveryResolver) discover() {
+	instances, err := r.builder.discovery.Discover(r.ctx, r.serviceName)
+	if err != nil {
+		if r.ctx.Err() == nil {
+			r.cc.ReportError(fmt.Errorf("discover %s: %w", r.serviceName, err))
+		}
+		return
+	}
+	r.update(instances)
+}
+
+// update sends the healthy instances to the connection
+//
+// When no instance is healthy the update is reported as an error and the connection keeps its previous
+// addresses (fail static): a registry marking every instance unhealthy at once, such as a health check
+// outage, does not take the client down, and the connection health checks still skip dead instances.
+// Before the first healthy update, calls fail with Unavailable.
+func (r *discoveryResolver) update(instances []*governance.ServiceInstance) {
+	addresses := make([]resolver.Address, 0, len(instances))
+	for _, instance := range instances {
+		if !instance.Healthy {
+			continue
+		}
+		addresses = append(addresses, resolver.Address{
+			Addr:               instance.GetAddress(),
+			BalancerAttributes: attributes.New(instanceAttributeKey{}, instanceAttribute{instance: instance}),
+		})
+	}
+	if len(addresses) == 0 {
+		r.builder.logger.WarnCtx(r.ctx, "⚠️  No healthy service instance, keeping the previous addresses",
+			zap.String("service", r.serviceName),
+			zap.Int("instances", len(instances)))
+		r.cc.ReportError(fmt.Errorf("discover %s: %w", r.serviceName, governance.ErrNoAvailableInstance))
+		return
+	}
+
+	r.builder.logger.DebugCtx(r.ctx, "🔄 Service instance list updated",
+		zap.String("service", r.serviceName),
+		zap.Int("instances", len(instances)),
+		zap.Int("healthy_count", len(addresses)))
+
+	state := resolver.State{
+		Addresses:  addresses,
+		Attributes: r.builder.attributes.WithValue(resolvedInstancesKey{}, &resolvedInstances{all: instances}),
+	}
+	if err := r.cc.UpdateState(state); err != nil {
+		r.builder.logger.DebugCtx(r.ctx, "Resolver state rejected",
+			zap.String("service", r.serviceName),
+			zap.Error(err))
+	}
+}
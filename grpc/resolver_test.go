@@ -0,0 +1,183 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/governance"
+	"github.com/KOMKZ/go-yogan-framework/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/attributes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/resolver"
+)
+
+// countingHealthServer health server counting its calls
+type countingHealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	calls int32
+}
+
+func (s *countingHealthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	atomic.AddInt32(&s.calls, 1)
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+// startCountingServer starts a server and returns its address
+func startCountingServer(t *testing.T) (*countingHealthServer, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	health := &countingHealthServer{}
+	grpc_health_v1.RegisterHealthServer(server, health)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return health, listener.Addr().String()
+}
+
+// callsOf returns the calls of each server
+func callsOf(servers ...*countingHealthServer) []int32 {
+	calls := make([]int32, len(servers))
+	for i, server := range servers {
+		calls[i] = atomic.SwapInt32(&server.calls, 0)
+	}
+	return calls
+}
+
+func TestClientManager_ResolverBalancing(t *testing.T) {
+	log := logger.GetLogger("grpc_test")
+	v1, v1Addr := startCountingServer(t)
+	v2, v2Addr := startCountingServer(t)
+
+	discovery, err := governance.NewStaticDiscovery(map[string][]governance.StaticInstance{
+		"test-service": {
+			{Address: v1Addr, Metadata: map[string]string{"version": "v1"}},
+			{Address: v2Addr, Metadata: map[string]string{"version": "v2"}},
+		},
+	}, log)
+	require.NoError(t, err)
+	defer discovery.Stop()
+
+	manager := NewClientManager(map[string]ClientConfig{
+		"selector": {
+			DiscoveryMode: "static",
+			ServiceName:   "test-service",
+			Timeout:       5,
+			Selector:      "round_robin",
+			Routing: governance.RoutingConfig{
+				Subsets: []governance.SubsetRule{{Header: "x-version", MetadataKey: "version"}},
+			},
+		},
+		"builtin": {DiscoveryMode: "static", ServiceName: "test-service", Timeout: 5, LoadBalance: "round_robin"},
+	}, log)
+	manager.SetModeDiscovery("static", discovery)
+	defer manager.Close()
+
+	check := func(clientName string, ctx context.Context, calls int) {
+		conn, err := manager.GetConn(clientName)
+		require.NoError(t, err)
+		client := grpc_health_v1.NewHealthClient(conn)
+		for i := 0; i < calls; i++ {
+			callCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+			_, err := client.Check(callCtx, &grpc_health_v1.HealthCheckRequest{})
+			cancel()
+			require.NoError(t, err)
+		}
+	}
+
+	// One connection, the calls are spread over every instance (once both sub-connections are ready)
+	require.Eventually(t, func() bool {
+		check("selector", context.Background(), 2)
+		calls := callsOf(v1, v2)
+		return calls[0] > 0 && calls[1] > 0
+	}, 3*time.Second, 20*time.Millisecond)
+	check("selector", context.Background(), 10)
+	assert.Equal(t, []int32{5, 5}, callsOf(v1, v2))
+
+	// Routing subsets apply to each call
+	check("selector", metadata.AppendToOutgoingContext(context.Background(), "x-version", "v2"), 4)
+	assert.Equal(t, []int32{0, 4}, callsOf(v1, v2))
+
+	// gRPC built-in policies work on the resolved addresses as well
+	require.Eventually(t, func() bool {
+		check("builtin", context.Background(), 4)
+		calls := callsOf(v1, v2)
+		return calls[0] > 0 && calls[1] > 0
+	}, 3*time.Second, 20*time.Millisecond)
+
+	// The addresses follow the discovery without a new connection
+	require.NoError(t, discovery.Update(map[string][]governance.StaticInstance{
+		"test-service": {{Address: v2Addr, Metadata: map[string]string{"version": "v2"}}},
+	}))
+	require.Eventually(t, func() bool {
+		check("selector", context.Background(), 4)
+		return assert.ObjectsAreEqual([]int32{0, 4}, callsOf(v1, v2))
+	}, 3*time.Second, 50*time.Millisecond)
+}
+
+func TestInstanceFromAddress(t *testing.T) {
+	instance := &governance.ServiceInstance{ID: "a", Address: "10.0.0.1", Port: 9000, Metadata: map[string]string{"zone": "a"}}
+	addr := resolver.Address{
+		Addr:               instance.GetAddress(),
+		BalancerAttributes: attributes.New(instanceAttributeKey{}, instanceAttribute{instance: instance}),
+	}
+	assert.Equal(t, "a", InstanceFromAddress(addr).Metadata["zone"])
+	assert.Nil(t, InstanceFromAddress(resolver.Address{Addr: "10.0.0.1:9000"}))
+
+	// A metadata change keeps the address identity
+	changed := *instance
+	changed.Metadata = map[string]string{"zone": "b"}
+	assert.True(t, addr.Equal(resolver.Address{
+		Addr:               changed.GetAddress(),
+		BalancerAttributes: attributes.New(instanceAttributeKey{}, instanceAttribute{instance: &changed}),
+	}))
+}
+
+// recordingClientConn resolver.ClientConn recording the updates of a resolver
+type recordingClientConn struct {
+	resolver.ClientConn
+	states []resolver.State
+	errs   []error
+}
+
+func (c *recordingClientConn) UpdateState(state resolver.State) error {
+	c.states = append(c.states, state)
+	return nil
+}
+
+func (c *recordingClientConn) ReportError(err error) {
+	c.errs = append(c.errs, err)
+}
+
+func TestDiscoveryResolver_Update(t *testing.T) {
+	cc := &recordingClientConn{}
+	r := &discoveryResolver{builder: NewResolverBuilder(nil, nil), serviceName: "user-service", cc: cc, ctx: context.Background()}
+
+	healthy := &governance.ServiceInstance{ID: "a", Address: "10.0.0.1", Port: 9000, Healthy: true}
+	down := &governance.ServiceInstance{ID: "b", Address: "10.0.0.2", Port: 9000}
+	r.update([]*governance.ServiceInstance{healthy, down})
+	require.Len(t, cc.states, 1)
+	require.Len(t, cc.states[0].Addresses, 1)
+	assert.Equal(t, "10.0.0.1:9000", cc.states[0].Addresses[0].Addr)
+	assert.Same(t, healthy, InstanceFromAddress(cc.states[0].Addresses[0]))
+
+	// Without a healthy instance the error is reported and the previous addresses stay in use
+	r.update([]*governance.ServiceInstance{down})
+	assert.Len(t, cc.states, 1)
+	require.Len(t, cc.errs, 1)
+	assert.ErrorIs(t, cc.errs[0], governance.ErrNoAvailableInstance)
+}
+
+func TestResolverBuilder_Build(t *testing.T) {
+	builder := NewResolverBuilder(nil, nil)
+	assert.Equal(t, ResolverScheme, builder.Scheme())
+
+	_, err := builder.Build(resolver.Target{}, nil, resolver.BuildOptions{})
+	assert.Error(t, err, "the target needs a service name")
+}
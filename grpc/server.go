@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"strings"
 
+	"github.com/KOMKZ/go-yogan-framework/jwt"
+	"github.com/KOMKZ/go-yogan-framework/limiter"
 	"github.com/KOMKZ/go-yogan-framework/logger"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/otel/trace"
@@ -23,7 +26,10 @@ type Server struct {
 	tracerProvider trace.TracerProvider // 🎯 OpenTelemetry TracerProvider (optional)
 	statsHandler   stats.Handler        // 🎯 StatsHandler (for OTel integration)
 	interceptors   []grpc.UnaryServerInterceptor
+	streamInts     []grpc.StreamServerInterceptor
 	serverOpts     []grpc.ServerOption // 🎯 Additional Server options
+	limiter        *limiter.Manager    // Inbound rate limiting (optional)
+	tokenManager   jwt.TokenManager    // JWT subject caller keys (optional)
 }
 
 // Create gRPC Server (using default interceptors)
@@ -97,9 +103,18 @@ func (s *Server) buildGRPCServer() {
 		s.logger.DebugCtx(context.Background(), "✅ StatsHandler registered to gRPC server")
 	}
 
-	// Add interceptor chain
-	if len(s.interceptors) > 0 {
-		opts = append(opts, grpc.ChainUnaryInterceptor(s.interceptors...))
+	// Add interceptor chain (inbound rate limiting runs after the default interceptors)
+	interceptors := s.interceptors
+	streamInts := s.streamInts
+	if rateLimitOpts, ok := s.rateLimitOptions(); ok {
+		interceptors = append(interceptors[:len(interceptors):len(interceptors)], UnaryServerRateLimitInterceptor(rateLimitOpts))
+		streamInts = append(streamInts[:len(streamInts):len(streamInts)], StreamServerRateLimitInterceptor(rateLimitOpts))
+	}
+	if len(interceptors) > 0 {
+		opts = append(opts, grpc.ChainUnaryInterceptor(interceptors...))
+	}
+	if len(streamInts) > 0 {
+		opts = append(opts, grpc.ChainStreamInterceptor(streamInts...))
 	}
 
 	// 3. Add other options
@@ -114,6 +129,38 @@ func (s *Server) buildGRPCServer() {
 	}
 }
 
+// rateLimitOptions builds the inbound rate limiting options, false when rate limiting is off
+func (s *Server) rateLimitOptions() (ServerRateLimitOptions, bool) {
+	cfg := s.config.RateLimit
+	if !cfg.Enabled || s.limiter == nil || !s.limiter.IsEnabled() {
+		return ServerRateLimitOptions{}, false
+	}
+
+	opts := ServerRateLimitOptions{
+		Manager:     s.limiter,
+		SkipMethods: cfg.SkipMethods,
+		Logger:      s.logger,
+	}
+	switch {
+	case cfg.CallerKey == "":
+	case cfg.CallerKey == "jwt_subject":
+		if s.tokenManager == nil {
+			s.logger.WarnCtx(context.Background(), "⚠️  JWT token manager not set, limiting by method only",
+				zap.String("caller_key", cfg.CallerKey))
+			break
+		}
+		opts.CallerKeyFunc = CallerKeyFromJWTSubject(s.tokenManager)
+	case cfg.CallerKey == "peer":
+		opts.CallerKeyFunc = CallerKeyFromPeer
+	case strings.HasPrefix(cfg.CallerKey, "metadata:"):
+		opts.CallerKeyFunc = CallerKeyFromMetadata(strings.TrimPrefix(cfg.CallerKey, "metadata:"))
+	default:
+		s.logger.WarnCtx(context.Background(), "⚠️  Unknown rate limit caller_key, limiting by method only",
+			zap.String("caller_key", cfg.CallerKey))
+	}
+	return opts, true
+}
+
 // Shut down gRPC Server gracefully
 func (s *Server) Stop(ctx context.Context) {
 	if s.server == nil {
@@ -132,6 +179,25 @@ func (s *Server) GetGRPCServer() *grpc.Server {
 	return s.server
 }
 
+// SetLimiter sets the rate limiter manager of inbound rate limiting (call before Start)
+// Takes effect when grpc.server.rate_limit.enabled is true
+func (s *Server) SetLimiter(lim *limiter.Manager) {
+	s.limiter = lim
+	if lim != nil && lim.IsEnabled() && s.config.RateLimit.Enabled {
+		s.logger.DebugCtx(context.Background(), "✅ Rate limiter injected into gRPC server")
+	}
+}
+
+// SetTokenManager sets the JWT token manager that verifies tokens for jwt_subject caller keys (call before Start)
+func (s *Server) SetTokenManager(tm jwt.TokenManager) {
+	s.tokenManager = tm
+}
+
+// AddStreamInterceptors appends stream interceptors (call before Start)
+func (s *Server) AddStreamInterceptors(interceptors ...grpc.StreamServerInterceptor) {
+	s.streamInts = append(s.streamInts, interceptors...)
+}
+
 // SetTracerProvider sets the TracerProvider (call before Start)
 // 🎯 Automatically create otelgrpc.NewServerHandler
 func (s *Server) SetTracerProvider(tp trace.TracerProvider) {
@@ -52,7 +52,7 @@ func (c *Client) executeWithBreaker(ctx context.Context, req *Request, cfg *conf
 	// Check if the circuit breaker is disabled
 	if cfg.breakerDisabled || cfg.breakerManager == nil || !cfg.breakerManager.IsEnabled() {
 		// Circuit breaker not enabled, execute directly
-		return c.doRequest(ctx, req, cfg)
+		return c.send(ctx, req, cfg)
 	}
 	
 	// Determine resource name
@@ -71,14 +71,14 @@ func (c *Client) executeWithBreaker(ctx context.Context, req *Request, cfg *conf
 		Resource: resource,
 		Execute: func(ctx context.Context) (interface{}, error) {
 			// Execute the actual HTTP request
-			resp, err := c.doRequest(ctx, req, cfg)
+			resp, err := c.send(ctx, req, cfg)
 			if err != nil {
 				return nil, err
 			}
 			
 			// Check the HTTP status code; 5xx errors should trigger circuit breaking
 			if resp.IsServerError() {
-				return resp, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+				return resp, resp.statusError()
 			}
 			
 			return resp, nil
@@ -88,6 +88,17 @@ func (c *Client) Do(ctx context.Context, req *Request, opts ...Option) (*Respons
 		!finalCfg.breakerDisabled && 
 		finalCfg.breakerManager.IsEnabled()
 	
+	// Resolve the named retry policy
+	if finalCfg.retryEnabled && finalCfg.retryPolicy != "" {
+		if finalCfg.retryRegistry == nil {
+			return nil, fmt.Errorf("retry policy %s: no retry registry (WithRetryRegistry)", finalCfg.retryPolicy)
+		}
+		finalCfg.retryOpts, err = finalCfg.retryRegistry.Options(finalCfg.retryPolicy)
+		if err != nil {
+			return nil, err
+		}
+	}
+	
 	if finalCfg.retryEnabled && len(finalCfg.retryOpts) > 0 {
 		// Use retry
 		err = retry.Do(ctx, func() error {
@@ -96,7 +107,7 @@ func (c *Client) Do(ctx context.Context, req *Request, opts ...Option) (*Respons
 				resp, err = c.executeWithBreaker(ctx, req, finalCfg)
 			} else {
 				// Execute directly
-				resp, err = c.doRequest(ctx, req, finalCfg)
+				resp, err = c.send(ctx, req, finalCfg)
 			}
 			
 			if err != nil {
@@ -105,7 +116,7 @@ func (c *Client) Do(ctx context.Context, req *Request, opts ...Option) (*Respons
 			
 			// Check if the HTTP status code requires a retry
 			if resp.IsServerError() || resp.StatusCode == 429 {
-				return fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+				return resp.statusError()
 			}
 			
 			return nil
@@ -122,7 +133,7 @@ func (c *Client) Do(ctx context.Context, req *Request, opts ...Option) (*Respons
 			resp, err = c.executeWithBreaker(ctx, req, finalCfg)
 		} else {
 			// Execute directly
-			resp, err = c.doRequest(ctx, req, finalCfg)
+			resp, err = c.send(ctx, req, finalCfg)
 		}
 	}
 	
@@ -167,8 +178,13 @@ func (c *Client) doRequest(ctx context.Context, req *Request, cfg *config) (*Res
 		}
 	}
 	
-	// Execute HTTP request
-	httpResp, err := c.httpClient.Do(httpReq)
+	// Execute HTTP request (on a discovered instance with WithDiscovery)
+	var httpResp *http.Response
+	if cfg.discovery != nil {
+		httpResp, err = cfg.discovery.do(ctx, c.httpClient, httpReq)
+	} else {
+		httpResp, err = c.httpClient.Do(httpReq)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("http request failed: %w", err)
 	}
@@ -239,7 +255,7 @@ func DoWithData[T any](client *Client, ctx context.Context, req *Request, opts .
 	defer resp.Close()
 	
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+		return nil, resp.statusError()
 	}
 	
 	var result T
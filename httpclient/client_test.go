@@ -3,6 +3,7 @@ package httpclient
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -377,6 +378,42 @@ func TestClient_Do_WithRetry_Success(t *testing.T) {
 	}
 }
 
+func TestClient_Do_WithRetry_RetryAfter(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	
+	client := NewClient()
+	start := time.Now()
+	
+	// Retry-After replaces the base backoff, capped at 10ms
+	resp, err := client.Get(context.Background(), ts.URL,
+		WithRetry(
+			retry.MaxAttempts(2),
+			retry.Backoff(retry.RetryAfterBackoff(retry.ConstantBackoff(time.Hour), 10*time.Millisecond)),
+		),
+	)
+	if err != nil {
+		t.Fatalf("Do() failed: %v", err)
+	}
+	defer resp.Close()
+	
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the capped Retry-After delay, took %v", elapsed)
+	}
+}
+
 func TestClient_Do_DisableRetry(t *testing.T) {
 	attempts := 0
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -615,3 +652,40 @@ func Benchmark_Get_Generic(b *testing.B) {
 	}
 }
 
+
+func TestClient_Do_WithRetryPolicy(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+	
+	registry, err := retry.NewRegistry(retry.RegistryConfig{Policies: map[string]retry.PolicyConfig{
+		"idempotent-read": {MaxAttempts: 2, Backoff: retry.BackoffNone, HTTPStatuses: []int{503}},
+	}})
+	if err != nil {
+		t.Fatalf("NewRegistry failed: %v", err)
+	}
+	client := NewClient(WithRetryRegistry(registry), WithRetryPolicy("idempotent-read"))
+	
+	_, _ = client.Get(context.Background(), ts.URL)
+	if attempts != 2 {
+		t.Errorf("expected 2 attempts, got %d", attempts)
+	}
+	
+	// Reloaded policies apply to the next requests
+	_ = registry.Reload(retry.RegistryConfig{Policies: map[string]retry.PolicyConfig{
+		"idempotent-read": {MaxAttempts: 4, Backoff: retry.BackoffNone, HTTPStatuses: []int{503}},
+	}})
+	attempts = 0
+	_, _ = client.Get(context.Background(), ts.URL)
+	if attempts != 4 {
+		t.Errorf("expected 4 attempts after reload, got %d", attempts)
+	}
+	
+	// Unknown policy
+	if _, err := client.Get(context.Background(), ts.URL, WithRetryPolicy("missing")); !errors.Is(err, retry.ErrPolicyNotFound) {
+		t.Errorf("expected ErrPolicyNotFound, got %v", err)
+	}
+}
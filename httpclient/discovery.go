@@ -0,0 +1,205 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/governance"
+)
+
+// Discovery service discovery of a client (WithDiscovery)
+type Discovery struct {
+	Discovery   governance.ServiceDiscovery  // Discoverer (etcd, consul, static, file...)
+	ServiceName string                       // Discovered service
+	Balancer    governance.LoadBalancer      // Instance selection (default round_robin)
+	Routing     governance.RoutingConfig     // Metadata-aware routing rules (optional)
+	HealthCheck governance.HealthCheckConfig // Active HTTP health checks of the instances (optional, path default /health)
+	Outlier     governance.OutlierConfig     // Ejection of the instances with consecutive 5xx / transport errors (optional)
+}
+
+// discoveryTarget resolves the instance of each request attempt
+// The instance list is discovered once, then kept up to date by Watch.
+type discoveryTarget struct {
+	discovery   governance.ServiceDiscovery
+	serviceName string
+	balancer    governance.LoadBalancer
+	router      *governance.Router
+	outliers    governance.OutlierReporter // Set with outlier detection
+	err         error                      // Invalid configuration, returned by every request
+
+	mu        sync.RWMutex
+	watching  bool
+	instances []*governance.ServiceInstance
+}
+
+func newDiscoveryTarget(d Discovery) *discoveryTarget {
+	target := &discoveryTarget{
+		discovery:   d.Discovery,
+		serviceName: d.ServiceName,
+		balancer:    d.Balancer,
+	}
+	if target.balancer == nil {
+		target.balancer = governance.NewRoundRobinBalancer()
+	}
+	if d.Discovery == nil || d.ServiceName == "" {
+		target.err = fmt.Errorf("discovery: discoverer and service name are required")
+	} else if err := d.Routing.Validate(); err != nil {
+		target.err = fmt.Errorf("discovery %s: %w", d.ServiceName, err)
+	} else if err := d.Outlier.Validate(); err != nil {
+		target.err = fmt.Errorf("discovery %s: %w", d.ServiceName, err)
+	} else if d.Routing.IsEnabled() {
+		target.router = governance.NewRouter(d.Routing)
+	}
+	if target.err != nil || (!d.HealthCheck.Enabled && !d.Outlier.Enabled) {
+		return target
+	}
+
+	// Failed and ejected instances are returned unhealthy by the discoverer
+	var checker *governance.ActiveHealthChecker
+	if d.HealthCheck.Enabled {
+		checker = governance.NewActiveHealthChecker(d.HealthCheck, governance.NewHTTPProber(d.HealthCheck.Path), nil)
+	}
+	var outliers *governance.OutlierDetector
+	if d.Outlier.Enabled {
+		outliers = governance.NewOutlierDetector(d.Outlier)
+	}
+	health := governance.NewHealthAwareDiscovery(d.Discovery, checker, outliers, nil)
+	target.discovery = health
+	if outliers != nil {
+		target.outliers = health
+	}
+	return target
+}
+
+// list returns the instances of the service (Discover first, then the Watch updates)
+func (t *discoveryTarget) list(ctx context.Context) ([]*governance.ServiceInstance, error) {
+	t.mu.RLock()
+	if t.watching {
+		instances := t.instances
+		t.mu.RUnlock()
+		return instances, nil
+	}
+	t.mu.RUnlock()
+
+	instances, err := t.discovery.Discover(ctx, t.serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("discover %s: %w", t.serviceName, err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.watching {
+		return t.instances, nil
+	}
+	t.instances = instances
+	// Without a watch the next request discovers again
+	if watchCh, err := t.discovery.Watch(context.Background(), t.serviceName); err == nil {
+		t.watching = true
+		go t.follow(watchCh)
+	}
+	return instances, nil
+}
+
+// follow applies the Watch updates until the channel is closed
+func (t *discoveryTarget) follow(watchCh <-chan []*governance.ServiceInstance) {
+	for instances := range watchCh {
+		t.mu.Lock()
+		t.instances = instances
+		t.mu.Unlock()
+	}
+	t.mu.Lock()
+	t.watching = false
+	t.mu.Unlock()
+}
+
+// pick selects the instance of a request attempt
+// Routing attributes are the request headers (lowercase), the routing/hash key comes from governance.WithHashKey.
+func (t *discoveryTarget) pick(ctx context.Context, httpReq *http.Request) (*governance.ServiceInstance, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+
+	instances, err := t.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key := governance.HashKeyFromContext(ctx)
+	var candidates []*governance.ServiceInstance
+	if t.router != nil {
+		candidates = t.router.Route(instances, governance.RouteRequest{
+			Attributes: headerAttributes(httpReq.Header),
+			Key:        key,
+		})
+	} else {
+		for _, instance := range instances {
+			if instance.Healthy {
+				candidates = append(candidates, instance)
+			}
+		}
+	}
+
+	var selected *governance.ServiceInstance
+	if keyed, ok := t.balancer.(governance.KeyedBalancer); ok && key != "" {
+		selected = keyed.SelectKey(candidates, key)
+	} else {
+		selected = t.balancer.Select(candidates)
+	}
+	if selected == nil {
+		return nil, fmt.Errorf("discover %s: %w", t.serviceName, governance.ErrNoAvailableInstance)
+	}
+	return selected, nil
+}
+
+// do sends a request attempt to the selected instance (the URL host is replaced by the instance address)
+func (t *discoveryTarget) do(ctx context.Context, client *http.Client, httpReq *http.Request) (*http.Response, error) {
+	instance, err := t.pick(ctx, httpReq)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.URL.Host = instance.GetAddress()
+	httpReq.Host = ""
+
+	feedback, ok := t.balancer.(governance.FeedbackBalancer)
+	if !ok && t.outliers == nil {
+		return client.Do(httpReq)
+	}
+
+	if ok {
+		feedback.Begin(instance)
+	}
+	start := time.Now()
+	httpResp, err := client.Do(httpReq)
+	result := governance.CallResult{Latency: time.Since(start)}
+	canceled := err != nil && ctx.Err() == context.Canceled
+	switch {
+	case canceled:
+		result = governance.CallResult{}
+	case err != nil:
+		result.Err = err
+	case httpResp.StatusCode >= 500 || httpResp.StatusCode == http.StatusTooManyRequests:
+		result.Err = fmt.Errorf("http %d", httpResp.StatusCode)
+	}
+	if ok {
+		feedback.End(instance, result)
+	}
+	if t.outliers != nil && !canceled {
+		t.outliers.Report(instance, err != nil || httpResp.StatusCode >= 500)
+	}
+	return httpResp, err
+}
+
+// headerAttributes returns the request headers as routing attributes
+func headerAttributes(header http.Header) map[string]string {
+	attributes := make(map[string]string, len(header))
+	for key, values := range header {
+		if len(values) > 0 {
+			attributes[strings.ToLower(key)] = values[0]
+		}
+	}
+	return attributes
+}
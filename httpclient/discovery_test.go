@@ -0,0 +1,212 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/governance"
+)
+
+// newNamedServer creates a test server answering its name
+func newNamedServer(name string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(name))
+	}))
+}
+
+func TestClient_Do_WithDiscovery(t *testing.T) {
+	stable := newNamedServer("stable")
+	defer stable.Close()
+	staging := newNamedServer("staging")
+	defer staging.Close()
+
+	discovery, err := governance.NewStaticDiscovery(map[string][]governance.StaticInstance{
+		"user-service": {
+			{Address: strings.TrimPrefix(stable.URL, "http://"), Metadata: map[string]string{"env": "prod"}},
+			{Address: strings.TrimPrefix(staging.URL, "http://"), Metadata: map[string]string{"env": "staging"}},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer discovery.Stop()
+
+	client := NewClient(
+		WithBaseURL("http://user-service"),
+		WithDiscovery(Discovery{
+			Discovery:   discovery,
+			ServiceName: "user-service",
+			Routing: governance.RoutingConfig{Subsets: []governance.SubsetRule{
+				{Header: "X-Env", Value: "staging", Metadata: map[string]string{"env": "staging"}},
+			}},
+		}),
+	)
+
+	// Round robin across both instances
+	seen := map[string]int{}
+	for i := 0; i < 4; i++ {
+		resp, err := client.Get(context.Background(), "/users")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		seen[resp.String()]++
+	}
+	if seen["stable"] != 2 || seen["staging"] != 2 {
+		t.Errorf("expected requests on both instances, got %v", seen)
+	}
+
+	// The header pins the staging subset
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(context.Background(), "/users", WithHeader("X-Env", "staging"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.String() != "staging" {
+			t.Errorf("expected the staging instance, got %q", resp.String())
+		}
+	}
+
+	// The instance list follows the discovery
+	if err := discovery.Update(map[string][]governance.StaticInstance{
+		"user-service": {{Address: strings.TrimPrefix(stable.URL, "http://"), Metadata: map[string]string{"env": "prod"}}},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		_, err = client.Get(context.Background(), "/users", WithHeader("X-Env", "staging"))
+		if err != nil || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !errors.Is(err, governance.ErrNoAvailableInstance) {
+		t.Errorf("expected ErrNoAvailableInstance, got %v", err)
+	}
+}
+
+func TestClient_Do_WithDiscoveryInvalid(t *testing.T) {
+	client := NewClient(WithDiscovery(Discovery{ServiceName: "user-service"}))
+	if _, err := client.Get(context.Background(), "http://user-service/users"); err == nil {
+		t.Error("expected an error without a discoverer")
+	}
+
+	discovery, _ := governance.NewStaticDiscovery(nil, nil)
+	defer discovery.Stop()
+	client = NewClient(WithDiscovery(Discovery{
+		Discovery:   discovery,
+		ServiceName: "user-service",
+		Routing:     governance.RoutingConfig{Canary: governance.CanaryConfig{Version: "v2", Percent: 150}},
+	}))
+	if _, err := client.Get(context.Background(), "http://user-service/users"); err == nil {
+		t.Error("expected an error with an invalid routing configuration")
+	}
+}
+
+func TestClient_Do_WithDiscoveryOutlier(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+	stable := newNamedServer("stable")
+	defer stable.Close()
+
+	discovery, err := governance.NewStaticDiscovery(map[string][]governance.StaticInstance{
+		"user-service": {
+			{Address: strings.TrimPrefix(failing.URL, "http://")},
+			{Address: strings.TrimPrefix(stable.URL, "http://")},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer discovery.Stop()
+
+	client := NewClient(
+		WithBaseURL("http://user-service"),
+		WithDiscovery(Discovery{
+			Discovery:   discovery,
+			ServiceName: "user-service",
+			Outlier:     governance.OutlierConfig{Enabled: true, ConsecutiveErrors: 2},
+		}),
+	)
+
+	// Round robin reaches the failing instance twice, then it is ejected
+	for i := 0; i < 4; i++ {
+		if _, err := client.Get(context.Background(), "/users"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		resp, err := client.Get(context.Background(), "/users")
+		if err == nil && resp.String() == "stable" {
+			resp, err = client.Get(context.Background(), "/users")
+			if err == nil && resp.String() == "stable" {
+				return
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("expected the failing instance to be ejected")
+}
+
+func TestClient_Do_WithDiscoveryHealthCheck(t *testing.T) {
+	unhealthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ping" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("unhealthy"))
+	}))
+	defer unhealthy.Close()
+	stable := newNamedServer("stable")
+	defer stable.Close()
+
+	discovery, err := governance.NewStaticDiscovery(map[string][]governance.StaticInstance{
+		"user-service": {
+			{Address: strings.TrimPrefix(unhealthy.URL, "http://")},
+			{Address: strings.TrimPrefix(stable.URL, "http://")},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer discovery.Stop()
+
+	client := NewClient(
+		WithBaseURL("http://user-service"),
+		WithDiscovery(Discovery{
+			Discovery:   discovery,
+			ServiceName: "user-service",
+			HealthCheck: governance.HealthCheckConfig{Enabled: true, Interval: 1, Path: "/ping"},
+		}),
+	)
+
+	// The first request starts the probes
+	if _, err := client.Get(context.Background(), "/users"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	deadline := time.Now().Add(3 * time.Second)
+	stableCount := 0
+	for time.Now().Before(deadline) && stableCount < 4 {
+		resp, err := client.Get(context.Background(), "/users")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.String() == "stable" {
+			stableCount++
+		} else {
+			stableCount = 0
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	if stableCount < 4 {
+		t.Error("expected the instance failing its health check to be skipped")
+	}
+}
@@ -0,0 +1,47 @@
+package httpclient
+
+import (
+	"context"
+	"sync"
+
+	"github.com/KOMKZ/go-yogan-framework/retry"
+)
+
+// send executes a request, hedged when a hedge policy is configured
+// Hedging sits below the circuit breaker and the retries: they see one call per hedged request,
+// and the cancelled attempts are not recorded as breaker timeouts.
+func (c *Client) send(ctx context.Context, req *Request, cfg *config) (*Response, error) {
+	if cfg.hedgePolicy == nil {
+		return c.doRequest(ctx, req, cfg)
+	}
+
+	// An attempt answering 5xx or 429 fails, so that another attempt can win
+	var mu sync.Mutex
+	var lastStatus *Response
+	resp, err := retry.HedgeWithData(ctx, cfg.hedgePolicy, func(ctx context.Context) (*Response, error) {
+		resp, err := c.doRequest(ctx, req, cfg)
+		if err != nil {
+			return nil, err
+		}
+		if resp.IsServerError() || resp.StatusCode == 429 {
+			mu.Lock()
+			lastStatus = resp
+			mu.Unlock()
+			return nil, resp.statusError()
+		}
+		return resp, nil
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		// No attempt succeeded: answer like an unhedged request
+		mu.Lock()
+		defer mu.Unlock()
+		if lastStatus != nil {
+			return lastStatus, nil
+		}
+		return nil, retry.LastAttemptError(err)
+	}
+	return resp, nil
+}
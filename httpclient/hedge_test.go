@@ -0,0 +1,63 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/retry"
+)
+
+func TestClient_Do_WithHedging(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// The first request is stuck until the client gives up on it
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		w.Write([]byte("hedged"))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithHedging(retry.NewHedgePolicy(retry.HedgeDelay(20 * time.Millisecond))))
+
+	start := time.Now()
+	resp, err := client.Get(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.String() != "hedged" {
+		t.Errorf("expected the hedged response, got %q", resp.String())
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the hedge to answer quickly, took %v", elapsed)
+	}
+	if atomic.LoadInt32(&calls) != 2 {
+		t.Errorf("expected 2 requests, got %d", atomic.LoadInt32(&calls))
+	}
+}
+
+func TestClient_Do_WithHedging_ServerErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithHedging(retry.NewHedgePolicy(retry.HedgeMaxAttempts(2))))
+
+	// Every attempt failing returns the response like an unhedged request
+	resp, err := client.Get(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", resp.StatusCode)
+	}
+}
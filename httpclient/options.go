@@ -26,6 +26,11 @@ type config struct {
 	body       io.Reader
 	retryOpts  []retry.Option
 	retryEnabled bool
+	retrySet     bool // Retry options were given (request level overrides client level)
+	retryPolicy  string // Named policy resolved in retryRegistry at request time
+	retryRegistry *retry.Registry
+	hedgePolicy  *retry.HedgePolicy
+	discovery    *discoveryTarget
 	
 	// Breaker configuration
 	breakerManager  BreakerManager
@@ -179,24 +184,67 @@ func WithBodyString(s string) Option {
 // Set retry options_withRetry
 func WithRetry(opts ...retry.Option) Option {
 	return func(c *config) {
+		c.retrySet = true
 		c.retryEnabled = true
 		c.retryOpts = opts
+		c.retryPolicy = ""
+	}
+}
+
+// WithRetryPolicy uses a named retry policy of the registry (retry.policies configuration)
+// The policy is resolved at each request, so configuration reloads apply to the next requests.
+func WithRetryPolicy(name string) Option {
+	return func(c *config) {
+		c.retrySet = true
+		c.retryEnabled = true
+		c.retryOpts = nil
+		c.retryPolicy = name
+	}
+}
+
+// WithRetryRegistry sets the registry of the named retry policies
+func WithRetryRegistry(registry *retry.Registry) Option {
+	return func(c *config) {
+		c.retryRegistry = registry
 	}
 }
 
 // Use default retry strategy
 func WithRetryDefaults() Option {
 	return func(c *config) {
+		c.retrySet = true
 		c.retryEnabled = true
 		c.retryOpts = retry.HTTPDefaults
+		c.retryPolicy = ""
 	}
 }
 
 // DisableRetry Disable retry
 func DisableRetry() Option {
 	return func(c *config) {
+		c.retrySet = true
 		c.retryEnabled = false
 		c.retryOpts = nil
+		c.retryPolicy = ""
+	}
+}
+
+// WithHedging sends hedged requests: when a request has not answered after the policy delay,
+// the same request is sent again and the first good response wins (only for idempotent requests).
+// Pass the retry BudgetManager to the policy (retry.HedgeBudget) so that hedges and retries share the budget.
+func WithHedging(policy *retry.HedgePolicy) Option {
+	return func(c *config) {
+		c.hedgePolicy = policy
+	}
+}
+
+// WithDiscovery sends the requests to instances of a discovered service
+// Each attempt (retries, hedges) selects an instance and replaces the URL host with its address,
+// e.g., WithBaseURL("http://user-service") + WithDiscovery(...). The routing rules use the request headers.
+func WithDiscovery(d Discovery) Option {
+	target := newDiscoveryTarget(d)
+	return func(c *config) {
+		c.discovery = target
 	}
 }
 
@@ -252,6 +300,10 @@ func (c *config) merge(other *config) *config {
 		queries:         make(url.Values),
 		retryEnabled:    c.retryEnabled,
 		retryOpts:       c.retryOpts,
+		retryPolicy:     c.retryPolicy,
+		retryRegistry:   c.retryRegistry,
+		hedgePolicy:     c.hedgePolicy,
+		discovery:       c.discovery,
 		breakerManager:  c.breakerManager,
 		breakerResource: c.breakerResource,
 		breakerFallback: c.breakerFallback,
@@ -292,9 +344,23 @@ func (c *config) merge(other *config) *config {
 	}
 	
 	// Retry configuration override
-	if other.retryEnabled != c.retryEnabled || len(other.retryOpts) > 0 {
+	if other.retrySet || len(other.retryOpts) > 0 {
 		merged.retryEnabled = other.retryEnabled
 		merged.retryOpts = other.retryOpts
+		merged.retryPolicy = other.retryPolicy
+	}
+	if other.retryRegistry != nil {
+		merged.retryRegistry = other.retryRegistry
+	}
+	
+	// Hedging configuration override
+	if other.hedgePolicy != nil {
+		merged.hedgePolicy = other.hedgePolicy
+	}
+	
+	// Discovery configuration override
+	if other.discovery != nil {
+		merged.discovery = other.discovery
 	}
 	
 	// Breaker configuration override
@@ -165,6 +165,7 @@ func TestConfig_merge_Retry(t *testing.T) {
 	base.retryOpts = []retry.Option{retry.MaxAttempts(3)}
 	
 	other := newConfig()
+	other.retrySet = true
 	other.retryEnabled = false // disable
 	
 	merged := base.merge(other)
@@ -2,9 +2,14 @@ package httpclient
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
+	
+	"github.com/KOMKZ/go-yogan-framework/retry"
 )
 
 // Encapsulate HTTP response
@@ -20,6 +25,59 @@ type Response struct {
 	Attempts int           // Number of retries
 }
 
+// StatusError error of a response with an unexpected HTTP status
+// It exposes the status to the breaker error classification (HTTPStatus)
+// and the Retry-After header to the retry backoff (RetryHint).
+type StatusError struct {
+	StatusCode int
+	Status     string
+	RetryAfter time.Duration // Delay of the Retry-After header (0 when absent)
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Status)
+}
+
+// HTTPStatus returns the HTTP status code
+func (e *StatusError) HTTPStatus() int {
+	return e.StatusCode
+}
+
+// RetryHint returns the delay asked by the Retry-After header
+func (e *StatusError) RetryHint() (retry.RetryHint, bool) {
+	if e.RetryAfter <= 0 {
+		return retry.RetryHint{}, false
+	}
+	return retry.RetryHint{Delay: e.RetryAfter}, true
+}
+
+// statusError builds the StatusError of a response
+func (r *Response) statusError() error {
+	return &StatusError{
+		StatusCode: r.StatusCode,
+		Status:     r.Status,
+		RetryAfter: parseRetryAfter(r.Headers.Get("Retry-After"), time.Now()),
+	}
+}
+
+// parseRetryAfter parses a Retry-After header: delay in seconds or HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
 // Checks if the response is successful (2xx)
 func (r *Response) IsSuccess() bool {
 	return r.StatusCode >= 200 && r.StatusCode < 300
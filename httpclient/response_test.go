@@ -2,11 +2,15 @@ package httpclient
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
 	"testing"
 	"time"
+	
+	"github.com/KOMKZ/go-yogan-framework/retry"
 )
 
 // ============================================================
@@ -293,3 +297,54 @@ func BenchmarkNewResponse(b *testing.B) {
 	}
 }
 
+
+func TestStatusError(t *testing.T) {
+	resp := &Response{StatusCode: 503, Status: "503 Service Unavailable"}
+	err := fmt.Errorf("call failed: %w", resp.statusError())
+
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatal("expected StatusError in chain")
+	}
+	if statusErr.HTTPStatus() != 503 {
+		t.Errorf("expected 503, got %d", statusErr.HTTPStatus())
+	}
+	if statusErr.Error() != "HTTP 503: 503 Service Unavailable" {
+		t.Errorf("unexpected message %q", statusErr.Error())
+	}
+}
+
+func TestStatusError_RetryAfter(t *testing.T) {
+	resp := &Response{StatusCode: 429, Status: "429 Too Many Requests", Headers: http.Header{"Retry-After": []string{"3"}}}
+	err := fmt.Errorf("call failed: %w", resp.statusError())
+
+	hint, ok := retry.HintOf(err)
+	if !ok {
+		t.Fatal("expected a retry hint")
+	}
+	if hint.Delay != 3*time.Second {
+		t.Errorf("expected 3s, got %v", hint.Delay)
+	}
+
+	// No header, no hint
+	if _, ok := retry.HintOf((&Response{StatusCode: 503}).statusError()); ok {
+		t.Error("expected no retry hint")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 15, 4, 0, 0, time.UTC)
+
+	if d := parseRetryAfter("120", now); d != 2*time.Minute {
+		t.Errorf("expected 2m, got %v", d)
+	}
+	if d := parseRetryAfter(now.Add(30*time.Second).Format(http.TimeFormat), now); d != 30*time.Second {
+		t.Errorf("expected 30s, got %v", d)
+	}
+	if d := parseRetryAfter(now.Add(-time.Minute).Format(http.TimeFormat), now); d != 0 {
+		t.Errorf("expected 0 for a past date, got %v", d)
+	}
+	if d := parseRetryAfter("soon", now); d != 0 {
+		t.Errorf("expected 0 for an invalid value, got %v", d)
+	}
+}
@@ -35,6 +35,9 @@ type ConsumerConfigEntry struct {
 
 	// HeartbeatInterval heartbeat interval
 	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval"`
+
+	// RetryPolicy named retry policy (retry.policies)
+	RetryPolicy string `mapstructure:"retry_policy"`
 }
 
 // ConsumersConfig consumer configuration mapping
@@ -93,6 +96,10 @@ func LoadConsumerRunnerConfig(loader ConfigLoader, name string) ConsumerRunnerCo
 		cfg.HeartbeatInterval = loader.GetDuration(prefix + ".heartbeat_interval")
 	}
 
+	if loader.IsSet(prefix + ".retry_policy") {
+		cfg.RetryPolicy = loader.GetString(prefix + ".retry_policy")
+	}
+
 	return cfg
 }
 
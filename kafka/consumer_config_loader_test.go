@@ -93,6 +93,7 @@ func TestLoadConsumerRunnerConfig_Full(t *testing.T) {
 	loader.set("kafka.consumers.demo.auto_commit", true)
 	loader.set("kafka.consumers.demo.auto_commit_interval", 2*time.Second)
 	loader.set("kafka.consumers.demo.max_processing_time", 30*time.Second)
+	loader.set("kafka.consumers.demo.retry_policy", "consumer")
 
 	cfg := LoadConsumerRunnerConfig(loader, "demo")
 
@@ -102,6 +103,7 @@ func TestLoadConsumerRunnerConfig_Full(t *testing.T) {
 	assert.True(t, cfg.AutoCommit)
 	assert.Equal(t, 2*time.Second, cfg.AutoCommitInterval)
 	assert.Equal(t, 30*time.Second, cfg.MaxProcessingTime)
+	assert.Equal(t, "consumer", cfg.RetryPolicy)
 }
 
 func TestLoadConsumerTopics_FromConfig(t *testing.T) {
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/KOMKZ/go-yogan-framework/logger"
+	"github.com/KOMKZ/go-yogan-framework/retry"
 	"go.uber.org/zap"
 )
 
@@ -38,6 +39,9 @@ type ConsumerRunnerConfig struct {
 
 	// HeartbeatInterval heartbeat interval (default 3s)
 	HeartbeatInterval time.Duration
+
+	// RetryPolicy named retry policy of the handler (retry.policies, optional)
+	RetryPolicy string
 }
 
 // Apply default values
@@ -185,7 +189,7 @@ func (r *ConsumerRunner) runWorker(ctx context.Context, workerID int, consumer *
 
 	// Wrap handler, add workerID to log
 	wrappedHandler := func(ctx context.Context, msg *ConsumedMessage) error {
-		return r.handler.Handle(ctx, msg)
+		return r.handle(ctx, msg)
 	}
 
 	err := consumer.Start(ctx, wrappedHandler)
@@ -196,6 +200,34 @@ func (r *ConsumerRunner) runWorker(ctx context.Context, workerID int, consumer *
 	}
 }
 
+// handle handles a message, retried with the named retry policy when configured
+// The policy is resolved for each message, so a reload of retry.policies applies to the next messages.
+func (r *ConsumerRunner) handle(ctx context.Context, msg *ConsumedMessage) error {
+	registry := r.manager.GetRetryRegistry()
+	if r.config.RetryPolicy == "" || registry == nil {
+		return r.handler.Handle(ctx, msg)
+	}
+
+	policy, ok := registry.Get(r.config.RetryPolicy)
+	if !ok {
+		r.logger.WarnCtx(ctx, "retry policy not found, message handled once",
+			zap.String("retry_policy", r.config.RetryPolicy))
+		return r.handler.Handle(ctx, msg)
+	}
+
+	// Each attempt is limited by its own context, so a timed out attempt stops before the next one starts
+	timeout := policy.Config().Timeout
+	return retry.Do(ctx, func() error {
+		attemptCtx := ctx
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			attemptCtx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return r.handler.Handle(attemptCtx, msg)
+	}, append(policy.OptionsWithoutTimeout(), retry.Operation(r.handler.Name()))...)
+}
+
 // Graceful shutdown
 func (r *ConsumerRunner) Stop() error {
 	r.mu.Lock()
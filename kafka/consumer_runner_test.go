@@ -2,10 +2,15 @@ package kafka
 
 import (
 	"context"
+	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/KOMKZ/go-yogan-framework/logger"
+	"github.com/KOMKZ/go-yogan-framework/retry"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConsumerRunnerConfig_ApplyDefaults(t *testing.T) {
@@ -118,3 +123,78 @@ func TestConsumerHandlerWithContext(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "value", receivedCtx.Value("key"))
 }
+
+func TestConsumerRunner_HandleWithRetryPolicy(t *testing.T) {
+	registry, err := retry.NewRegistry(retry.RegistryConfig{Policies: map[string]retry.PolicyConfig{
+		"consumer": {MaxAttempts: 3, Backoff: retry.BackoffNone},
+	}})
+	require.NoError(t, err)
+
+	calls := 0
+	handler := NewConsumerHandlerFunc("test", []string{"topic"}, func(ctx context.Context, msg *ConsumedMessage) error {
+		calls++
+		if calls < 3 {
+			return errors.New("temporary error")
+		}
+		return nil
+	})
+
+	manager := &Manager{}
+	manager.SetRetryRegistry(registry)
+	runner := &ConsumerRunner{
+		manager: manager,
+		handler: handler,
+		config:  ConsumerRunnerConfig{RetryPolicy: "consumer"},
+		logger:  logger.GetLogger("test"),
+	}
+
+	assert.NoError(t, runner.handle(context.Background(), &ConsumedMessage{}))
+	assert.Equal(t, 3, calls)
+
+	// Unknown policy: the message is handled once
+	calls = 0
+	runner.config.RetryPolicy = "missing"
+	assert.Error(t, runner.handle(context.Background(), &ConsumedMessage{}))
+	assert.Equal(t, 1, calls)
+}
+
+func TestConsumerRunner_HandleWithRetryPolicyTimeout(t *testing.T) {
+	registry, err := retry.NewRegistry(retry.RegistryConfig{Policies: map[string]retry.PolicyConfig{
+		"consumer": {MaxAttempts: 3, Backoff: retry.BackoffNone, Timeout: 20 * time.Millisecond},
+	}})
+	require.NoError(t, err)
+
+	var running, maxRunning, calls int32
+	handler := NewConsumerHandlerFunc("test", []string{"topic"}, func(ctx context.Context, msg *ConsumedMessage) error {
+		atomic.AddInt32(&calls, 1)
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			old := atomic.LoadInt32(&maxRunning)
+			if n <= old || atomic.CompareAndSwapInt32(&maxRunning, old, n) {
+				break
+			}
+		}
+		// Slower than the policy timeout, stops with its context
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(200 * time.Millisecond):
+			return nil
+		}
+	})
+
+	manager := &Manager{}
+	manager.SetRetryRegistry(registry)
+	runner := &ConsumerRunner{
+		manager: manager,
+		handler: handler,
+		config:  ConsumerRunnerConfig{RetryPolicy: "consumer"},
+		logger:  logger.GetLogger("test"),
+	}
+
+	assert.Error(t, runner.handle(context.Background(), &ConsumedMessage{}))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	// Attempts never overlap
+	assert.Equal(t, int32(1), atomic.LoadInt32(&maxRunning))
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/IBM/sarama"
 	"github.com/KOMKZ/go-yogan-framework/logger"
+	"github.com/KOMKZ/go-yogan-framework/retry"
 	"go.uber.org/zap"
 )
 
@@ -17,7 +18,8 @@ type Manager struct {
 	config       Config
 	saramaConfig *sarama.Config
 	logger       *logger.CtxZapLogger
-	metrics      *KafkaMetrics // Optional: OTel metrics provider (injected after creation)
+	metrics      *KafkaMetrics   // Optional: OTel metrics provider (injected after creation)
+	retries      *retry.Registry // Optional: named retry policies of the consumers
 
 	client        sarama.Client    // Sarama client
 	producer      Producer
@@ -379,6 +381,16 @@ func (m *Manager) GetMetrics() *KafkaMetrics {
 	return m.metrics
 }
 
+// SetRetryRegistry injects the named retry policies used by consumers with retry_policy.
+func (m *Manager) SetRetryRegistry(registry *retry.Registry) {
+	m.retries = registry
+}
+
+// GetRetryRegistry returns the named retry policies (may be nil).
+func (m *Manager) GetRetryRegistry() *retry.Registry {
+	return m.retries
+}
+
 // Produce sends a message and records metrics.
 // This is a convenience method that wraps the Producer.Send call with metrics recording.
 func (m *Manager) Produce(ctx context.Context, msg *Message) (*ProducerResult, error) {
@@ -0,0 +1,165 @@
+package limiter
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// hammer calls Allow on one resource from many goroutines and returns the number of allowed requests
+func hammer(t *testing.T, algo Algorithm, store Store, cfg ResourceConfig) int64 {
+	const goroutines = 50
+	const callsPerGoroutine = 20
+
+	var allowed int64
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			for j := 0; j < callsPerGoroutine; j++ {
+				resp, err := algo.Allow(context.Background(), store, "hot", 1, cfg)
+				if !assert.NoError(t, err) {
+					return
+				}
+				if resp.Allowed {
+					atomic.AddInt64(&allowed, 1)
+				}
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+	return allowed
+}
+
+func TestAtomic_LimitHoldsUnderContention(t *testing.T) {
+	cases := []struct {
+		name  string
+		algo  Algorithm
+		cfg   ResourceConfig
+		limit int64
+	}{
+		{
+			name:  "token_bucket",
+			algo:  NewTokenBucketAlgorithm(),
+			cfg:   ResourceConfig{Algorithm: string(AlgorithmTokenBucket), Rate: 1, Capacity: 100},
+			limit: 100,
+		},
+		{
+			name:  "sliding_window",
+			algo:  NewSlidingWindowAlgorithm(),
+			cfg:   ResourceConfig{Algorithm: string(AlgorithmSlidingWindow), Limit: 100, WindowSize: time.Minute},
+			limit: 100,
+		},
+		{
+			name:  "gcra",
+			algo:  NewGCRAAlgorithm(),
+			cfg:   ResourceConfig{Algorithm: string(AlgorithmGCRA), Rate: 1, Capacity: 100},
+			limit: 100,
+		},
+		{
+			name:  "fixed_window",
+			algo:  NewFixedWindowAlgorithm(),
+			cfg:   ResourceConfig{Algorithm: string(AlgorithmFixedWindow), Limit: 100, WindowSize: time.Hour},
+			limit: 100,
+		},
+		{
+			name:  "concurrency",
+			algo:  NewConcurrencyAlgorithm(),
+			cfg:   ResourceConfig{Algorithm: string(AlgorithmConcurrency), MaxConcurrency: 10},
+			limit: 10,
+		},
+	}
+
+	for _, tc := range cases {
+		eachStore(t, tc.name, func(t *testing.T, store Store) {
+			allowed := hammer(t, tc.algo, store, tc.cfg)
+			// Rate based algorithms may refill one token while the test runs
+			if tc.name == "token_bucket" || tc.name == "gcra" {
+				assert.InDelta(t, tc.limit, allowed, 1)
+				return
+			}
+			assert.Equal(t, tc.limit, allowed)
+		})
+	}
+}
+
+// eachStore runs fn as a subtest against a fresh Redis (miniredis) store and a fresh memory store
+func eachStore(t *testing.T, name string, fn func(t *testing.T, store Store)) {
+	t.Run("redis/"+name, func(t *testing.T) {
+		_, store := setupMiniRedis(t)
+		fn(t, store)
+	})
+	t.Run("memory/"+name, func(t *testing.T) {
+		store := NewMemoryStore()
+		defer store.Close()
+		fn(t, store)
+	})
+}
+
+func TestAtomic_RedisScriptState(t *testing.T) {
+	mr, store := setupMiniRedis(t)
+	ctx := context.Background()
+
+	algo := NewConcurrencyAlgorithm()
+	cfg := ResourceConfig{MaxConcurrency: 2}
+
+	resp, err := algo.Allow(ctx, store, "api", 2, cfg)
+	require.NoError(t, err)
+	assert.True(t, resp.Allowed)
+	assert.Equal(t, int64(0), resp.Remaining)
+
+	value, err := mr.Get("limiter:limiter:concurrency:api:count")
+	require.NoError(t, err)
+	assert.Equal(t, "2", value)
+
+	resp, err = algo.Allow(ctx, store, "api", 1, cfg)
+	require.NoError(t, err)
+	assert.False(t, resp.Allowed)
+
+	// Release keeps working on the script-managed counter
+	require.NoError(t, algo.(*concurrencyAlgorithm).Release(ctx, store, "api", 1))
+	resp, err = algo.Allow(ctx, store, "api", 1, cfg)
+	require.NoError(t, err)
+	assert.True(t, resp.Allowed)
+
+	// Sliding window entries expire with the window
+	window := NewSlidingWindowAlgorithm()
+	_, err = window.Allow(ctx, store, "api", 1, ResourceConfig{Limit: 5, WindowSize: time.Second})
+	require.NoError(t, err)
+	assert.Greater(t, mr.TTL("limiter:limiter:window:api"), time.Duration(0))
+}
+
+func TestAtomic_RedisScriptTimestampsExact(t *testing.T) {
+	mr, store := setupMiniRedis(t)
+	ctx := context.Background()
+
+	// A TAT in the future with nanosecond digits beyond the exact range of Lua numbers
+	tat := time.Now().Add(5*time.Second).UnixNano()/1000*1000 + 789
+	key := "limiter:limiter:gcra:api:tat"
+	require.NoError(t, mr.Set(key, strconv.FormatInt(tat, 10)))
+
+	algo := NewGCRAAlgorithm()
+	cfg := ResourceConfig{Algorithm: string(AlgorithmGCRA), Rate: 1, Capacity: 10}
+	resp, err := algo.Allow(ctx, store, "api", 1, cfg)
+	require.NoError(t, err)
+	assert.True(t, resp.Allowed)
+
+	value, err := mr.Get(key)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.FormatInt(tat+int64(time.Second), 10), value)
+
+	require.NoError(t, algo.(*gcraAlgorithm).Refund(ctx, store, "api", 1, cfg))
+	value, err = mr.Get(key)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.FormatInt(tat, 10), value)
+}
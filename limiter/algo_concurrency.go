@@ -20,34 +20,28 @@ func (a *concurrencyAlgorithm) Name() string {
 }
 
 // Allow check if the request is permitted
+// Check and increment run atomically: a single Lua script on Redis, under the key lock on the memory store
 func (a *concurrencyAlgorithm) Allow(ctx context.Context, store Store, resource string, n int64, cfg ResourceConfig) (*Response, error) {
 	if n <= 0 {
 		n = 1
 	}
 
 	key := a.concurrencyKey(resource)
-
-	// Get current concurrency count
-	current, err := store.GetInt64(ctx, key)
-	if err != nil && err != ErrKeyNotFound {
-		return nil, fmt.Errorf("get current concurrency failed: %w", err)
-	}
-
-	if err == ErrKeyNotFound {
-		current = 0
+	result, err := runAtomic(ctx, store, key, concurrencyScript,
+		[]string{key},
+		[]interface{}{cfg.MaxConcurrency, n},
+		func() ([]int64, error) {
+			return a.acquire(ctx, store, key, n, cfg)
+		})
+	if err != nil {
+		return nil, err
 	}
 
-	// Check if exceeded limit
-	if current+n <= cfg.MaxConcurrency {
-		// Increase concurrency number
-		newCurrent, err := store.IncrBy(ctx, key, n)
-		if err != nil {
-			return nil, fmt.Errorf("increment concurrency failed: %w", err)
-		}
-
+	allowed, current := result[0] == 1, result[1]
+	if allowed {
 		return &Response{
 			Allowed:   true,
-			Remaining: cfg.MaxConcurrency - newCurrent,
+			Remaining: cfg.MaxConcurrency - current,
 			Limit:     cfg.MaxConcurrency,
 		}, nil
 	}
@@ -59,6 +53,30 @@ func (a *concurrencyAlgorithm) Allow(ctx context.Context, store Store, resource
 	}, nil
 }
 
+// acquire checks and increments the concurrency count with individual store operations (same logic as concurrencyScript)
+// Returns {allowed, current}
+func (a *concurrencyAlgorithm) acquire(ctx context.Context, store Store, key string, n int64, cfg ResourceConfig) ([]int64, error) {
+	current, err := store.GetInt64(ctx, key)
+	if err != nil && err != ErrKeyNotFound {
+		return nil, fmt.Errorf("get current concurrency failed: %w", err)
+	}
+
+	if err == ErrKeyNotFound {
+		current = 0
+	}
+
+	// Check if exceeded limit
+	if current+n > cfg.MaxConcurrency {
+		return []int64{0, current}, nil
+	}
+
+	newCurrent, err := store.IncrBy(ctx, key, n)
+	if err != nil {
+		return nil, fmt.Errorf("increment concurrency failed: %w", err)
+	}
+	return []int64{1, newCurrent}, nil
+}
+
 // Wait for permission to be acquired
 func (a *concurrencyAlgorithm) Wait(ctx context.Context, store Store, resource string, n int64, cfg ResourceConfig, timeout time.Duration) error {
 	if n <= 0 {
@@ -0,0 +1,216 @@
+package limiter
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Calendar periods of fixed window quotas
+const (
+	PeriodHour  = "hour"
+	PeriodDay   = "day"
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+)
+
+// fixedWindowAlgorithm fixed window counter implementation
+// Counts requests per window aligned to the Unix epoch (Limit per WindowSize) or to calendar periods
+// (Limit per Period in Timezone), suited to quota-style limits
+type fixedWindowAlgorithm struct{}
+
+// NewFixedWindowAlgorithm creates the fixed window algorithm
+func NewFixedWindowAlgorithm() Algorithm {
+	return &fixedWindowAlgorithm{}
+}
+
+// Name Returns the algorithm name
+func (a *fixedWindowAlgorithm) Name() string {
+	return string(AlgorithmFixedWindow)
+}
+
+// Allow check if the request is permitted
+func (a *fixedWindowAlgorithm) Allow(ctx context.Context, store Store, resource string, n int64, cfg ResourceConfig) (*Response, error) {
+	if n <= 0 {
+		n = 1
+	}
+
+	now := time.Now()
+	windowEnd := fixedWindowEnd(now, cfg)
+	key := a.counterKey(resource)
+	// The counter is created by the first request of a window and expires with the window
+	ttl := max(windowEnd.Sub(now), time.Millisecond)
+
+	result, err := runAtomic(ctx, store, key, fixedWindowScript,
+		[]string{key},
+		[]interface{}{cfg.Limit, n, ttl.Milliseconds()},
+		func() ([]int64, error) {
+			return a.count(ctx, store, key, n, ttl, cfg)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, count := result[0] == 1, result[1]
+	resp := &Response{
+		Allowed:   allowed,
+		Remaining: maxInt64(0, cfg.Limit-count),
+		Limit:     cfg.Limit,
+		ResetAt:   windowEnd,
+	}
+	if !allowed {
+		resp.RetryAfter = windowEnd.Sub(now)
+	}
+	return resp, nil
+}
+
+// count checks and increments the window counter with individual store operations (same logic as fixedWindowScript)
+// Returns {allowed, count}
+func (a *fixedWindowAlgorithm) count(ctx context.Context, store Store, key string, n int64, ttl time.Duration, cfg ResourceConfig) ([]int64, error) {
+	current, err := store.GetInt64(ctx, key)
+	if err != nil && err != ErrKeyNotFound {
+		return nil, fmt.Errorf("get window count failed: %w", err)
+	}
+
+	if current+n > cfg.Limit {
+		return []int64{0, current}, nil
+	}
+
+	newCurrent, err := store.IncrBy(ctx, key, n)
+	if err != nil {
+		return nil, fmt.Errorf("increment window count failed: %w", err)
+	}
+	if newCurrent == n {
+		if err := store.Expire(ctx, key, ttl); err != nil {
+			return nil, fmt.Errorf("expire window count failed: %w", err)
+		}
+	}
+	return []int64{1, newCurrent}, nil
+}
+
+// Refund decrements the counter of the current window, not below zero
+// Permits taken in a previous window must not be refunded, the counter is shared by all windows
+func (a *fixedWindowAlgorithm) Refund(ctx context.Context, store Store, resource string, n int64, cfg ResourceConfig) error {
+	if n <= 0 {
+		return nil
+	}
+
+	key := a.counterKey(resource)
+	_, err := runAtomic(ctx, store, key, fixedWindowRefundScript,
+		[]string{key},
+		[]interface{}{n},
+		func() ([]int64, error) {
+			current, err := store.GetInt64(ctx, key)
+			if err == ErrKeyNotFound {
+				return []int64{0}, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("get window count failed: %w", err)
+			}
+			count, err := store.DecrBy(ctx, key, min(n, current))
+			if err != nil {
+				return nil, fmt.Errorf("decrement window count failed: %w", err)
+			}
+			return []int64{count}, nil
+		})
+	return err
+}
+
+// Wait for permission acquisition
+func (a *fixedWindowAlgorithm) Wait(ctx context.Context, store Store, resource string, n int64, cfg ResourceConfig, timeout time.Duration) error {
+	if n <= 0 {
+		n = 1
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		resp, err := a.Allow(ctx, store, resource, n, cfg)
+		if err != nil {
+			return err
+		}
+
+		if resp.Allowed {
+			return nil
+		}
+
+		// The quota only comes back with the next window
+		if resp.RetryAfter > time.Until(deadline) {
+			return ErrWaitTimeout
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(resp.RetryAfter):
+			// Continue retrying
+		}
+	}
+}
+
+// GetMetrics retrieves current metrics
+func (a *fixedWindowAlgorithm) GetMetrics(ctx context.Context, store Store, resource string) (*AlgorithmMetrics, error) {
+	count, err := store.GetInt64(ctx, a.counterKey(resource))
+	if err != nil && err != ErrKeyNotFound {
+		return nil, fmt.Errorf("get window count failed: %w", err)
+	}
+
+	return &AlgorithmMetrics{
+		Current: count,
+		Limit:   0, // The limit is part of the resource configuration
+	}, nil
+}
+
+// Reset reset status
+func (a *fixedWindowAlgorithm) Reset(ctx context.Context, store Store, resource string) error {
+	return store.Del(ctx, a.counterKey(resource))
+}
+
+// counterKey returns the counter storage key of the current window
+func (a *fixedWindowAlgorithm) counterKey(resource string) string {
+	return fmt.Sprintf("limiter:fixed:%s:count", resource)
+}
+
+// fixedWindowEnd returns the end of the window containing now
+func fixedWindowEnd(now time.Time, cfg ResourceConfig) time.Time {
+	if cfg.Period == "" {
+		return now.Truncate(cfg.WindowSize).Add(cfg.WindowSize)
+	}
+
+	loc := cfg.location
+	if loc == nil {
+		// Configuration used without Validate
+		if l, err := time.LoadLocation(cfg.Timezone); err == nil {
+			loc = l
+		} else {
+			loc = time.UTC
+		}
+	}
+
+	t := now.In(loc)
+	year, month, day := t.Date()
+	switch cfg.Period {
+	case PeriodHour:
+		return time.Date(year, month, day, t.Hour()+1, 0, 0, 0, loc)
+	case PeriodWeek:
+		// Weeks start on Monday
+		days := (8 - int(t.Weekday())) % 7
+		if days == 0 {
+			days = 7
+		}
+		return time.Date(year, month, day+days, 0, 0, 0, 0, loc)
+	case PeriodMonth:
+		return time.Date(year, month+1, 1, 0, 0, 0, 0, loc)
+	default:
+		return time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+	}
+}
+
+// isValidPeriod checks a calendar period name
+func isValidPeriod(period string) bool {
+	switch period {
+	case PeriodHour, PeriodDay, PeriodWeek, PeriodMonth:
+		return true
+	}
+	return false
+}
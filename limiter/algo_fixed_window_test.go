@@ -0,0 +1,91 @@
+package limiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixedWindow_Allow(t *testing.T) {
+	eachStore(t, "quota", func(t *testing.T, store Store) {
+		algo := NewFixedWindowAlgorithm()
+		ctx := context.Background()
+		cfg := ResourceConfig{Algorithm: string(AlgorithmFixedWindow), Limit: 3, WindowSize: time.Hour}
+
+		for i := 0; i < 3; i++ {
+			resp, err := algo.Allow(ctx, store, "api", 1, cfg)
+			require.NoError(t, err)
+			assert.True(t, resp.Allowed)
+			assert.Equal(t, int64(2-i), resp.Remaining)
+		}
+
+		resp, err := algo.Allow(ctx, store, "api", 1, cfg)
+		require.NoError(t, err)
+		assert.False(t, resp.Allowed)
+		assert.Equal(t, int64(0), resp.Remaining)
+
+		// Reset and retry point at the end of the aligned window
+		windowEnd := time.Now().Truncate(time.Hour).Add(time.Hour)
+		assert.WithinDuration(t, windowEnd, resp.ResetAt, time.Second)
+		assert.InDelta(t, float64(time.Until(windowEnd)), float64(resp.RetryAfter), float64(time.Second))
+
+		metrics, err := algo.GetMetrics(ctx, store, "api")
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), metrics.Current)
+	})
+}
+
+func TestFixedWindow_NewWindow(t *testing.T) {
+	store := NewMemoryStore()
+	defer store.Close()
+
+	algo := NewFixedWindowAlgorithm()
+	ctx := context.Background()
+	cfg := ResourceConfig{Algorithm: string(AlgorithmFixedWindow), Limit: 2, WindowSize: 200 * time.Millisecond}
+
+	resp, err := algo.Allow(ctx, store, "api", 2, cfg)
+	require.NoError(t, err)
+	require.True(t, resp.Allowed)
+
+	resp, err = algo.Allow(ctx, store, "api", 1, cfg)
+	require.NoError(t, err)
+	require.False(t, resp.Allowed)
+
+	// Wait sleeps until the next window starts
+	require.NoError(t, algo.Wait(ctx, store, "api", 1, cfg, time.Second))
+}
+
+func TestFixedWindow_RedisCounterExpires(t *testing.T) {
+	mr, store := setupMiniRedis(t)
+	algo := NewFixedWindowAlgorithm()
+	ctx := context.Background()
+	cfg := ResourceConfig{Algorithm: string(AlgorithmFixedWindow), Limit: 1, WindowSize: time.Hour}
+
+	resp, err := algo.Allow(ctx, store, "api", 1, cfg)
+	require.NoError(t, err)
+	require.True(t, resp.Allowed)
+
+	ttl := mr.TTL("limiter:limiter:fixed:api:count")
+	assert.Greater(t, ttl, time.Duration(0))
+	assert.LessOrEqual(t, ttl, time.Hour)
+
+	mr.FastForward(ttl)
+	resp, err = algo.Allow(ctx, store, "api", 1, cfg)
+	require.NoError(t, err)
+	assert.True(t, resp.Allowed)
+}
+
+func TestFixedWindow_Validate(t *testing.T) {
+	cfg := ResourceConfig{Algorithm: string(AlgorithmFixedWindow), Limit: 10}
+	assert.Error(t, cfg.Validate())
+
+	cfg = ResourceConfig{Algorithm: string(AlgorithmFixedWindow), WindowSize: time.Minute}
+	assert.Error(t, cfg.Validate())
+
+	cfg = ResourceConfig{Algorithm: string(AlgorithmFixedWindow), Limit: 10, WindowSize: time.Minute}
+	assert.NoError(t, cfg.Validate())
+	assert.Equal(t, "fixed_window", GetAlgorithm(cfg, nil).Name())
+}
@@ -0,0 +1,174 @@
+package limiter
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// gcraAlgorithm generic cell rate algorithm implementation
+// Behaves like a token bucket (Rate per second, Capacity as burst) but stores a single timestamp per resource,
+// the theoretical arrival time (TAT), which makes it one GET/SET on Redis
+type gcraAlgorithm struct{}
+
+// NewGCRAAlgorithm creates the GCRA algorithm
+func NewGCRAAlgorithm() Algorithm {
+	return &gcraAlgorithm{}
+}
+
+// Name Returns the algorithm name
+func (a *gcraAlgorithm) Name() string {
+	return string(AlgorithmGCRA)
+}
+
+// Allow check if the request is permitted
+func (a *gcraAlgorithm) Allow(ctx context.Context, store Store, resource string, n int64, cfg ResourceConfig) (*Response, error) {
+	if n <= 0 {
+		n = 1
+	}
+
+	now := time.Now()
+	interval := gcraInterval(cfg)
+	key := a.tatKey(resource)
+
+	result, err := runAtomic(ctx, store, key, gcraScript,
+		[]string{key},
+		[]interface{}{interval.Nanoseconds(), cfg.Capacity, strconv.FormatInt(now.UnixNano(), 10), n},
+		func() ([]int64, error) {
+			return a.update(ctx, store, key, interval, n, cfg, now)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{
+		Allowed:    result[0] == 1,
+		Remaining:  result[1],
+		RetryAfter: time.Duration(result[2]),
+		Limit:      cfg.Capacity,
+		ResetAt:    now.Add(time.Duration(result[3])),
+	}, nil
+}
+
+// update applies GCRA with individual store operations (same logic as gcraScript)
+// Returns {allowed, remaining, retry after (ns), reset after (ns)}
+func (a *gcraAlgorithm) update(ctx context.Context, store Store, key string, interval time.Duration, n int64, cfg ResourceConfig, now time.Time) ([]int64, error) {
+	nowNano := now.UnixNano()
+
+	tat, err := store.GetInt64(ctx, key)
+	if err != nil && err != ErrKeyNotFound {
+		return nil, fmt.Errorf("get tat failed: %w", err)
+	}
+	tat = maxInt64(tat, nowNano)
+
+	burstOffset := cfg.Capacity * int64(interval)
+	newTat := tat + n*int64(interval)
+	allowAt := newTat - burstOffset
+	if nowNano < allowAt {
+		remaining := (nowNano - (tat - burstOffset)) / int64(interval)
+		return []int64{0, maxInt64(remaining, 0), allowAt - nowNano, tat - nowNano}, nil
+	}
+
+	if err := store.SetInt64(ctx, key, newTat, time.Duration(newTat-nowNano)+time.Millisecond); err != nil {
+		return nil, fmt.Errorf("set tat failed: %w", err)
+	}
+	return []int64{1, (nowNano - (newTat - burstOffset)) / int64(interval), 0, newTat - nowNano}, nil
+}
+
+// Refund moves the theoretical arrival time back by N emission intervals, not before now
+func (a *gcraAlgorithm) Refund(ctx context.Context, store Store, resource string, n int64, cfg ResourceConfig) error {
+	if n <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+	interval := gcraInterval(cfg)
+	key := a.tatKey(resource)
+	_, err := runAtomic(ctx, store, key, gcraRefundScript,
+		[]string{key},
+		[]interface{}{interval.Nanoseconds(), strconv.FormatInt(now.UnixNano(), 10), n},
+		func() ([]int64, error) {
+			tat, err := store.GetInt64(ctx, key)
+			if err != nil && err != ErrKeyNotFound {
+				return nil, fmt.Errorf("get tat failed: %w", err)
+			}
+			offset := tat - now.UnixNano()
+			if offset <= 0 {
+				return []int64{0}, nil
+			}
+			offset = maxInt64(offset-n*int64(interval), 0)
+			if err := store.SetInt64(ctx, key, now.UnixNano()+offset, time.Duration(offset)+time.Millisecond); err != nil {
+				return nil, fmt.Errorf("set tat failed: %w", err)
+			}
+			return []int64{offset}, nil
+		})
+	return err
+}
+
+// Wait for permission acquisition
+func (a *gcraAlgorithm) Wait(ctx context.Context, store Store, resource string, n int64, cfg ResourceConfig, timeout time.Duration) error {
+	if n <= 0 {
+		n = 1
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		resp, err := a.Allow(ctx, store, resource, n, cfg)
+		if err != nil {
+			return err
+		}
+
+		if resp.Allowed {
+			return nil
+		}
+
+		// GCRA knows the exact time the request conforms, give up early if it is past the deadline
+		if resp.RetryAfter > time.Until(deadline) {
+			return ErrWaitTimeout
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(resp.RetryAfter):
+			// Continue retrying
+		}
+	}
+}
+
+// GetMetrics retrieves current metrics
+func (a *gcraAlgorithm) GetMetrics(ctx context.Context, store Store, resource string) (*AlgorithmMetrics, error) {
+	tat, err := store.GetInt64(ctx, a.tatKey(resource))
+	if err != nil && err != ErrKeyNotFound {
+		return nil, fmt.Errorf("get tat failed: %w", err)
+	}
+
+	var resetAt time.Time
+	if tat > 0 {
+		resetAt = time.Unix(0, tat)
+	}
+
+	return &AlgorithmMetrics{
+		Current: 0,
+		Limit:   0, // Burst is part of the resource configuration, not of the stored state
+		ResetAt: resetAt,
+	}, nil
+}
+
+// Reset reset status
+func (a *gcraAlgorithm) Reset(ctx context.Context, store Store, resource string) error {
+	return store.Del(ctx, a.tatKey(resource))
+}
+
+// tatKey returns the theoretical arrival time storage key
+func (a *gcraAlgorithm) tatKey(resource string) string {
+	return fmt.Sprintf("limiter:gcra:%s:tat", resource)
+}
+
+// gcraInterval returns the emission interval (time between two requests at the sustained rate)
+func gcraInterval(cfg ResourceConfig) time.Duration {
+	return time.Duration(math.Max(1, float64(time.Second)/float64(cfg.Rate)))
+}
@@ -0,0 +1,95 @@
+package limiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGCRA_Allow(t *testing.T) {
+	eachStore(t, "burst", func(t *testing.T, store Store) {
+		algo := NewGCRAAlgorithm()
+		ctx := context.Background()
+		cfg := ResourceConfig{Algorithm: string(AlgorithmGCRA), Rate: 10, Capacity: 5}
+
+		for i := 0; i < 5; i++ {
+			resp, err := algo.Allow(ctx, store, "api", 1, cfg)
+			require.NoError(t, err)
+			assert.True(t, resp.Allowed, "request %d within burst", i+1)
+			assert.Equal(t, int64(4-i), resp.Remaining)
+			assert.Equal(t, int64(5), resp.Limit)
+		}
+
+		resp, err := algo.Allow(ctx, store, "api", 1, cfg)
+		require.NoError(t, err)
+		assert.False(t, resp.Allowed)
+		assert.Equal(t, int64(0), resp.Remaining)
+		assert.Greater(t, resp.RetryAfter, time.Duration(0))
+		assert.LessOrEqual(t, resp.RetryAfter, 100*time.Millisecond)
+
+		// One emission interval later one more request conforms
+		time.Sleep(resp.RetryAfter)
+		resp, err = algo.Allow(ctx, store, "api", 1, cfg)
+		require.NoError(t, err)
+		assert.True(t, resp.Allowed)
+	})
+}
+
+func TestGCRA_AllowN(t *testing.T) {
+	eachStore(t, "n", func(t *testing.T, store Store) {
+		algo := NewGCRAAlgorithm()
+		ctx := context.Background()
+		cfg := ResourceConfig{Algorithm: string(AlgorithmGCRA), Rate: 1, Capacity: 10}
+
+		resp, err := algo.Allow(ctx, store, "api", 8, cfg)
+		require.NoError(t, err)
+		assert.True(t, resp.Allowed)
+		assert.Equal(t, int64(2), resp.Remaining)
+
+		// Rejected requests do not consume the burst
+		resp, err = algo.Allow(ctx, store, "api", 3, cfg)
+		require.NoError(t, err)
+		assert.False(t, resp.Allowed)
+
+		resp, err = algo.Allow(ctx, store, "api", 2, cfg)
+		require.NoError(t, err)
+		assert.True(t, resp.Allowed)
+	})
+}
+
+func TestGCRA_WaitAndReset(t *testing.T) {
+	eachStore(t, "wait", func(t *testing.T, store Store) {
+		algo := NewGCRAAlgorithm()
+		ctx := context.Background()
+		cfg := ResourceConfig{Algorithm: string(AlgorithmGCRA), Rate: 20, Capacity: 1}
+
+		require.NoError(t, algo.Wait(ctx, store, "api", 1, cfg, time.Second))
+
+		start := time.Now()
+		require.NoError(t, algo.Wait(ctx, store, "api", 1, cfg, time.Second))
+		assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+		// The next conforming time is known, a short timeout fails immediately
+		assert.ErrorIs(t, algo.Wait(ctx, store, "api", 1, cfg, time.Millisecond), ErrWaitTimeout)
+
+		require.NoError(t, algo.Reset(ctx, store, "api"))
+		resp, err := algo.Allow(ctx, store, "api", 1, cfg)
+		require.NoError(t, err)
+		assert.True(t, resp.Allowed)
+	})
+}
+
+func TestGCRA_Validate(t *testing.T) {
+	cfg := ResourceConfig{Algorithm: string(AlgorithmGCRA), Rate: 10}
+	assert.Error(t, cfg.Validate())
+
+	cfg = ResourceConfig{Algorithm: string(AlgorithmGCRA), Capacity: 10}
+	assert.Error(t, cfg.Validate())
+
+	cfg = ResourceConfig{Algorithm: string(AlgorithmGCRA), Rate: 10, Capacity: 10}
+	assert.NoError(t, cfg.Validate())
+	assert.Equal(t, "gcra", GetAlgorithm(cfg, nil).Name())
+}
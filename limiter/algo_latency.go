@@ -0,0 +1,220 @@
+package limiter
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// latencyAlgorithm concurrency limit tuned from observed latency (gradient algorithm, like Netflix concurrency-limits)
+//
+// Every sample window the average latency of completed requests is compared to the minimum latency (min RTT):
+//
+//	gradient = clamp(latency_tolerance × minRTT / sampleRTT, 0.5, 1)
+//	newLimit = limit × gradient + sqrt(limit)
+//
+// While latency stays within the tolerance the limit grows by a queue of sqrt(limit), when the downstream
+// slows down (queueing) it shrinks proportionally. The limit only grows while the inflight requests actually
+// use it, and dropped requests (timeouts, overload errors) back off multiplicatively. The min RTT is measured
+// again every probe_interval so that it follows lasting latency changes.
+//
+// State is kept per instance (latency is local), the store is not used.
+type latencyAlgorithm struct {
+	mu          sync.Mutex
+	initialized bool
+	limit       float64
+	inflight    int64
+	minRTT      time.Duration
+	lastRTT     time.Duration // average latency of the last sample window
+	probeAt     time.Time     // next min RTT measurement
+
+	// current sample window
+	windowStart time.Time
+	sampleSum   time.Duration
+	sampleCount int64
+	maxInflight int64
+	dropped     bool
+}
+
+// NewLatencyAlgorithm creates the latency-based adaptive concurrency algorithm
+func NewLatencyAlgorithm() Algorithm {
+	return &latencyAlgorithm{}
+}
+
+// Name Returns algorithm name
+func (a *latencyAlgorithm) Name() string {
+	return string(AlgorithmLatency)
+}
+
+// Allow check if the request is permitted (inflight requests within the current limit)
+// Permits must be returned through Complete (or Release) when the request ends
+func (a *latencyAlgorithm) Allow(ctx context.Context, store Store, resource string, n int64, cfg ResourceConfig) (*Response, error) {
+	if n <= 0 {
+		n = 1
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.init(cfg, time.Now())
+
+	limit := int64(a.limit)
+	if a.inflight+n > limit {
+		return &Response{
+			Allowed:    false,
+			Remaining:  maxInt64(0, limit-a.inflight),
+			Limit:      limit,
+			RetryAfter: a.lastRTT,
+		}, nil
+	}
+
+	a.inflight += n
+	a.maxInflight = maxInt64(a.maxInflight, a.inflight)
+	return &Response{
+		Allowed:   true,
+		Remaining: limit - a.inflight,
+		Limit:     limit,
+	}, nil
+}
+
+// Wait for permission to be acquired
+func (a *latencyAlgorithm) Wait(ctx context.Context, store Store, resource string, n int64, cfg ResourceConfig, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		resp, err := a.Allow(ctx, store, resource, n, cfg)
+		if err != nil {
+			return err
+		}
+		if resp.Allowed {
+			return nil
+		}
+
+		// Retry after about one request latency
+		waitTime := min64Duration(max(resp.RetryAfter, 5*time.Millisecond), time.Until(deadline))
+		if waitTime <= 0 {
+			return ErrWaitTimeout
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(waitTime):
+		}
+	}
+}
+
+// Complete returns the permits of a completed request and samples its latency
+// Dropped requests (timeouts, overload errors) are not sampled, they make the limit back off
+func (a *latencyAlgorithm) Complete(ctx context.Context, store Store, resource string, n int64, latency time.Duration, dropped bool, cfg ResourceConfig) error {
+	if n <= 0 {
+		n = 1
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := time.Now()
+	a.init(cfg, now)
+	a.inflight = maxInt64(0, a.inflight-n)
+
+	if dropped {
+		a.dropped = true
+	} else if latency > 0 {
+		a.sampleSum += latency
+		a.sampleCount++
+	}
+
+	if now.Sub(a.windowStart) >= cfg.SampleWindow && (a.sampleCount > 0 || a.dropped) {
+		a.update(cfg, now)
+	}
+	return nil
+}
+
+// Release returns the permits of a request without sampling it (e.g., canceled requests)
+func (a *latencyAlgorithm) Release(ctx context.Context, store Store, resource string, n int64) error {
+	if n <= 0 {
+		n = 1
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.inflight = maxInt64(0, a.inflight-n)
+	return nil
+}
+
+// init sets the initial limit on first use. Caller holds mu.
+func (a *latencyAlgorithm) init(cfg ResourceConfig, now time.Time) {
+	if a.initialized {
+		return
+	}
+	a.initialized = true
+	a.limit = float64(cfg.InitLimit)
+	a.windowStart = now
+	a.probeAt = now.Add(cfg.ProbeInterval)
+}
+
+// update computes the new limit at the end of a sample window. Caller holds mu.
+func (a *latencyAlgorithm) update(cfg ResourceConfig, now time.Time) {
+	newLimit := a.limit
+	if a.dropped {
+		newLimit = a.limit * cfg.BackoffRatio
+	} else {
+		sample := a.sampleSum / time.Duration(a.sampleCount)
+		a.lastRTT = sample
+		if a.minRTT == 0 || sample < a.minRTT || !now.Before(a.probeAt) {
+			a.minRTT = sample
+			a.probeAt = now.Add(cfg.ProbeInterval)
+		}
+
+		gradient := math.Max(0.5, math.Min(1, cfg.LatencyTolerance*float64(a.minRTT)/float64(sample)))
+		newLimit = a.limit*gradient + math.Sqrt(a.limit)
+
+		// Application limited: the traffic did not use the limit, do not grow it
+		if float64(a.maxInflight) < a.limit/2 {
+			newLimit = math.Min(newLimit, a.limit)
+		}
+	}
+
+	limit := (1-cfg.Smoothing)*a.limit + cfg.Smoothing*newLimit
+	a.limit = math.Max(float64(cfg.MinLimit), math.Min(float64(cfg.MaxLimit), limit))
+
+	a.windowStart = now
+	a.sampleSum = 0
+	a.sampleCount = 0
+	a.maxInflight = a.inflight
+	a.dropped = false
+}
+
+// CurrentLimit returns the current concurrency limit (0 before the first request)
+func (a *latencyAlgorithm) CurrentLimit() int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return int64(a.limit)
+}
+
+// GetMetrics retrieves current metrics
+func (a *latencyAlgorithm) GetMetrics(ctx context.Context, store Store, resource string) (*AlgorithmMetrics, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	limit := int64(a.limit)
+	return &AlgorithmMetrics{
+		Current:   a.inflight,
+		Limit:     limit,
+		Remaining: maxInt64(0, limit-a.inflight),
+	}, nil
+}
+
+// Reset reset status, the limit starts again from init_limit (inflight requests are kept)
+func (a *latencyAlgorithm) Reset(ctx context.Context, store Store, resource string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.initialized = false
+	a.limit = 0
+	a.minRTT = 0
+	a.lastRTT = 0
+	a.sampleSum = 0
+	a.sampleCount = 0
+	a.maxInflight = 0
+	a.dropped = false
+	return nil
+}
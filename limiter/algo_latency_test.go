@@ -0,0 +1,183 @@
+package limiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// latencyConfig updates the limit on every completed request
+func latencyConfig() ResourceConfig {
+	cfg := ResourceConfig{Algorithm: "latency", MinLimit: 2, MaxLimit: 100, InitLimit: 10, SampleWindow: time.Nanosecond}
+	if err := cfg.Validate(); err != nil {
+		panic(err)
+	}
+	return cfg
+}
+
+// fill acquires n permits and completes them with the given latency
+func fill(t *testing.T, algo Algorithm, cfg ResourceConfig, n int, latency time.Duration, dropped bool) {
+	ctx := context.Background()
+	for i := 0; i < n; i++ {
+		resp, err := algo.Allow(ctx, nil, "api", 1, cfg)
+		require.NoError(t, err)
+		require.True(t, resp.Allowed)
+	}
+	for i := 0; i < n; i++ {
+		require.NoError(t, algo.(Completer).Complete(ctx, nil, "api", 1, latency, dropped, cfg))
+	}
+}
+
+func TestLatencyAlgorithm_InflightLimit(t *testing.T) {
+	algo := NewLatencyAlgorithm()
+	cfg := latencyConfig()
+	ctx := context.Background()
+
+	for i := 0; i < 10; i++ {
+		resp, err := algo.Allow(ctx, nil, "api", 1, cfg)
+		require.NoError(t, err)
+		require.True(t, resp.Allowed)
+	}
+	resp, err := algo.Allow(ctx, nil, "api", 1, cfg)
+	require.NoError(t, err)
+	assert.False(t, resp.Allowed)
+	assert.Equal(t, int64(10), resp.Limit)
+
+	// Released permits are not sampled
+	require.NoError(t, algo.(Releaser).Release(ctx, nil, "api", 1))
+	resp, err = algo.Allow(ctx, nil, "api", 1, cfg)
+	require.NoError(t, err)
+	assert.True(t, resp.Allowed)
+	assert.Equal(t, int64(10), algo.(*latencyAlgorithm).CurrentLimit())
+}
+
+func TestLatencyAlgorithm_GrowsWhileLatencyIsStable(t *testing.T) {
+	algo := NewLatencyAlgorithm()
+	cfg := latencyConfig()
+
+	for i := 0; i < 5; i++ {
+		limit := int(algo.(*latencyAlgorithm).CurrentLimit())
+		if limit == 0 {
+			limit = 10
+		}
+		fill(t, algo, cfg, limit, 10*time.Millisecond, false)
+	}
+	assert.Greater(t, algo.(*latencyAlgorithm).CurrentLimit(), int64(10))
+}
+
+func TestLatencyAlgorithm_ApplicationLimited(t *testing.T) {
+	algo := NewLatencyAlgorithm()
+	cfg := latencyConfig()
+
+	// Low concurrency does not prove that a higher limit is safe
+	for i := 0; i < 20; i++ {
+		fill(t, algo, cfg, 2, 10*time.Millisecond, false)
+	}
+	assert.Equal(t, int64(10), algo.(*latencyAlgorithm).CurrentLimit())
+}
+
+func TestLatencyAlgorithm_ShrinksWhenLatencyRises(t *testing.T) {
+	algo := NewLatencyAlgorithm()
+	cfg := latencyConfig()
+
+	fill(t, algo, cfg, 10, 10*time.Millisecond, false)
+	before := algo.(*latencyAlgorithm).CurrentLimit()
+
+	// Within the tolerance (2x min RTT) the limit does not shrink
+	fill(t, algo, cfg, 10, 15*time.Millisecond, false)
+	assert.GreaterOrEqual(t, algo.(*latencyAlgorithm).CurrentLimit(), before)
+
+	// Queueing downstream: latency far above the min RTT, the limit halves down to its sqrt queue (limit = 4)
+	for i := 0; i < 30; i++ {
+		fill(t, algo, cfg, 2, 100*time.Millisecond, false)
+	}
+	assert.Equal(t, int64(4), algo.(*latencyAlgorithm).CurrentLimit())
+}
+
+func TestLatencyAlgorithm_DroppedBacksOff(t *testing.T) {
+	algo := NewLatencyAlgorithm()
+	cfg := latencyConfig()
+
+	fill(t, algo, cfg, 1, 0, true)
+	// 10 × (0.8 + 0.2 × 0.9)
+	assert.Equal(t, int64(9), algo.(*latencyAlgorithm).CurrentLimit())
+
+	require.NoError(t, algo.Reset(context.Background(), nil, "api"))
+	fill(t, algo, cfg, 1, 10*time.Millisecond, false)
+	assert.Equal(t, int64(10), algo.(*latencyAlgorithm).CurrentLimit())
+}
+
+func TestResourceConfig_ValidateLatency(t *testing.T) {
+	cfg := ResourceConfig{Algorithm: "latency"}
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, int64(1), cfg.MinLimit)
+	assert.Equal(t, int64(1000), cfg.MaxLimit)
+	assert.Equal(t, int64(20), cfg.InitLimit)
+	assert.Equal(t, 2.0, cfg.LatencyTolerance)
+	assert.Equal(t, time.Second, cfg.SampleWindow)
+
+	cfg = ResourceConfig{Algorithm: "latency", MinLimit: 5, MaxLimit: 10, InitLimit: 20}
+	assert.Error(t, cfg.Validate())
+
+	cfg = ResourceConfig{Algorithm: "latency", LatencyTolerance: 0.5}
+	assert.Error(t, cfg.Validate())
+
+	cfg = ResourceConfig{Algorithm: "latency", BackoffRatio: 1}
+	assert.Error(t, cfg.Validate())
+}
+
+func TestManager_CompleteExportsLimitGauge(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test")
+
+	manager, err := NewManager(Config{
+		Enabled:   true,
+		StoreType: "memory",
+		Resources: map[string]ResourceConfig{
+			"api":   {Algorithm: "latency", InitLimit: 1, SampleWindow: time.Nanosecond},
+			"other": {Algorithm: "token_bucket", Rate: 1, Capacity: 1},
+		},
+	})
+	require.NoError(t, err)
+	defer manager.Close()
+
+	metrics := NewOTelMetrics(MetricsConfig{Enabled: true})
+	require.NoError(t, metrics.RegisterMetrics(meter))
+	manager.SetMetrics(metrics)
+
+	ctx := context.Background()
+	allowed, err := manager.Allow(ctx, "api")
+	require.NoError(t, err)
+	require.True(t, allowed)
+	allowed, err = manager.Allow(ctx, "api")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	// Completing returns the permit and samples the latency (limit 1 × 0.8 + 2 × 0.2 = 1.2)
+	require.NoError(t, manager.Complete(ctx, "api", 1, 5*time.Millisecond, false))
+	_, err = manager.Allow(ctx, "other")
+	require.NoError(t, err)
+	assert.NoError(t, manager.Complete(ctx, "other", 1, time.Millisecond, false))
+	assert.Equal(t, map[string]int64{"api": 1}, manager.CurrentLimits())
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	limits := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "limiter_concurrency_limit" {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Gauge[int64]).DataPoints {
+				resource, _ := dp.Attributes.Value("resource")
+				limits[resource.AsString()] = dp.Value
+			}
+		}
+	}
+	assert.Equal(t, map[string]int64{"api": 1}, limits)
+}
@@ -0,0 +1,142 @@
+package limiter
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// leakyBucketAlgorithm leaky bucket (queue) implementation
+// Requests leak out at Rate per second, at most Capacity requests can be queued.
+// Allow only admits a request that can leak immediately (no burst), Wait reserves a slot in the queue
+// and sleeps until it leaks, which smooths traffic to the configured rate.
+// A reserved slot is consumed even if the caller's context is cancelled while waiting.
+type leakyBucketAlgorithm struct{}
+
+// NewLeakyBucketAlgorithm creates the leaky bucket algorithm
+func NewLeakyBucketAlgorithm() Algorithm {
+	return &leakyBucketAlgorithm{}
+}
+
+// Name Returns the algorithm name
+func (a *leakyBucketAlgorithm) Name() string {
+	return string(AlgorithmLeakyBucket)
+}
+
+// Allow check if the request is permitted (without queueing)
+func (a *leakyBucketAlgorithm) Allow(ctx context.Context, store Store, resource string, n int64, cfg ResourceConfig) (*Response, error) {
+	resp, _, err := a.reserve(ctx, store, resource, n, cfg, 0)
+	return resp, err
+}
+
+// Wait queues the request and blocks until it leaks
+func (a *leakyBucketAlgorithm) Wait(ctx context.Context, store Store, resource string, n int64, cfg ResourceConfig, timeout time.Duration) error {
+	resp, delay, err := a.reserve(ctx, store, resource, n, cfg, timeout)
+	if err != nil {
+		return err
+	}
+	if !resp.Allowed {
+		// Queue full or the request would leak after the timeout
+		return ErrWaitTimeout
+	}
+	if delay <= 0 {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(delay):
+		return nil
+	}
+}
+
+// reserve reserves leak slots for n requests that leak within maxDelay
+// Returns the response and the delay until the reserved slots leak
+func (a *leakyBucketAlgorithm) reserve(ctx context.Context, store Store, resource string, n int64, cfg ResourceConfig, maxDelay time.Duration) (*Response, time.Duration, error) {
+	if n <= 0 {
+		n = 1
+	}
+
+	now := time.Now()
+	interval := gcraInterval(cfg)
+	key := a.slotKey(resource)
+
+	result, err := runAtomic(ctx, store, key, leakyBucketScript,
+		[]string{key},
+		[]interface{}{interval.Nanoseconds(), cfg.Capacity, strconv.FormatInt(now.UnixNano(), 10), n, maxDelay.Nanoseconds()},
+		func() ([]int64, error) {
+			return a.enqueue(ctx, store, key, interval, n, cfg, now, maxDelay)
+		})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	allowed, queued, delay := result[0] == 1, result[1], time.Duration(result[2])
+	resp := &Response{
+		Allowed:   allowed,
+		Remaining: maxInt64(0, cfg.Capacity-queued),
+		Limit:     cfg.Capacity,
+	}
+	if allowed {
+		// The queue is empty once the last reserved slot leaked
+		resp.ResetAt = now.Add(delay + time.Duration(n)*interval)
+	} else {
+		resp.ResetAt = now.Add(delay)
+		resp.RetryAfter = max(delay, interval)
+	}
+	return resp, delay, nil
+}
+
+// enqueue reserves leak slots with individual store operations (same logic as leakyBucketScript)
+// Returns {allowed, queued, delay (ns)}
+func (a *leakyBucketAlgorithm) enqueue(ctx context.Context, store Store, key string, interval time.Duration, n int64, cfg ResourceConfig, now time.Time, maxDelay time.Duration) ([]int64, error) {
+	nowNano := now.UnixNano()
+
+	nextSlot, err := store.GetInt64(ctx, key)
+	if err != nil && err != ErrKeyNotFound {
+		return nil, fmt.Errorf("get next slot failed: %w", err)
+	}
+	nextSlot = maxInt64(nextSlot, nowNano)
+
+	delay := nextSlot - nowNano
+	queued := (delay + int64(interval) - 1) / int64(interval)
+	if queued+n > cfg.Capacity || delay > int64(maxDelay) {
+		return []int64{0, queued, delay}, nil
+	}
+
+	newSlot := nextSlot + n*int64(interval)
+	if err := store.SetInt64(ctx, key, newSlot, time.Duration(newSlot-nowNano)+time.Millisecond); err != nil {
+		return nil, fmt.Errorf("set next slot failed: %w", err)
+	}
+	return []int64{1, queued + n, delay}, nil
+}
+
+// GetMetrics retrieves current metrics
+func (a *leakyBucketAlgorithm) GetMetrics(ctx context.Context, store Store, resource string) (*AlgorithmMetrics, error) {
+	nextSlot, err := store.GetInt64(ctx, a.slotKey(resource))
+	if err != nil && err != ErrKeyNotFound {
+		return nil, fmt.Errorf("get next slot failed: %w", err)
+	}
+
+	var resetAt time.Time
+	if nextSlot > 0 {
+		resetAt = time.Unix(0, nextSlot)
+	}
+
+	return &AlgorithmMetrics{
+		Limit:   0, // Capacity is part of the resource configuration
+		ResetAt: resetAt,
+	}, nil
+}
+
+// Reset reset status
+func (a *leakyBucketAlgorithm) Reset(ctx context.Context, store Store, resource string) error {
+	return store.Del(ctx, a.slotKey(resource))
+}
+
+// slotKey returns the storage key of the next free leak slot
+func (a *leakyBucketAlgorithm) slotKey(resource string) string {
+	return fmt.Sprintf("limiter:leaky:%s:next", resource)
+}
@@ -0,0 +1,103 @@
+package limiter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeakyBucket_AllowNoBurst(t *testing.T) {
+	eachStore(t, "allow", func(t *testing.T, store Store) {
+		algo := NewLeakyBucketAlgorithm()
+		ctx := context.Background()
+		cfg := ResourceConfig{Algorithm: string(AlgorithmLeakyBucket), Rate: 10, Capacity: 5}
+
+		resp, err := algo.Allow(ctx, store, "api", 1, cfg)
+		require.NoError(t, err)
+		assert.True(t, resp.Allowed)
+
+		// The next request must wait for the previous one to leak
+		resp, err = algo.Allow(ctx, store, "api", 1, cfg)
+		require.NoError(t, err)
+		assert.False(t, resp.Allowed)
+		assert.Greater(t, resp.RetryAfter, time.Duration(0))
+		assert.LessOrEqual(t, resp.RetryAfter, 100*time.Millisecond)
+
+		time.Sleep(resp.RetryAfter)
+		resp, err = algo.Allow(ctx, store, "api", 1, cfg)
+		require.NoError(t, err)
+		assert.True(t, resp.Allowed)
+	})
+}
+
+func TestLeakyBucket_WaitSmoothsTraffic(t *testing.T) {
+	eachStore(t, "wait", func(t *testing.T, store Store) {
+		algo := NewLeakyBucketAlgorithm()
+		ctx := context.Background()
+		cfg := ResourceConfig{Algorithm: string(AlgorithmLeakyBucket), Rate: 20, Capacity: 10}
+
+		// 5 simultaneous requests leak one every 50ms
+		start := time.Now()
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		var done []time.Duration
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if assert.NoError(t, algo.Wait(ctx, store, "api", 1, cfg, time.Second)) {
+					mu.Lock()
+					done = append(done, time.Since(start))
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		require.Len(t, done, 5)
+		var last time.Duration
+		for _, d := range done {
+			last = max(last, d)
+		}
+		assert.GreaterOrEqual(t, last, 190*time.Millisecond)
+	})
+}
+
+func TestLeakyBucket_QueueFull(t *testing.T) {
+	eachStore(t, "full", func(t *testing.T, store Store) {
+		algo := NewLeakyBucketAlgorithm()
+		ctx := context.Background()
+		cfg := ResourceConfig{Algorithm: string(AlgorithmLeakyBucket), Rate: 1, Capacity: 2}
+
+		resp, delay, err := algo.(*leakyBucketAlgorithm).reserve(ctx, store, "api", 2, cfg, time.Minute)
+		require.NoError(t, err)
+		assert.True(t, resp.Allowed)
+		assert.Equal(t, time.Duration(0), delay)
+		assert.Equal(t, int64(0), resp.Remaining)
+
+		// Queue holds 2 requests, a third is rejected right away
+		assert.ErrorIs(t, algo.Wait(ctx, store, "api", 1, cfg, time.Minute), ErrWaitTimeout)
+
+		// A request that would leak after the timeout is rejected without waiting
+		require.NoError(t, algo.Reset(ctx, store, "api"))
+		_, _, err = algo.(*leakyBucketAlgorithm).reserve(ctx, store, "api", 1, cfg, time.Minute)
+		require.NoError(t, err)
+		start := time.Now()
+		assert.ErrorIs(t, algo.Wait(ctx, store, "api", 1, cfg, 100*time.Millisecond), ErrWaitTimeout)
+		assert.Less(t, time.Since(start), 50*time.Millisecond)
+	})
+}
+
+func TestLeakyBucket_Validate(t *testing.T) {
+	cfg := ResourceConfig{Algorithm: string(AlgorithmLeakyBucket), Rate: 10}
+	assert.Error(t, cfg.Validate())
+
+	cfg = ResourceConfig{Algorithm: string(AlgorithmLeakyBucket), Rate: 10, Capacity: 100}
+	assert.NoError(t, cfg.Validate())
+	assert.Equal(t, time.Second, cfg.Timeout)
+	assert.Equal(t, "leaky_bucket", GetAlgorithm(cfg, nil).Name())
+}
@@ -3,6 +3,8 @@ package limiter
 import (
 	"context"
 	"fmt"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
@@ -22,6 +24,7 @@ func (a *slidingWindowAlgorithm) Name() string {
 }
 
 // Allow check if the request is permitted
+// Cleanup, count and record run atomically: a single Lua script on Redis, under the key lock on the memory store
 func (a *slidingWindowAlgorithm) Allow(ctx context.Context, store Store, resource string, n int64, cfg ResourceConfig) (*Response, error) {
 	if n <= 0 {
 		n = 1
@@ -30,35 +33,28 @@ func (a *slidingWindowAlgorithm) Allow(ctx context.Context, store Store, resourc
 	now := time.Now()
 	key := a.windowKey(resource)
 
-	// Delete data outside the window (delete data < windowStart)
-	windowStart := now.Add(-cfg.WindowSize)
-	minScore := float64(windowStart.UnixNano())
-	// Delete data with scores less than minScore (excluding minScore itself)
-	if err := store.ZRemRangeByScore(ctx, key, 0, minScore-1); err != nil {
-		return nil, fmt.Errorf("remove old entries failed: %w", err)
-	}
-
-	// Count the number of requests within the current window (from windowStart to now, inclusive)
+	// The window covers [windowStart, now], older entries are deleted
+	minScore := float64(now.Add(-cfg.WindowSize).UnixNano())
 	maxScore := float64(now.UnixNano())
-	count, err := store.ZCount(ctx, key, minScore, maxScore)
+	// UUID prefix keeps members unique (avoid conflicts within the same nanosecond)
+	member := uuid.New().String()
+
+	result, err := runAtomic(ctx, store, key, slidingWindowScript,
+		[]string{key},
+		[]interface{}{
+			strconv.FormatFloat(minScore, 'f', 0, 64),
+			strconv.FormatFloat(maxScore, 'f', 0, 64),
+			cfg.Limit, n, member, cfg.WindowSize.Milliseconds() + 1,
+		},
+		func() ([]int64, error) {
+			return a.record(ctx, store, key, minScore, maxScore, member, n, cfg)
+		})
 	if err != nil {
-		return nil, fmt.Errorf("count requests failed: %w", err)
+		return nil, err
 	}
 
-	// Check if exceeded limit
-	if count+n <= cfg.Limit {
-		// Add new request
-		for i := int64(0); i < n; i++ {
-			// Slightly increase the score for each request to ensure uniqueness
-			scoreTime := now.Add(time.Duration(i) * time.Nanosecond)
-			score := float64(scoreTime.UnixNano())
-			// Use UUID to ensure member uniqueness (avoid conflicts within the same nanosecond)
-			member := uuid.New().String()
-			if err := store.ZAdd(ctx, key, score, member); err != nil {
-				return nil, fmt.Errorf("add request failed: %w", err)
-			}
-		}
-
+	allowed, count := result[0] == 1, result[1]
+	if allowed {
 		return &Response{
 			Allowed:   true,
 			Remaining: cfg.Limit - count - n,
@@ -79,6 +75,34 @@ func (a *slidingWindowAlgorithm) Allow(ctx context.Context, store Store, resourc
 	}, nil
 }
 
+// record cleans up, counts and records requests with individual store operations (same logic as slidingWindowScript)
+// Returns {allowed, count before this request}
+func (a *slidingWindowAlgorithm) record(ctx context.Context, store Store, key string, minScore, maxScore float64, member string, n int64, cfg ResourceConfig) ([]int64, error) {
+	// Delete data with scores less than minScore (excluding minScore itself)
+	if err := store.ZRemRangeByScore(ctx, key, 0, minScore-1); err != nil {
+		return nil, fmt.Errorf("remove old entries failed: %w", err)
+	}
+
+	// Count the number of requests within the current window
+	// Entries recorded by concurrent callers may carry a later score than maxScore, so the count is unbounded above
+	count, err := store.ZCount(ctx, key, minScore, math.Inf(1))
+	if err != nil {
+		return nil, fmt.Errorf("count requests failed: %w", err)
+	}
+
+	// Check if exceeded limit
+	if count+n > cfg.Limit {
+		return []int64{0, count}, nil
+	}
+
+	for i := int64(1); i <= n; i++ {
+		if err := store.ZAdd(ctx, key, maxScore, member+":"+strconv.FormatInt(i, 10)); err != nil {
+			return nil, fmt.Errorf("add request failed: %w", err)
+		}
+	}
+	return []int64{1, count}, nil
+}
+
 // Wait for permission acquisition
 func (a *slidingWindowAlgorithm) Wait(ctx context.Context, store Store, resource string, n int64, cfg ResourceConfig, timeout time.Duration) error {
 	if n <= 0 {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strconv"
 	"time"
 )
 
@@ -21,6 +22,7 @@ func (a *tokenBucketAlgorithm) Name() string {
 }
 
 // Allow check if the request is permitted
+// Refill and take run atomically: a single Lua script on Redis, under the key lock on the memory store
 func (a *tokenBucketAlgorithm) Allow(ctx context.Context, store Store, resource string, n int64, cfg ResourceConfig) (*Response, error) {
 	if n <= 0 {
 		n = 1
@@ -28,10 +30,51 @@ func (a *tokenBucketAlgorithm) Allow(ctx context.Context, store Store, resource
 
 	now := time.Now()
 
-	// Generate storage key
+	// Use configured InitTokens, if not set use Capacity (default full bucket)
+	initTokens := cfg.InitTokens
+	if initTokens == 0 {
+		initTokens = cfg.Capacity
+	}
+
 	tokensKey := a.tokensKey(resource)
 	lastRefillKey := a.lastRefillKey(resource)
+	result, err := runAtomic(ctx, store, tokensKey, tokenBucketScript,
+		[]string{tokensKey, lastRefillKey},
+		[]interface{}{cfg.Rate, cfg.Capacity, initTokens, strconv.FormatInt(now.UnixNano(), 10), n},
+		func() ([]int64, error) {
+			return a.take(ctx, store, tokensKey, lastRefillKey, n, initTokens, cfg, now)
+		})
+	if err != nil {
+		return nil, err
+	}
 
+	allowed, tokens := result[0] == 1, result[1]
+	resetAt := now.Add(time.Duration(float64(cfg.Capacity-tokens) / float64(cfg.Rate) * float64(time.Second)))
+	if allowed {
+		return &Response{
+			Allowed:   true,
+			Remaining: tokens,
+			Limit:     cfg.Capacity,
+			ResetAt:   resetAt,
+		}, nil
+	}
+
+	// Calculate retry time
+	tokensNeeded := n - tokens
+	retryAfter := time.Duration(float64(tokensNeeded) / float64(cfg.Rate) * float64(time.Second))
+
+	return &Response{
+		Allowed:    false,
+		RetryAfter: retryAfter,
+		Remaining:  tokens,
+		Limit:      cfg.Capacity,
+		ResetAt:    resetAt,
+	}, nil
+}
+
+// take refills and takes tokens with individual store operations (same logic as tokenBucketScript)
+// Returns {allowed, tokens}
+func (a *tokenBucketAlgorithm) take(ctx context.Context, store Store, tokensKey, lastRefillKey string, n, initTokens int64, cfg ResourceConfig, now time.Time) ([]int64, error) {
 	// Get current token count and last refill time
 	tokens, err := store.GetInt64(ctx, tokensKey)
 	if err != nil && err != ErrKeyNotFound {
@@ -43,67 +86,62 @@ func (a *tokenBucketAlgorithm) Allow(ctx context.Context, store Store, resource
 		return nil, fmt.Errorf("get last refill time failed: %w", err2)
 	}
 
-	// If it is the first visit (both keys do not exist), initialize
-	if err == ErrKeyNotFound && err2 == ErrKeyNotFound {
-		// Use configured InitTokens, if not set use Capacity
-		tokens = cfg.InitTokens
-		if cfg.InitTokens == 0 {
-			tokens = cfg.Capacity // Default full bucket
-		}
-		lastRefillNano = now.UnixNano()
-
-		// Initialize storage
-		if err := store.SetInt64(ctx, tokensKey, tokens, 0); err != nil {
-			return nil, fmt.Errorf("init tokens failed: %w", err)
-		}
-		if err := store.SetInt64(ctx, lastRefillKey, lastRefillNano, 0); err != nil {
+	if err == ErrKeyNotFound || err2 == ErrKeyNotFound {
+		// First visit, initialize
+		tokens = initTokens
+		if err := store.SetInt64(ctx, lastRefillKey, now.UnixNano(), 0); err != nil {
 			return nil, fmt.Errorf("init last refill failed: %w", err)
 		}
 	} else {
-		// Calculate the number of new tokens
-		lastRefill := time.Unix(0, lastRefillNano)
-		elapsed := now.Sub(lastRefill)
-		newTokens := int64(float64(cfg.Rate) * elapsed.Seconds())
-		tokens = min(tokens+newTokens, cfg.Capacity)
+		// Calculate the number of new tokens, the refill time only advances when tokens were added
+		// so frequent calls cannot starve a slow refill
+		elapsed := now.Sub(time.Unix(0, lastRefillNano))
+		if newTokens := int64(float64(cfg.Rate) * elapsed.Seconds()); newTokens > 0 {
+			tokens = min(tokens+newTokens, cfg.Capacity)
+			if err := store.SetInt64(ctx, lastRefillKey, now.UnixNano(), 0); err != nil {
+				return nil, fmt.Errorf("set last refill failed: %w", err)
+			}
+		}
 	}
 
 	// Check if there are enough tokens
+	var allowed int64
 	if tokens >= n {
-		// deduct token
 		tokens -= n
-
-		// Update storage
-		if err := store.SetInt64(ctx, tokensKey, tokens, 0); err != nil {
-			return nil, fmt.Errorf("set tokens failed: %w", err)
-		}
-		if err := store.SetInt64(ctx, lastRefillKey, now.UnixNano(), 0); err != nil {
-			return nil, fmt.Errorf("set last refill failed: %w", err)
-		}
-
-		return &Response{
-			Allowed:   true,
-			Remaining: tokens,
-			Limit:     cfg.Capacity,
-			ResetAt:   now.Add(time.Duration(float64(cfg.Capacity-tokens) / float64(cfg.Rate) * float64(time.Second))),
-		}, nil
+		allowed = 1
 	}
 
-	// Token insufficient, update lastRefill time (to avoid recalculation next time)
-	if err := store.SetInt64(ctx, lastRefillKey, now.UnixNano(), 0); err != nil {
-		return nil, fmt.Errorf("set last refill failed: %w", err)
+	if err := store.SetInt64(ctx, tokensKey, tokens, 0); err != nil {
+		return nil, fmt.Errorf("set tokens failed: %w", err)
 	}
+	return []int64{allowed, tokens}, nil
+}
 
-	// Calculate retry time
-	tokensNeeded := n - tokens
-	retryAfter := time.Duration(float64(tokensNeeded) / float64(cfg.Rate) * float64(time.Second))
+// Refund gives unused tokens back, capped at the capacity
+func (a *tokenBucketAlgorithm) Refund(ctx context.Context, store Store, resource string, n int64, cfg ResourceConfig) error {
+	if n <= 0 {
+		return nil
+	}
 
-	return &Response{
-		Allowed:    false,
-		RetryAfter: retryAfter,
-		Remaining:  tokens,
-		Limit:      cfg.Capacity,
-		ResetAt:    now.Add(time.Duration(float64(cfg.Capacity-tokens) / float64(cfg.Rate) * float64(time.Second))),
-	}, nil
+	tokensKey := a.tokensKey(resource)
+	_, err := runAtomic(ctx, store, tokensKey, tokenBucketRefundScript,
+		[]string{tokensKey},
+		[]interface{}{n, cfg.Capacity},
+		func() ([]int64, error) {
+			tokens, err := store.GetInt64(ctx, tokensKey)
+			if err == ErrKeyNotFound {
+				return []int64{0}, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("get tokens failed: %w", err)
+			}
+			tokens = min(tokens+n, cfg.Capacity)
+			if err := store.SetInt64(ctx, tokensKey, tokens, 0); err != nil {
+				return nil, fmt.Errorf("set tokens failed: %w", err)
+			}
+			return []int64{tokens}, nil
+		})
+	return err
 }
 
 // Wait for permission acquisition
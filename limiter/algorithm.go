@@ -23,6 +23,27 @@ type Algorithm interface {
 	Name() string
 }
 
+// Releaser algorithms holding permits until the requests complete (e.g., concurrency)
+type Releaser interface {
+	// Release returns N permits of a resource
+	Release(ctx context.Context, store Store, resource string, n int64) error
+}
+
+// Completer algorithms learning from completed requests (latency-based concurrency)
+// Completer algorithms also implement Releaser for requests that end without a usable sample
+type Completer interface {
+	// Complete returns N permits of a completed request with its latency
+	// dropped marks requests that failed from overload (timeouts, 503), they make the limit back off
+	Complete(ctx context.Context, store Store, resource string, n int64, latency time.Duration, dropped bool, cfg ResourceConfig) error
+}
+
+// Refunder algorithms that can take back unused permits (token bucket, GCRA, fixed window)
+// Used by the hybrid store to return the unused part of a lease
+type Refunder interface {
+	// Refund gives N unused permits of a resource back
+	Refund(ctx context.Context, store Store, resource string, n int64, cfg ResourceConfig) error
+}
+
 // AlgorithmMetrics algorithm metrics
 type AlgorithmMetrics struct {
 	Current   int64     // Current value (concurrency count/token usage/request count)
@@ -46,6 +67,18 @@ const (
 
 	// Algorithm Adaptive for rate limiting
 	AlgorithmAdaptive AlgorithmType = "adaptive"
+
+	// AlgorithmGCRA generic cell rate algorithm (token bucket semantics, single stored timestamp)
+	AlgorithmGCRA AlgorithmType = "gcra"
+
+	// AlgorithmFixedWindow fixed window counter (quota-style limits)
+	AlgorithmFixedWindow AlgorithmType = "fixed_window"
+
+	// AlgorithmLeakyBucket leaky bucket (queues and smooths traffic through Wait)
+	AlgorithmLeakyBucket AlgorithmType = "leaky_bucket"
+
+	// AlgorithmLatency concurrency limit tuned from observed latency (gradient, min RTT vs sampled RTT)
+	AlgorithmLatency AlgorithmType = "latency"
 )
 
 // GetAlgorithm obtains an algorithm instance according to the configuration
@@ -59,6 +92,14 @@ func GetAlgorithm(cfg ResourceConfig, provider AdaptiveProvider) Algorithm {
 		return NewConcurrencyAlgorithm()
 	case AlgorithmAdaptive:
 		return NewAdaptiveAlgorithm(provider)
+	case AlgorithmGCRA:
+		return NewGCRAAlgorithm()
+	case AlgorithmFixedWindow:
+		return NewFixedWindowAlgorithm()
+	case AlgorithmLeakyBucket:
+		return NewLeakyBucketAlgorithm()
+	case AlgorithmLatency:
+		return NewLatencyAlgorithm()
 	default:
 		// Use token bucket by default
 		return NewTokenBucketAlgorithm()
@@ -9,12 +9,15 @@ type Config struct {
 	// Enabled whether to enable rate limiting (false means direct passthrough)
 	Enabled bool `mapstructure:"enabled"`
 
-	// StoreType storage type: memory, redis
+	// StoreType storage type: memory, redis, hybrid
 	StoreType string `mapstructure:"store_type"`
 
-	// Redis configuration (required when StoreType is redis)
+	// Redis configuration (required when StoreType is redis or hybrid)
 	Redis RedisInstanceConfig `mapstructure:"redis"`
 
+	// Hybrid local token lease configuration (when StoreType is hybrid)
+	Hybrid HybridConfig `mapstructure:"hybrid"`
+
 	// EventBusBuffer event bus buffer size
 	EventBusBuffer int `mapstructure:"event_bus_buffer"`
 
@@ -25,28 +28,41 @@ type Config struct {
 	// SkipPaths list of paths to bypass rate limiting (for middleware)
 	SkipPaths []string `mapstructure:"skip_paths"`
 
+	// Headers rate limit response headers (for middleware)
+	// Optional values: ietf (RateLimit-*), x (X-RateLimit-*), none (default is ietf)
+	Headers string `mapstructure:"headers"`
+
 	// Default resource configuration (if a valid default is set, it will be automatically applied to unconfigured resources)
 	Default ResourceConfig `mapstructure:"default"`
 
 	// Resources configuration level (overrides Default)
 	Resources map[string]ResourceConfig `mapstructure:"resources"`
+
+	// Policy ordered multi-dimension rules (for middleware), evaluated together instead of the key_func resource
+	// Example: a global route limit, per-tenant and per-user limits, daily and monthly quotas per API key
+	Policy []PolicyRule `mapstructure:"policy"`
 }
 
 // ResourceConfig resource-level configuration
 type ResourceConfig struct {
-	// Algorithm rate_limiting: token_bucket, sliding_window, concurrency, adaptive
+	// Algorithm rate_limiting: token_bucket, sliding_window, concurrency, adaptive, gcra, fixed_window, leaky_bucket, latency
 	Algorithm string `mapstructure:"algorithm"`
 
-	// Token bucket configuration
+	// Token bucket configuration (also GCRA: rate and burst, leaky bucket: leak rate and queue size)
 	Rate       int64 `mapstructure:"rate"`        // token generation rate (per second)
 	Capacity   int64 `mapstructure:"capacity"`    // bucket capacity
 	InitTokens int64 `mapstructure:"init_tokens"` // Initial token count
 
-	// Sliding window configuration
+	// Sliding window configuration (also fixed window)
 	Limit      int64         `mapstructure:"limit"`       // maximum request count within window
 	WindowSize time.Duration `mapstructure:"window_size"` // window size
 	BucketSize time.Duration `mapstructure:"bucket_size"` // bucket size
 
+	// Fixed window calendar periods (quotas), used instead of window_size when set
+	Period   string `mapstructure:"period"`   // hour, day, week (starts Monday), month
+	Timezone string `mapstructure:"timezone"` // IANA time zone of period boundaries (default UTC)
+	location *time.Location
+
 	// Concurrent rate limiting configuration
 	MaxConcurrency int64         `mapstructure:"max_concurrency"` // maximum concurrency limit
 	Timeout        time.Duration `mapstructure:"timeout"`         // timeout waiting
@@ -58,6 +74,14 @@ type ResourceConfig struct {
 	TargetMemory   float64       `mapstructure:"target_memory"`   // Target memory utilization rate
 	TargetLoad     float64       `mapstructure:"target_load"`     // target system load
 	AdjustInterval time.Duration `mapstructure:"adjust_interval"` // Adjust interval
+
+	// Latency-based concurrency configuration (also min_limit, max_limit and timeout)
+	InitLimit        int64         `mapstructure:"init_limit"`        // initial concurrency limit
+	LatencyTolerance float64       `mapstructure:"latency_tolerance"` // sampled/min latency ratio tolerated before the limit shrinks
+	SampleWindow     time.Duration `mapstructure:"sample_window"`     // latency sample window (limit update interval)
+	ProbeInterval    time.Duration `mapstructure:"probe_interval"`    // min latency measurement interval
+	Smoothing        float64       `mapstructure:"smoothing"`         // share of the new limit applied per update (0-1]
+	BackoffRatio     float64       `mapstructure:"backoff_ratio"`     // limit factor after dropped requests (0-1)
 }
 
 // RedisInstanceConfig Redis instance reference configuration (reusing kernel redis component)
@@ -66,6 +90,55 @@ type RedisInstanceConfig struct {
 	KeyPrefix string `mapstructure:"key_prefix"` // Redis key prefix (default "limiter:")
 }
 
+// HybridConfig local token lease configuration of the hybrid store
+//
+// Each instance leases a batch of permits from Redis and serves requests from it locally, refilling
+// in the background when the lease runs low. Applies to token_bucket, gcra and fixed_window resources,
+// other algorithms use Redis directly.
+//
+// Leased permits are taken from the global limit when they are leased, not when they are served. Each
+// instance holds up to one lease (at most MaxLease permits) for at most LeaseTTL, and can serve it after
+// Redis has already refilled or reset, so the global limit may be overshot by up to one lease per
+// instance (instances × MaxLease requests) within one LeaseTTL.
+//
+// Remaining of responses served from a lease is what is left of the local lease, not the global
+// remaining count; rejected responses report 0 with the ResetAt and RetryAfter of Redis.
+type HybridConfig struct {
+	// MinLease minimum permits leased at once (default 1)
+	MinLease int64 `mapstructure:"min_lease"`
+
+	// MaxLease maximum permits leased at once (default 100), the overshoot bound of each instance
+	MaxLease int64 `mapstructure:"max_lease"`
+
+	// LeaseTarget local traffic a lease should cover (default 100ms), the lease size follows the local rate
+	LeaseTarget time.Duration `mapstructure:"lease_target"`
+
+	// LeaseTTL lifetime of a lease (default 1s), unused permits of an expired lease are dropped
+	LeaseTTL time.Duration `mapstructure:"lease_ttl"`
+
+	// RefillThreshold share of the last lease left when the background refill starts (default 0.5)
+	RefillThreshold float64 `mapstructure:"refill_threshold"`
+}
+
+// ApplyDefaults fills unset hybrid options
+func (h *HybridConfig) ApplyDefaults() {
+	if h.MinLease <= 0 {
+		h.MinLease = 1
+	}
+	if h.MaxLease <= 0 {
+		h.MaxLease = 100
+	}
+	if h.LeaseTarget <= 0 {
+		h.LeaseTarget = 100 * time.Millisecond
+	}
+	if h.LeaseTTL <= 0 {
+		h.LeaseTTL = time.Second
+	}
+	if h.RefillThreshold <= 0 {
+		h.RefillThreshold = 0.5
+	}
+}
+
 // Return default configuration
 func DefaultConfig() Config {
 	return Config{
@@ -102,12 +175,29 @@ func (c *Config) Validate() error {
 	}
 
 	// Validate storage type
-	if c.StoreType != string(StoreTypeMemory) && c.StoreType != string(StoreTypeRedis) {
-		return &ValidationError{Field: "store_type", Message: "must be 'memory' or 'redis'"}
+	switch StoreType(c.StoreType) {
+	case StoreTypeMemory, StoreTypeRedis:
+	case StoreTypeHybrid:
+		c.Hybrid.ApplyDefaults()
+		if c.Hybrid.MinLease > c.Hybrid.MaxLease {
+			return &ValidationError{Field: "hybrid.min_lease", Message: "must not exceed max_lease"}
+		}
+		if c.Hybrid.RefillThreshold >= 1 {
+			return &ValidationError{Field: "hybrid.refill_threshold", Message: "must be less than 1"}
+		}
+	default:
+		return &ValidationError{Field: "store_type", Message: "must be 'memory', 'redis' or 'hybrid'"}
+	}
+
+	// Validate response header style
+	switch c.Headers {
+	case "", "ietf", "x", "none":
+	default:
+		return &ValidationError{Field: "headers", Message: "must be 'ietf', 'x' or 'none'"}
 	}
 
 	// Verify Redis configuration
-	if c.StoreType == string(StoreTypeRedis) {
+	if c.StoreType == string(StoreTypeRedis) || c.StoreType == string(StoreTypeHybrid) {
 		if c.Redis.Instance == "" {
 			return &ValidationError{Field: "redis.instance", Message: "redis instance name is required"}
 		}
@@ -125,6 +215,10 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if err := c.validatePolicy(); err != nil {
+		return err
+	}
+
 	// Merge and validate resource configurations
 	for name, cfg := range c.Resources {
 		// If default is valid, merge the default configuration
@@ -134,14 +228,14 @@ func (c *Config) Validate() error {
 		} else {
 			merged = cfg
 		}
-		c.Resources[name] = merged
-
 		if err := merged.Validate(); err != nil {
 			return &ValidationError{
 				Resource: name,
 				Err:      err,
 			}
 		}
+		// Store the validated configuration (with the defaults filled by Validate)
+		c.Resources[name] = merged
 	}
 
 	return nil
@@ -173,6 +267,12 @@ func (rc ResourceConfig) Merge(override ResourceConfig) ResourceConfig {
 	if override.BucketSize > 0 {
 		result.BucketSize = override.BucketSize
 	}
+	if override.Period != "" {
+		result.Period = override.Period
+	}
+	if override.Timezone != "" {
+		result.Timezone = override.Timezone
+	}
 	if override.MaxConcurrency > 0 {
 		result.MaxConcurrency = override.MaxConcurrency
 	}
@@ -197,6 +297,24 @@ func (rc ResourceConfig) Merge(override ResourceConfig) ResourceConfig {
 	if override.AdjustInterval > 0 {
 		result.AdjustInterval = override.AdjustInterval
 	}
+	if override.InitLimit > 0 {
+		result.InitLimit = override.InitLimit
+	}
+	if override.LatencyTolerance > 0 {
+		result.LatencyTolerance = override.LatencyTolerance
+	}
+	if override.SampleWindow > 0 {
+		result.SampleWindow = override.SampleWindow
+	}
+	if override.ProbeInterval > 0 {
+		result.ProbeInterval = override.ProbeInterval
+	}
+	if override.Smoothing > 0 {
+		result.Smoothing = override.Smoothing
+	}
+	if override.BackoffRatio > 0 {
+		result.BackoffRatio = override.BackoffRatio
+	}
 
 	return result
 }
@@ -217,7 +335,9 @@ func (rc *ResourceConfig) Validate() error {
 	// Validate algorithm type
 	algo := AlgorithmType(rc.Algorithm)
 	if algo != AlgorithmTokenBucket && algo != AlgorithmSlidingWindow &&
-		algo != AlgorithmConcurrency && algo != AlgorithmAdaptive {
+		algo != AlgorithmConcurrency && algo != AlgorithmAdaptive &&
+		algo != AlgorithmGCRA && algo != AlgorithmFixedWindow && algo != AlgorithmLeakyBucket &&
+		algo != AlgorithmLatency {
 		return &ValidationError{Field: "algorithm", Message: "invalid algorithm type"}
 	}
 
@@ -259,6 +379,42 @@ func (rc *ResourceConfig) Validate() error {
 			rc.Timeout = 1 * time.Second // Default 1 second
 		}
 
+	case AlgorithmGCRA:
+		if rc.Rate <= 0 {
+			return &ValidationError{Field: "rate", Message: "must be > 0"}
+		}
+		if rc.Capacity <= 0 {
+			return &ValidationError{Field: "capacity", Message: "must be > 0 (burst)"}
+		}
+
+	case AlgorithmFixedWindow:
+		if rc.Limit <= 0 {
+			return &ValidationError{Field: "limit", Message: "must be > 0"}
+		}
+		if rc.Period != "" {
+			if !isValidPeriod(rc.Period) {
+				return &ValidationError{Field: "period", Message: "must be 'hour', 'day', 'week' or 'month'"}
+			}
+			loc, err := time.LoadLocation(rc.Timezone)
+			if err != nil {
+				return &ValidationError{Field: "timezone", Message: err.Error()}
+			}
+			rc.location = loc
+		} else if rc.WindowSize <= 0 {
+			return &ValidationError{Field: "window_size", Message: "must be > 0 (or set period)"}
+		}
+
+	case AlgorithmLeakyBucket:
+		if rc.Rate <= 0 {
+			return &ValidationError{Field: "rate", Message: "must be > 0"}
+		}
+		if rc.Capacity <= 0 {
+			return &ValidationError{Field: "capacity", Message: "must be > 0 (queue size)"}
+		}
+		if rc.Timeout <= 0 {
+			rc.Timeout = 1 * time.Second // Default 1 second
+		}
+
 	case AlgorithmAdaptive:
 		if rc.MinLimit <= 0 {
 			return &ValidationError{Field: "min_limit", Message: "must be > 0"}
@@ -281,15 +437,65 @@ func (rc *ResourceConfig) Validate() error {
 		if rc.AdjustInterval <= 0 {
 			rc.AdjustInterval = 10 * time.Second // Default 10 seconds
 		}
+
+	case AlgorithmLatency:
+		if rc.MinLimit <= 0 {
+			rc.MinLimit = 1 // Default 1
+		}
+		if rc.MaxLimit <= 0 {
+			rc.MaxLimit = 1000 // Default 1000
+		}
+		if rc.MinLimit > rc.MaxLimit {
+			return &ValidationError{Field: "min_limit", Message: "must be <= max_limit"}
+		}
+		if rc.InitLimit <= 0 {
+			rc.InitLimit = maxInt64(rc.MinLimit, minInt64(20, rc.MaxLimit)) // Default 20
+		}
+		if rc.InitLimit < rc.MinLimit || rc.InitLimit > rc.MaxLimit {
+			return &ValidationError{Field: "init_limit", Message: "must be between min_limit and max_limit"}
+		}
+		if rc.LatencyTolerance <= 0 {
+			rc.LatencyTolerance = 2 // Default 2x the min latency
+		}
+		if rc.LatencyTolerance < 1 {
+			return &ValidationError{Field: "latency_tolerance", Message: "must be >= 1"}
+		}
+		if rc.SampleWindow <= 0 {
+			rc.SampleWindow = 1 * time.Second // Default 1 second
+		}
+		if rc.ProbeInterval <= 0 {
+			rc.ProbeInterval = 30 * time.Second // Default 30 seconds
+		}
+		if rc.Smoothing <= 0 {
+			rc.Smoothing = 0.2 // Default 0.2
+		}
+		if rc.Smoothing > 1 {
+			return &ValidationError{Field: "smoothing", Message: "must be between 0.0 and 1.0"}
+		}
+		if rc.BackoffRatio <= 0 {
+			rc.BackoffRatio = 0.9 // Default 0.9
+		}
+		if rc.BackoffRatio >= 1 {
+			return &ValidationError{Field: "backoff_ratio", Message: "must be less than 1.0"}
+		}
+		if rc.Timeout <= 0 {
+			rc.Timeout = 1 * time.Second // Default 1 second
+		}
 	}
 
 	return nil
 }
 
 // GetResourceConfig Retrieve resource configuration (prioritize resource-level configuration, fallback to default)
+// Resource names may be glob patterns, see matchResource
 func (c *Config) GetResourceConfig(resource string) ResourceConfig {
 	if cfg, ok := c.Resources[resource]; ok {
 		return cfg
 	}
+	for _, pattern := range sortResourcePatterns(c.Resources) {
+		if matchResource(pattern, resource) {
+			return c.Resources[pattern]
+		}
+	}
 	return c.Default
 }
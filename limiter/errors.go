@@ -1,6 +1,14 @@
 package limiter
 
-import "errors"
+import (
+	"errors"
+	"net/http"
+
+	"github.com/KOMKZ/go-yogan-framework/errcode"
+)
+
+// Module Code: 71 (Framework Layer Limiter Module)
+const moduleCodeLimiter = 71
 
 var (
 	// ErrLimitExceeded Exceeds rate limiting threshold
@@ -22,6 +30,24 @@ var (
 	ErrResourceNotFound = errors.New("resource not found")
 )
 
+// Error code definitions
+var (
+	// ErrRateLimited request rejected by rate limiting (HTTP 429)
+	ErrRateLimited = errcode.Register(errcode.New(
+		moduleCodeLimiter, 1, "limiter", "limiter.rate_limited", "请求过于频繁，请稍后再试", http.StatusTooManyRequests,
+	))
+
+	// ErrResourceNotConfigured resource not configured (runtime resource management)
+	ErrResourceNotConfigured = errcode.Register(errcode.New(
+		moduleCodeLimiter, 2, "limiter", "limiter.resource_not_configured", "限流资源未配置", http.StatusNotFound,
+	))
+
+	// ErrConfigInvalid invalid resource configuration (runtime resource management)
+	ErrConfigInvalid = errcode.Register(errcode.New(
+		moduleCodeLimiter, 3, "limiter", "limiter.config_invalid", "限流配置无效", http.StatusBadRequest,
+	))
+)
+
 // ValidationError configuration validation error
 type ValidationError struct {
 	Resource string
@@ -0,0 +1,271 @@
+package limiter
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// hybridRateWindow sample window of the local request rate that sizes the leases
+const hybridRateWindow = 250 * time.Millisecond
+
+// leasedAlgorithm serves requests from permits leased in batches from the shared store (hybrid store)
+// One instance exists per resource, the wrapped algorithm keeps the global state in Redis
+type leasedAlgorithm struct {
+	Algorithm
+	refunder Refunder
+	cfg      HybridConfig
+
+	// leaseMu serializes leases so that concurrent requests do not lease more than one batch
+	leaseMu sync.Mutex
+
+	mu        sync.Mutex
+	tokens    int64     // permits left in the local lease
+	expiresAt time.Time // end of the local lease
+	lastLease int64     // size of the last lease, the refill threshold is relative to it
+	refilling bool      // background refill in flight
+	resource  string    // resource of the lease (to return it on shutdown)
+	config    ResourceConfig
+
+	// local request rate (EWMA), sizes the leases
+	rate        float64
+	windowStart time.Time
+	windowCount int64
+}
+
+// newLeasedAlgorithm wraps algorithms that can refund permits, others are returned as is
+func newLeasedAlgorithm(algorithm Algorithm, cfg HybridConfig) Algorithm {
+	refunder, ok := algorithm.(Refunder)
+	if !ok {
+		return algorithm
+	}
+	return &leasedAlgorithm{Algorithm: algorithm, refunder: refunder, cfg: cfg}
+}
+
+// Allow serves the request from the local lease, leasing synchronously when it is empty
+// Remaining of allowed responses is the local lease left on this instance, not the global remaining count
+func (a *leasedAlgorithm) Allow(ctx context.Context, store Store, resource string, n int64, cfg ResourceConfig) (*Response, error) {
+	if n <= 0 {
+		n = 1
+	}
+
+	a.mu.Lock()
+	a.observe(time.Now(), n)
+	a.mu.Unlock()
+
+	if resp, ok := a.take(ctx, store, resource, n, cfg); ok {
+		return resp, nil
+	}
+
+	// Local lease exhausted: one caller leases, the others wait for it and retry locally
+	a.leaseMu.Lock()
+	defer a.leaseMu.Unlock()
+	if resp, ok := a.take(ctx, store, resource, n, cfg); ok {
+		return resp, nil
+	}
+
+	resp, err := a.lease(ctx, store, resource, max(a.leaseSize(), n), cfg)
+	if err != nil {
+		return nil, err
+	}
+	if taken, ok := a.take(ctx, store, resource, n, cfg); ok {
+		return taken, nil
+	}
+
+	// Not enough permits left globally
+	return &Response{
+		Allowed:    false,
+		Remaining:  0,
+		Limit:      resp.Limit,
+		ResetAt:    resp.ResetAt,
+		RetryAfter: resp.RetryAfter,
+	}, nil
+}
+
+// take serves N permits from the local lease and starts a background refill when it runs low
+func (a *leasedAlgorithm) take(ctx context.Context, store Store, resource string, n int64, cfg ResourceConfig) (*Response, bool) {
+	a.mu.Lock()
+	now := time.Now()
+	if a.tokens > 0 && !now.Before(a.expiresAt) {
+		// Expired leases are dropped (a fixed window lease must not outlive its window)
+		a.tokens = 0
+	}
+	if a.tokens < n {
+		a.mu.Unlock()
+		return nil, false
+	}
+
+	a.tokens -= n
+	remaining := a.tokens
+	refill := !a.refilling && float64(a.tokens) < a.cfg.RefillThreshold*float64(a.lastLease)
+	if refill {
+		a.refilling = true
+	}
+	a.mu.Unlock()
+
+	if refill {
+		go a.refill(context.WithoutCancel(ctx), store, resource, cfg)
+	}
+	return &Response{Allowed: true, Remaining: remaining, Limit: responseLimit(cfg)}, true
+}
+
+// refill leases the next batch in the background
+func (a *leasedAlgorithm) refill(ctx context.Context, store Store, resource string, cfg ResourceConfig) {
+	defer func() {
+		a.mu.Lock()
+		a.refilling = false
+		a.mu.Unlock()
+	}()
+
+	// A synchronous lease is already running
+	if !a.leaseMu.TryLock() {
+		return
+	}
+	defer a.leaseMu.Unlock()
+	_, _ = a.lease(ctx, store, resource, a.leaseSize(), cfg)
+}
+
+// lease takes up to size permits from the shared store and adds them to the local lease
+// Caller holds leaseMu. Returns the response of the last store call.
+func (a *leasedAlgorithm) lease(ctx context.Context, store Store, resource string, size int64, cfg ResourceConfig) (*Response, error) {
+	resp, err := a.Algorithm.Allow(ctx, store, resource, size, cfg)
+	if err != nil {
+		return nil, err
+	}
+	granted := size
+	if !resp.Allowed {
+		// Take what is left of the global limit
+		granted = 0
+		if resp.Remaining > 0 && resp.Remaining < size {
+			partial, err := a.Algorithm.Allow(ctx, store, resource, resp.Remaining, cfg)
+			if err != nil {
+				return nil, err
+			}
+			if partial.Allowed {
+				granted = resp.Remaining
+			}
+			resp = partial
+		}
+	}
+	if granted == 0 {
+		return resp, nil
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(a.cfg.LeaseTTL)
+	isWindow := AlgorithmType(cfg.Algorithm) == AlgorithmFixedWindow
+	if isWindow && resp.ResetAt.Before(expiresAt) {
+		expiresAt = resp.ResetAt
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.tokens > 0 && now.Before(a.expiresAt) && isWindow && a.expiresAt.Before(expiresAt) {
+		// Permits of the previous window must not move into the next one
+		expiresAt = a.expiresAt
+	}
+	a.tokens += granted
+	a.expiresAt = expiresAt
+	a.lastLease = granted
+	a.resource = resource
+	a.config = cfg
+	return resp, nil
+}
+
+// observe updates the local request rate. Caller holds mu.
+func (a *leasedAlgorithm) observe(now time.Time, n int64) {
+	if a.windowStart.IsZero() {
+		a.windowStart = now
+	}
+	a.windowCount += n
+	if elapsed := now.Sub(a.windowStart); elapsed >= hybridRateWindow {
+		sample := float64(a.windowCount) / elapsed.Seconds()
+		if a.rate == 0 {
+			a.rate = sample
+		} else {
+			a.rate = 0.5*a.rate + 0.5*sample
+		}
+		a.windowStart = now
+		a.windowCount = 0
+	}
+}
+
+// leaseSize returns the lease size covering LeaseTarget of local traffic, within MinLease and MaxLease
+func (a *leasedAlgorithm) leaseSize() int64 {
+	a.mu.Lock()
+	rate := a.rate
+	a.mu.Unlock()
+
+	size := int64(math.Ceil(rate * a.cfg.LeaseTarget.Seconds()))
+	return min(max(size, a.cfg.MinLease), a.cfg.MaxLease)
+}
+
+// Wait polls the local lease until the request is allowed or the timeout expires
+func (a *leasedAlgorithm) Wait(ctx context.Context, store Store, resource string, n int64, cfg ResourceConfig, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		resp, err := a.Allow(ctx, store, resource, n, cfg)
+		if err != nil {
+			return err
+		}
+		if resp.Allowed {
+			return nil
+		}
+
+		wait := min64Duration(max(resp.RetryAfter, 10*time.Millisecond), time.Until(deadline))
+		if wait <= 0 {
+			return ErrWaitTimeout
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// Refund gives N permits back to the local lease, or to the shared store once the lease expired
+func (a *leasedAlgorithm) Refund(ctx context.Context, store Store, resource string, n int64, cfg ResourceConfig) error {
+	a.mu.Lock()
+	if time.Now().Before(a.expiresAt) {
+		a.tokens += n
+		a.mu.Unlock()
+		return nil
+	}
+	a.mu.Unlock()
+	return a.refunder.Refund(ctx, store, resource, n, cfg)
+}
+
+// Reset drops the local lease and resets the shared state
+func (a *leasedAlgorithm) Reset(ctx context.Context, store Store, resource string) error {
+	a.mu.Lock()
+	a.tokens = 0
+	a.mu.Unlock()
+	return a.Algorithm.Reset(ctx, store, resource)
+}
+
+// returnLease gives the unused permits of an unexpired lease back to the shared store
+func (a *leasedAlgorithm) returnLease(ctx context.Context, store Store) error {
+	a.leaseMu.Lock()
+	defer a.leaseMu.Unlock()
+
+	a.mu.Lock()
+	tokens, resource, cfg := a.tokens, a.resource, a.config
+	expired := !time.Now().Before(a.expiresAt)
+	a.tokens = 0
+	a.mu.Unlock()
+
+	if tokens <= 0 || expired {
+		return nil
+	}
+	return a.refunder.Refund(ctx, store, resource, tokens, cfg)
+}
+
+// responseLimit returns the limit reported for a resource configuration
+func responseLimit(cfg ResourceConfig) int64 {
+	if AlgorithmType(cfg.Algorithm) == AlgorithmFixedWindow {
+		return cfg.Limit
+	}
+	return cfg.Capacity
+}
@@ -0,0 +1,232 @@
+package limiter
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newHybridManager creates a hybrid store manager on a shared miniredis (one manager per instance)
+func newHybridManager(t *testing.T, mr *miniredis.Miniredis, hybrid HybridConfig, resources map[string]ResourceConfig) *Manager {
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	manager, err := NewManagerWithLogger(Config{
+		Enabled:   true,
+		StoreType: "hybrid",
+		Redis:     RedisInstanceConfig{Instance: "main"},
+		Hybrid:    hybrid,
+		Resources: resources,
+	}, nil, client, nil)
+	require.NoError(t, err)
+	return manager
+}
+
+func TestRefund(t *testing.T) {
+	cases := []struct {
+		name string
+		algo Algorithm
+		cfg  ResourceConfig
+	}{
+		{"token_bucket", NewTokenBucketAlgorithm(), ResourceConfig{Rate: 1, Capacity: 5}},
+		{"gcra", NewGCRAAlgorithm(), ResourceConfig{Rate: 1, Capacity: 5}},
+		{"fixed_window", NewFixedWindowAlgorithm(), ResourceConfig{Limit: 5, WindowSize: time.Hour}},
+	}
+	for _, tc := range cases {
+		eachStore(t, tc.name, func(t *testing.T, store Store) {
+			ctx := context.Background()
+			refunder := tc.algo.(Refunder)
+
+			resp, err := tc.algo.Allow(ctx, store, "api", 5, tc.cfg)
+			require.NoError(t, err)
+			require.True(t, resp.Allowed)
+
+			require.NoError(t, refunder.Refund(ctx, store, "api", 3, tc.cfg))
+			resp, err = tc.algo.Allow(ctx, store, "api", 3, tc.cfg)
+			require.NoError(t, err)
+			assert.True(t, resp.Allowed)
+			resp, err = tc.algo.Allow(ctx, store, "api", 1, tc.cfg)
+			require.NoError(t, err)
+			assert.False(t, resp.Allowed)
+
+			// Refunds never exceed the limit
+			require.NoError(t, tc.algo.Reset(ctx, store, "api"))
+			require.NoError(t, refunder.Refund(ctx, store, "api", 10, tc.cfg))
+			resp, err = tc.algo.Allow(ctx, store, "api", 6, tc.cfg)
+			require.NoError(t, err)
+			assert.False(t, resp.Allowed)
+		})
+	}
+}
+
+func TestHybrid_ServesLocallyFromLease(t *testing.T) {
+	mr := miniredis.RunT(t)
+	manager := newHybridManager(t, mr, HybridConfig{MinLease: 10, MaxLease: 10}, map[string]ResourceConfig{
+		"api": {Algorithm: "fixed_window", Limit: 100, WindowSize: time.Hour},
+	})
+	defer manager.Close()
+
+	ctx := context.Background()
+	resp, err := manager.Check(ctx, "api", 1)
+	require.NoError(t, err)
+	require.True(t, resp.Allowed)
+	assert.Equal(t, int64(100), resp.Limit)
+
+	// The first request leased a batch of 10, the next ones are served without Redis
+	commands := mr.CommandCount()
+	for i := 0; i < 4; i++ {
+		resp, err = manager.Check(ctx, "api", 1)
+		require.NoError(t, err)
+		assert.True(t, resp.Allowed)
+	}
+	assert.Equal(t, commands, mr.CommandCount())
+	assert.Equal(t, int64(5), resp.Remaining)
+}
+
+func TestHybrid_GlobalLimitAcrossInstances(t *testing.T) {
+	mr := miniredis.RunT(t)
+	resources := map[string]ResourceConfig{
+		"api": {Algorithm: "fixed_window", Limit: 50, WindowSize: time.Hour},
+	}
+	instances := []*Manager{
+		newHybridManager(t, mr, HybridConfig{MinLease: 8, MaxLease: 8}, resources),
+		newHybridManager(t, mr, HybridConfig{MinLease: 8, MaxLease: 8}, resources),
+		newHybridManager(t, mr, HybridConfig{MinLease: 8, MaxLease: 8}, resources),
+	}
+
+	var allowed int64
+	var wg sync.WaitGroup
+	for _, manager := range instances {
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(manager *Manager) {
+				defer wg.Done()
+				for j := 0; j < 10; j++ {
+					ok, err := manager.Allow(context.Background(), "api")
+					if assert.NoError(t, err) && ok {
+						atomic.AddInt64(&allowed, 1)
+					}
+				}
+			}(manager)
+		}
+	}
+	wg.Wait()
+
+	// Leased permits are taken from the global limit, partial leases use the rest of it
+	assert.Equal(t, int64(50), allowed)
+	for _, manager := range instances {
+		manager.Close()
+	}
+}
+
+func TestHybrid_LeaseSizeFollowsTraffic(t *testing.T) {
+	algo := newLeasedAlgorithm(NewTokenBucketAlgorithm(), HybridConfig{
+		MinLease: 1, MaxLease: 50, LeaseTarget: 100 * time.Millisecond,
+	}).(*leasedAlgorithm)
+	assert.Equal(t, int64(1), algo.leaseSize())
+
+	// 1000 requests/s cover 100 permits in 100ms, capped at MaxLease
+	start := time.Now()
+	algo.observe(start, 1)
+	algo.observe(start.Add(hybridRateWindow), 250)
+	assert.Equal(t, int64(50), algo.leaseSize())
+
+	// 40 requests/s cover 4 permits in 100ms, the EWMA moves towards it
+	algo.observe(start.Add(2*hybridRateWindow), 10)
+	assert.Equal(t, int64(50), algo.leaseSize())
+	for i := 3; i < 20; i++ {
+		algo.observe(start.Add(time.Duration(i)*hybridRateWindow), 10)
+	}
+	assert.InDelta(t, 4, algo.leaseSize(), 1)
+
+	// Algorithms without refunds keep using the store directly
+	_, ok := newLeasedAlgorithm(NewSlidingWindowAlgorithm(), HybridConfig{}).(*leasedAlgorithm)
+	assert.False(t, ok)
+}
+
+func TestHybrid_CloseReturnsLease(t *testing.T) {
+	mr := miniredis.RunT(t)
+	resources := map[string]ResourceConfig{
+		"api": {Algorithm: "fixed_window", Limit: 10, WindowSize: time.Hour},
+	}
+	first := newHybridManager(t, mr, HybridConfig{MinLease: 10, MaxLease: 10}, resources)
+	second := newHybridManager(t, mr, HybridConfig{MinLease: 1, MaxLease: 1}, resources)
+	defer second.Close()
+
+	ctx := context.Background()
+	allowed, err := first.Allow(ctx, "api")
+	require.NoError(t, err)
+	require.True(t, allowed)
+
+	// The first instance holds the whole limit
+	allowed, err = second.Allow(ctx, "api")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	// Its 9 unused permits go back to Redis on shutdown
+	require.NoError(t, first.Close())
+	for i := 0; i < 9; i++ {
+		allowed, err = second.Allow(ctx, "api")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	}
+	allowed, err = second.Allow(ctx, "api")
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
+
+func TestHybrid_FixedWindowLeaseEndsWithWindow(t *testing.T) {
+	mr := miniredis.RunT(t)
+	manager := newHybridManager(t, mr, HybridConfig{MinLease: 10, MaxLease: 10, LeaseTTL: time.Hour}, map[string]ResourceConfig{
+		"api": {Algorithm: "fixed_window", Limit: 10, WindowSize: 100 * time.Millisecond},
+	})
+	defer manager.Close()
+
+	ctx := context.Background()
+	allowed, err := manager.Allow(ctx, "api")
+	require.NoError(t, err)
+	require.True(t, allowed)
+
+	limiter, _ := manager.getOrCreateLimiter("api")
+	leased := limiter.algorithm.(*leasedAlgorithm)
+	leased.mu.Lock()
+	expiresAt := leased.expiresAt
+	leased.mu.Unlock()
+	assert.WithinDuration(t, time.Now(), expiresAt, 100*time.Millisecond)
+
+	// Permits of an ended window are dropped instead of being served in the next one
+	time.Sleep(time.Until(expiresAt) + 10*time.Millisecond)
+	leased.mu.Lock()
+	tokens := leased.tokens
+	leased.mu.Unlock()
+	resp, ok := leased.take(ctx, manager.store, "api", tokens, limiter.config)
+	assert.False(t, ok)
+	assert.Nil(t, resp)
+}
+
+func TestConfig_ValidateHybrid(t *testing.T) {
+	cfg := Config{Enabled: true, StoreType: "hybrid", Redis: RedisInstanceConfig{Instance: "main"}}
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, int64(1), cfg.Hybrid.MinLease)
+	assert.Equal(t, int64(100), cfg.Hybrid.MaxLease)
+	assert.Equal(t, time.Second, cfg.Hybrid.LeaseTTL)
+	assert.Equal(t, "limiter:", cfg.Redis.KeyPrefix)
+
+	cfg = Config{Enabled: true, StoreType: "hybrid"}
+	assert.Error(t, cfg.Validate())
+
+	cfg = Config{Enabled: true, StoreType: "hybrid", Redis: RedisInstanceConfig{Instance: "main"},
+		Hybrid: HybridConfig{MinLease: 20, MaxLease: 10}}
+	assert.Error(t, cfg.Validate())
+
+	cfg = Config{Enabled: true, StoreType: "hybrid", Redis: RedisInstanceConfig{Instance: "main"},
+		Hybrid: HybridConfig{RefillThreshold: 1}}
+	assert.Error(t, cfg.Validate())
+}
@@ -20,12 +20,14 @@ type Manager struct {
 	provider    AdaptiveProvider
 	logger      *logger.CtxZapLogger
 	otelMetrics *OTelMetrics // Optional: OTel metrics provider (injected after creation)
+	patterns    []string     // glob resource names, most specific first
 	mu          sync.RWMutex
 }
 
 // rateLimiter rate limiter for a single resource
 type rateLimiter struct {
 	resource  string
+	rule      string // policy rule name, empty for configured resources
 	config    ResourceConfig
 	algorithm Algorithm
 	metrics   MetricsCollector
@@ -66,9 +68,9 @@ func NewManagerWithLogger(config Config, ctxLogger *logger.CtxZapLogger, redisCl
 	case StoreTypeMemory:
 		store = NewMemoryStore()
 		ctxLogger.DebugCtx(ctx, "✅ English: ✔ Using in-memory storage")
-	case StoreTypeRedis:
+	case StoreTypeRedis, StoreTypeHybrid:
 		if redisClient == nil {
-			return nil, fmt.Errorf("redis client is required for redis store")
+			return nil, fmt.Errorf("redis client is required for %s store", config.StoreType)
 		}
 		store = NewRedisStore(redisClient, config.Redis.KeyPrefix)
 		ctxLogger.DebugCtx(ctx, "✅ English: √ Using Redis for storage Redis English: √ Using Redis for storage",
@@ -86,6 +88,7 @@ func NewManagerWithLogger(config Config, ctxLogger *logger.CtxZapLogger, redisCl
 
 	return &Manager{
 		config:   config,
+		patterns: sortResourcePatterns(config.Resources),
 		store:    store,
 		limiters: make(map[string]*rateLimiter),
 		eventBus: eventBus,
@@ -98,6 +101,9 @@ func NewManagerWithLogger(config Config, ctxLogger *logger.CtxZapLogger, redisCl
 // This should be called after the Manager is created when metrics are enabled.
 func (m *Manager) SetMetrics(metrics *OTelMetrics) {
 	m.otelMetrics = metrics
+	if metrics != nil {
+		metrics.SetLimitSource(m.CurrentLimits)
+	}
 }
 
 // Allow check if the request is permitted
@@ -107,6 +113,16 @@ func (m *Manager) Allow(ctx context.Context, resource string) (bool, error) {
 
 // AllowN checks if N requests are permitted
 func (m *Manager) AllowN(ctx context.Context, resource string, n int64) (bool, error) {
+	resp, err := m.Check(ctx, resource, n)
+	if err != nil {
+		return false, err
+	}
+	return resp.Allowed, nil
+}
+
+// Check checks if N requests are permitted and returns the full decision (limit, remaining, reset, retry after)
+// Requests that are not rate limited (disabled, unconfigured resource) get an allowed response with zero Limit
+func (m *Manager) Check(ctx context.Context, resource string, n int64) (*Response, error) {
 	if m.logger != nil {
 		m.logger.DebugCtx(ctx, "🔍 [LimiterManager] AllowN called",
 			zap.Bool("enabled", m.config.Enabled),
@@ -120,45 +136,35 @@ func (m *Manager) AllowN(ctx context.Context, resource string, n int64) (bool, e
 		if m.otelMetrics != nil {
 			m.otelMetrics.RecordAllowed(ctx, resource, "disabled")
 		}
-		return true, nil
+		return &Response{Allowed: true}, nil
 	}
 
-	// 🎯 Check if the resource is defined in the configuration
-	_, exists := m.config.Resources[resource]
-
-	// If the resource is not configured
-	if !exists {
-		// Try using default configuration
-		if err := m.config.Default.Validate(); err != nil {
-			// default configuration is invalid or not set, allow directly
-			if m.logger != nil {
-				m.logger.DebugCtx(ctx, "🔓 [LimiterManager] Resource not configured and default config is invalid, auto-allowing",
-					zap.String("resource", resource))
-			}
-			// Record OTel metrics for auto-allowed requests
-			if m.otelMetrics != nil {
-				m.otelMetrics.RecordAllowed(ctx, resource, "none")
-			}
-			return true, nil
-		}
-
-		// default configuration is effective, rate limiting using default configuration
+	// Get or create the rate limiter of the resource (exact name, pattern or default configuration)
+	limiter, ok := m.getOrCreateLimiter(resource)
+	if !ok {
+		// Resource not configured and default configuration is invalid or not set, allow directly
 		if m.logger != nil {
-			m.logger.DebugCtx(ctx, "🎯 [LimiterManager] Applying default config to unknown resource",
-				zap.String("resource", resource),
-				zap.String("algorithm", m.config.Default.Algorithm),
-				zap.Int64("rate", m.config.Default.Rate))
+			m.logger.DebugCtx(ctx, "🔓 [LimiterManager] Resource not configured and default config is invalid, auto-allowing",
+				zap.String("resource", resource))
 		}
-		// Continue with rate limiting logic (using default configuration)
+		// Record OTel metrics for auto-allowed requests
+		if m.otelMetrics != nil {
+			m.otelMetrics.RecordAllowed(ctx, resource, "none")
+		}
+		return &Response{Allowed: true}, nil
 	}
 
-	// Get or create the rate limiter
-	limiter := m.getOrCreateLimiter(resource)
+	return m.decide(ctx, limiter, n)
+}
+
+// decide runs the algorithm of a limiter, records metrics and publishes the decision event
+func (m *Manager) decide(ctx context.Context, limiter *rateLimiter, n int64) (*Response, error) {
+	resource := limiter.resource
 
 	// Call the algorithm to check
 	resp, err := limiter.algorithm.Allow(ctx, m.store, resource, n, limiter.config)
 	if err != nil {
-		return false, fmt.Errorf("algorithm allow failed: %w", err)
+		return nil, fmt.Errorf("algorithm allow failed: %w", err)
 	}
 
 	// Record metrics (internal collector)
@@ -196,7 +202,7 @@ func (m *Manager) AllowN(ctx context.Context, resource string, n int64) (bool, e
 		}
 	}
 
-	return resp.Allowed, nil
+	return resp, nil
 }
 
 // Wait for permission to be acquired
@@ -218,8 +224,11 @@ func (m *Manager) WaitN(ctx context.Context, resource string, n int64) error {
 		return nil
 	}
 
-	// Get or create the rate limiter
-	limiter := m.getOrCreateLimiter(resource)
+	// Get or create the rate limiter, resources that are not rate limited pass directly
+	limiter, ok := m.getOrCreateLimiter(resource)
+	if !ok {
+		return nil
+	}
 
 	// Publish wait start event
 	start := time.Now()
@@ -255,13 +264,83 @@ func (m *Manager) WaitN(ctx context.Context, resource string, n int64) error {
 
 	if err != nil {
 		limiter.metrics.RecordRejected("wait timeout")
+		if m.otelMetrics != nil {
+			m.otelMetrics.RecordRejected(ctx, resource, limiter.config.Algorithm, "wait_timeout")
+		}
 		return err
 	}
 
 	limiter.metrics.RecordAllowed(0)
+	if m.otelMetrics != nil {
+		m.otelMetrics.RecordAllowed(ctx, resource, limiter.config.Algorithm)
+	}
+	return nil
+}
+
+// Release returns N permits of a resource after the requests complete
+// Only algorithms holding permits (concurrency, latency) release, for the others it is a no-op
+func (m *Manager) Release(ctx context.Context, resource string, n int64) error {
+	limiter, ok := m.existingLimiter(resource)
+	if !ok {
+		return nil
+	}
+
+	releaser, ok := limiter.algorithm.(Releaser)
+	if !ok {
+		return nil
+	}
+	return releaser.Release(ctx, m.store, resource, n)
+}
+
+// Complete returns N permits of a resource after the requests complete, with their latency
+// The latency algorithm samples the latency (dropped: the request failed from overload, e.g., timeout or 503),
+// other algorithms holding permits release them, for the others it is a no-op
+func (m *Manager) Complete(ctx context.Context, resource string, n int64, latency time.Duration, dropped bool) error {
+	limiter, ok := m.existingLimiter(resource)
+	if !ok {
+		return nil
+	}
+
+	if completer, ok := limiter.algorithm.(Completer); ok {
+		return completer.Complete(ctx, m.store, resource, n, latency, dropped, limiter.config)
+	}
+	if releaser, ok := limiter.algorithm.(Releaser); ok {
+		return releaser.Release(ctx, m.store, resource, n)
+	}
 	return nil
 }
 
+// existingLimiter returns the limiter of a resource without creating it
+func (m *Manager) existingLimiter(resource string) (*rateLimiter, bool) {
+	if !m.config.Enabled {
+		return nil, false
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	limiter, exists := m.limiters[resource]
+	return limiter, exists
+}
+
+// limitReporter algorithms with a dynamic limit (latency)
+type limitReporter interface {
+	CurrentLimit() int64
+}
+
+// CurrentLimits returns the current limit of every resource with a dynamic limit (latency algorithm)
+func (m *Manager) CurrentLimits() map[string]int64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	limits := make(map[string]int64)
+	for resource, limiter := range m.limiters {
+		if reporter, ok := limiter.algorithm.(limitReporter); ok {
+			limits[resource] = reporter.CurrentLimit()
+		}
+	}
+	return limits
+}
+
 // GetMetrics retrieves throttling metrics
 func (m *Manager) GetMetrics(resource string) *MetricsSnapshot {
 	m.mu.RLock()
@@ -317,6 +396,9 @@ func (m *Manager) Close() error {
 		m.eventBus.Close()
 	}
 
+	// Return the unused permits of local leases (hybrid store)
+	m.returnLeases()
+
 	// Close storage
 	if m.store != nil {
 		return m.store.Close()
@@ -325,6 +407,42 @@ func (m *Manager) Close() error {
 	return nil
 }
 
+// newAlgorithm creates the algorithm of a limiter, leasing permits locally with the hybrid store
+func (m *Manager) newAlgorithm(cfg ResourceConfig) Algorithm {
+	algorithm := GetAlgorithm(cfg, m.provider)
+	if StoreType(m.config.StoreType) == StoreTypeHybrid {
+		return newLeasedAlgorithm(algorithm, m.config.Hybrid)
+	}
+	return algorithm
+}
+
+// returnLeases gives the unused permits of every local lease back to the shared store
+func (m *Manager) returnLeases() {
+	m.mu.RLock()
+	limiters := make([]*rateLimiter, 0, len(m.limiters))
+	for _, limiter := range m.limiters {
+		limiters = append(limiters, limiter)
+	}
+	m.mu.RUnlock()
+
+	for _, limiter := range limiters {
+		m.returnLease(limiter.algorithm)
+	}
+}
+
+// returnLease gives the unused permits of a leasing algorithm back, other algorithms are ignored
+func (m *Manager) returnLease(algorithm Algorithm) {
+	leased, ok := algorithm.(*leasedAlgorithm)
+	if !ok || m.store == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := leased.returnLease(ctx, m.store); err != nil && m.logger != nil {
+		m.logger.WarnCtx(ctx, "⚠️  [LimiterManager] Return lease failed", zap.Error(err))
+	}
+}
+
 // Implements the samber/do.Shutdownable interface for shutdown functionality
 func (m *Manager) Shutdown() error {
 	return m.Close()
@@ -335,18 +453,21 @@ func (m *Manager) IsEnabled() bool {
 	return m.config.Enabled
 }
 
-// GetConfig retrieve rate limiter configuration
+// GetConfig retrieve rate limiter configuration (including runtime resource updates)
 func (m *Manager) GetConfig() Config {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 	return m.config
 }
 
 // Get or create limiter (thread-safe)
-func (m *Manager) getOrCreateLimiter(resource string) *rateLimiter {
+// Returns false when the resource is not rate limited (not configured and no valid default)
+func (m *Manager) getOrCreateLimiter(resource string) (*rateLimiter, bool) {
 	// Try to read first
 	m.mu.RLock()
 	if limiter, exists := m.limiters[resource]; exists {
 		m.mu.RUnlock()
-		return limiter
+		return limiter, true
 	}
 	m.mu.RUnlock()
 
@@ -356,14 +477,39 @@ func (m *Manager) getOrCreateLimiter(resource string) *rateLimiter {
 
 	// Double check
 	if limiter, exists := m.limiters[resource]; exists {
+		return limiter, true
+	}
+
+	// Resolve under the lock so that concurrent resource updates are not missed
+	resourceConfig, ok := m.resolveResourceLocked(resource)
+	if !ok {
+		return nil, false
+	}
+	return m.createLimiterLocked(resource, "", resourceConfig), true
+}
+
+// getOrCreateRuleLimiter gets or creates the limiter of a policy rule key
+func (m *Manager) getOrCreateRuleLimiter(resource string, rule *PolicyRule) *rateLimiter {
+	m.mu.RLock()
+	if limiter, exists := m.limiters[resource]; exists {
+		m.mu.RUnlock()
 		return limiter
 	}
+	m.mu.RUnlock()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	// Get resource configuration
-	resourceConfig := m.config.GetResourceConfig(resource)
+	if limiter, exists := m.limiters[resource]; exists {
+		return limiter
+	}
+	return m.createLimiterLocked(resource, rule.Name, rule.ResourceConfig)
+}
 
+// createLimiterLocked creates and registers a limiter. Caller holds the write lock.
+func (m *Manager) createLimiterLocked(resource string, rule string, resourceConfig ResourceConfig) *rateLimiter {
 	// Create algorithm instance
-	algorithm := GetAlgorithm(resourceConfig, m.provider)
+	algorithm := m.newAlgorithm(resourceConfig)
 
 	// Create metric collector
 	metrics := NewMetricsCollector(resource, resourceConfig.Algorithm)
@@ -371,6 +517,7 @@ func (m *Manager) getOrCreateLimiter(resource string) *rateLimiter {
 	// Create new rate limiter
 	limiter := &rateLimiter{
 		resource:  resource,
+		rule:      rule,
 		config:    resourceConfig,
 		algorithm: algorithm,
 		metrics:   metrics,
@@ -23,8 +23,11 @@ type OTelMetrics struct {
 	currentTokens   metric.Int64ObservableGauge // Current token count
 	rejectRate      metric.Float64ObservableGauge // Current reject rate
 	
+	concurrencyLimit metric.Int64ObservableGauge // Current limit of latency-based resources
+
 	// State tracking for gauges
 	tokenCallbacks  map[string]func() int64
+	limitSource     func() map[string]int64
 	tokenMu         sync.RWMutex
 }
 
@@ -108,10 +111,45 @@ func (m *OTelMetrics) RegisterMetrics(meter metric.Meter) error {
 		}
 	}
 
+	// Gauge: current concurrency limit of latency-based resources
+	m.concurrencyLimit, err = meter.Int64ObservableGauge(
+		"limiter_concurrency_limit",
+		metric.WithDescription("Current concurrency limit of latency-based resources"),
+		metric.WithUnit("{request}"),
+		metric.WithInt64Callback(m.collectLimits),
+	)
+	if err != nil {
+		return err
+	}
+
 	m.registered = true
 	return nil
 }
 
+// collectLimits is the callback for the concurrency limit gauge
+func (m *OTelMetrics) collectLimits(_ context.Context, observer metric.Int64Observer) error {
+	m.tokenMu.RLock()
+	source := m.limitSource
+	m.tokenMu.RUnlock()
+	if source == nil {
+		return nil
+	}
+
+	for resource, limit := range source() {
+		observer.Observe(limit,
+			metric.WithAttributes(attribute.String("resource", resource)),
+		)
+	}
+	return nil
+}
+
+// SetLimitSource sets the provider of the current dynamic limits by resource (Manager.CurrentLimits)
+func (m *OTelMetrics) SetLimitSource(source func() map[string]int64) {
+	m.tokenMu.Lock()
+	defer m.tokenMu.Unlock()
+	m.limitSource = source
+}
+
 // collectTokens is the callback for the observable gauge
 func (m *OTelMetrics) collectTokens(_ context.Context, observer metric.Int64Observer) error {
 	m.tokenMu.RLock()
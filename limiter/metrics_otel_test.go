@@ -3,10 +3,13 @@ package limiter
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
 
 func TestNewOTelMetrics(t *testing.T) {
@@ -121,3 +124,56 @@ func TestOTelMetrics_TokenCallbacks(t *testing.T) {
 		assert.Len(t, m.tokenCallbacks, 0)
 	})
 }
+
+func TestOTelMetrics_AlgorithmParity(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test")
+
+	m := NewOTelMetrics(MetricsConfig{Enabled: true})
+	require.NoError(t, m.RegisterMetrics(meter))
+
+	manager, err := NewManager(Config{
+		Enabled:   true,
+		StoreType: "memory",
+		Resources: map[string]ResourceConfig{
+			"gcra":  {Algorithm: string(AlgorithmGCRA), Rate: 1, Capacity: 1},
+			"fixed": {Algorithm: string(AlgorithmFixedWindow), Limit: 1, WindowSize: time.Hour},
+			"leaky": {Algorithm: string(AlgorithmLeakyBucket), Rate: 1, Capacity: 1, Timeout: time.Millisecond},
+		},
+	})
+	require.NoError(t, err)
+	defer manager.Close()
+	manager.SetMetrics(m)
+
+	ctx := context.Background()
+	for _, resource := range []string{"gcra", "fixed"} {
+		_, _ = manager.Allow(ctx, resource)
+		_, _ = manager.Allow(ctx, resource)
+	}
+	// Leaky bucket traffic goes through Wait
+	require.NoError(t, manager.Wait(ctx, "leaky"))
+	assert.ErrorIs(t, manager.Wait(ctx, "leaky"), ErrWaitTimeout)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+
+	counts := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, metric := range sm.Metrics {
+			sum, ok := metric.Data.(metricdata.Sum[int64])
+			if !ok {
+				continue
+			}
+			for _, dp := range sum.DataPoints {
+				algorithm, _ := dp.Attributes.Value("algorithm")
+				counts[metric.Name+"/"+algorithm.AsString()] += dp.Value
+			}
+		}
+	}
+
+	for _, algorithm := range []string{"gcra", "fixed_window", "leaky_bucket"} {
+		assert.Equal(t, int64(1), counts["limiter_allowed_total/"+algorithm], algorithm)
+		assert.Equal(t, int64(1), counts["limiter_rejected_total/"+algorithm], algorithm)
+		assert.Equal(t, int64(2), counts["limiter_requests_total/"+algorithm], algorithm)
+	}
+}
@@ -0,0 +1,207 @@
+package limiter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// PolicyRule one rule of a hierarchical rate limiting policy
+//
+// Example (yaml):
+//
+//	policy:
+//	  - name: route
+//	    key: path
+//	    algorithm: token_bucket
+//	    rate: 1000
+//	    capacity: 2000
+//	  - name: user
+//	    key: user
+//	    algorithm: gcra
+//	    rate: 10
+//	    capacity: 20
+//	  - name: api_key_daily
+//	    key: api_key
+//	    algorithm: fixed_window
+//	    limit: 10000
+//	    period: day
+type PolicyRule struct {
+	// Name rule name (unique), reported as the limiting rule
+	Name string `mapstructure:"name"`
+
+	// Key key extractor of the rule, interpreted by the caller (e.g., the HTTP middleware)
+	// Built-in middleware extractors: global, path, ip, user, tenant, api_key, header:<name>, query:<name>, context:<key>
+	Key string `mapstructure:"key"`
+
+	// ResourceConfig algorithm and parameters of the rule
+	ResourceConfig `mapstructure:",squash"`
+}
+
+// RuleResult decision of one evaluated rule
+type RuleResult struct {
+	Rule     string
+	Key      string
+	Resource string // limiter resource of the rule key (see Release / Complete)
+	Response *Response
+}
+
+// PolicyResponse result of evaluating a policy
+// The embedded Response is the decision of the most restrictive rule: the rejecting rule when rejected,
+// otherwise the rule with the smallest remaining share of its limit
+type PolicyResponse struct {
+	Response
+
+	// Rule name of the most restrictive rule (empty when no rule applied)
+	Rule string
+
+	// Results decisions of the evaluated rules, in rule order
+	Results []RuleResult
+}
+
+// HasPolicy returns whether policy rules are configured
+func (m *Manager) HasPolicy() bool {
+	return m.config.Enabled && len(m.config.Policy) > 0
+}
+
+// CheckPolicy evaluates the policy rules in order for N requests
+// keys maps rule names to the extracted key values, rules without a key (e.g., anonymous user) are skipped.
+// Evaluation stops at the first rejecting rule, so later rules are not charged for rejected requests;
+// rules that allowed before give their permits back. Allowed requests must be completed with CompletePolicy
+// so that concurrency and latency rules release their permits.
+func (m *Manager) CheckPolicy(ctx context.Context, keys map[string]string, n int64) (*PolicyResponse, error) {
+	result := &PolicyResponse{Response: Response{Allowed: true}}
+	if !m.HasPolicy() {
+		return result, nil
+	}
+
+	var restrictiveShare float64
+	for i := range m.config.Policy {
+		rule := &m.config.Policy[i]
+		key, ok := keys[rule.Name]
+		if !ok || key == "" {
+			continue
+		}
+
+		resource := fmt.Sprintf("policy:%s:%s", rule.Name, key)
+		limiter := m.getOrCreateRuleLimiter(resource, rule)
+
+		resp, err := m.decide(ctx, limiter, n)
+		if err != nil {
+			m.refundPolicy(ctx, result.Results, n)
+			return nil, fmt.Errorf("rule %s: %w", rule.Name, err)
+		}
+		result.Results = append(result.Results, RuleResult{Rule: rule.Name, Key: key, Resource: resource, Response: resp})
+
+		if !resp.Allowed {
+			m.refundPolicy(ctx, result.Results[:len(result.Results)-1], n)
+			result.Response = *resp
+			result.Rule = rule.Name
+			if m.logger != nil {
+				m.logger.DebugCtx(ctx, "🚫 [LimiterManager] Policy rule rejected",
+					zap.String("rule", rule.Name),
+					zap.String("key", key),
+					zap.Duration("retry_after", resp.RetryAfter))
+			}
+			return result, nil
+		}
+
+		// Most restrictive allowed rule: smallest remaining share of its limit
+		if resp.Limit > 0 {
+			share := float64(resp.Remaining) / float64(resp.Limit)
+			if result.Rule == "" || share < restrictiveShare {
+				result.Response = *resp
+				result.Rule = rule.Name
+				restrictiveShare = share
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// CompletePolicy completes N allowed requests of a policy decision with their latency
+// Concurrency and latency rules release their permits (see Complete), it is a no-op for the other rules
+func (m *Manager) CompletePolicy(ctx context.Context, result *PolicyResponse, n int64, latency time.Duration, dropped bool) error {
+	if result == nil || !result.Allowed {
+		return nil
+	}
+
+	var firstErr error
+	for _, r := range result.Results {
+		if err := m.Complete(ctx, r.Resource, n, latency, dropped); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("rule %s: %w", r.Rule, err)
+		}
+	}
+	return firstErr
+}
+
+// refundPolicy gives the permits of rules that allowed back after a later rule rejected
+func (m *Manager) refundPolicy(ctx context.Context, results []RuleResult, n int64) {
+	for _, r := range results {
+		limiter, ok := m.existingLimiter(r.Resource)
+		if !ok {
+			continue
+		}
+
+		var err error
+		if refunder, ok := limiter.algorithm.(Refunder); ok {
+			err = refunder.Refund(ctx, m.store, r.Resource, n, limiter.config)
+		} else if releaser, ok := limiter.algorithm.(Releaser); ok {
+			err = releaser.Release(ctx, m.store, r.Resource, n)
+		}
+		if err != nil && m.logger != nil {
+			m.logger.WarnCtx(ctx, "⚠️  [LimiterManager] Policy rule refund failed",
+				zap.String("rule", r.Rule),
+				zap.String("key", r.Key),
+				zap.Error(err))
+		}
+	}
+}
+
+// policyRefundable returns whether the permits of an algorithm can be given back
+// (refunded by token_bucket, gcra, fixed_window; released by concurrency, latency)
+func policyRefundable(algorithm string) bool {
+	switch AlgorithmType(algorithm) {
+	case AlgorithmTokenBucket, AlgorithmGCRA, AlgorithmFixedWindow, AlgorithmConcurrency, AlgorithmLatency:
+		return true
+	default:
+		return false
+	}
+}
+
+// validatePolicy validates policy rules
+func (c *Config) validatePolicy() error {
+	names := make(map[string]bool, len(c.Policy))
+	for i := range c.Policy {
+		rule := &c.Policy[i]
+		if rule.Name == "" {
+			return &ValidationError{Field: fmt.Sprintf("policy[%d].name", i), Message: "is required"}
+		}
+		if names[rule.Name] {
+			return &ValidationError{Field: fmt.Sprintf("policy[%d].name", i), Message: "duplicate rule name '" + rule.Name + "'"}
+		}
+		names[rule.Name] = true
+
+		if rule.Key == "" {
+			return &ValidationError{Field: "policy." + rule.Name + ".key", Message: "is required"}
+		}
+		// Quota rules (period set) default to the fixed window, other rules to the token bucket
+		if rule.Algorithm == "" {
+			rule.Algorithm = string(AlgorithmTokenBucket)
+			if rule.Period != "" {
+				rule.Algorithm = string(AlgorithmFixedWindow)
+			}
+		}
+		if err := rule.ResourceConfig.Validate(); err != nil {
+			return &ValidationError{Resource: "policy." + rule.Name, Err: err}
+		}
+		// A later rejection gives the permits of earlier rules back, other algorithms can only be last
+		if i < len(c.Policy)-1 && !policyRefundable(rule.Algorithm) {
+			return &ValidationError{Field: "policy." + rule.Name + ".algorithm", Message: "'" + rule.Algorithm + "' cannot give permits back, it must be the last rule"}
+		}
+	}
+	return nil
+}
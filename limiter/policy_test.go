@@ -0,0 +1,220 @@
+package limiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPolicyManager(t *testing.T, rules ...PolicyRule) *Manager {
+	manager, err := NewManager(Config{
+		Enabled:   true,
+		StoreType: "memory",
+		Policy:    rules,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { manager.Close() })
+	return manager
+}
+
+func TestPolicy_MostRestrictiveRule(t *testing.T) {
+	manager := newPolicyManager(t,
+		PolicyRule{Name: "route", Key: "path", ResourceConfig: ResourceConfig{Rate: 1, Capacity: 100}},
+		PolicyRule{Name: "user", Key: "user", ResourceConfig: ResourceConfig{Rate: 1, Capacity: 5}},
+	)
+	assert.True(t, manager.HasPolicy())
+
+	ctx := context.Background()
+	keys := map[string]string{"route": "get:/api", "user": "42"}
+
+	resp, err := manager.CheckPolicy(ctx, keys, 1)
+	require.NoError(t, err)
+	assert.True(t, resp.Allowed)
+	assert.Equal(t, "user", resp.Rule)
+	assert.Equal(t, int64(5), resp.Limit)
+	assert.Equal(t, int64(4), resp.Remaining)
+	require.Len(t, resp.Results, 2)
+	assert.Equal(t, "route", resp.Results[0].Rule)
+	assert.Equal(t, "get:/api", resp.Results[0].Key)
+
+	for i := 0; i < 4; i++ {
+		resp, err = manager.CheckPolicy(ctx, keys, 1)
+		require.NoError(t, err)
+		require.True(t, resp.Allowed)
+	}
+
+	// The user rule rejects, the route rule gives the permit of the rejected request back
+	resp, err = manager.CheckPolicy(ctx, keys, 1)
+	require.NoError(t, err)
+	assert.False(t, resp.Allowed)
+	assert.Equal(t, "user", resp.Rule)
+	assert.Greater(t, resp.RetryAfter, time.Duration(0))
+	assert.Equal(t, int64(94), resp.Results[0].Response.Remaining)
+
+	// Another user is limited separately
+	resp, err = manager.CheckPolicy(ctx, map[string]string{"route": "get:/api", "user": "7"}, 1)
+	require.NoError(t, err)
+	assert.True(t, resp.Allowed)
+	assert.Equal(t, int64(94), resp.Results[0].Response.Remaining, "rejected requests do not use up the route rule")
+}
+
+func TestPolicy_ReleasesConcurrencyRules(t *testing.T) {
+	manager := newPolicyManager(t,
+		PolicyRule{Name: "inflight", Key: "user", ResourceConfig: ResourceConfig{Algorithm: string(AlgorithmConcurrency), MaxConcurrency: 1}},
+		PolicyRule{Name: "daily", Key: "api_key", ResourceConfig: ResourceConfig{Limit: 1, Period: PeriodDay}},
+	)
+
+	ctx := context.Background()
+	keys := map[string]string{"inflight": "42", "daily": "k1"}
+
+	first, err := manager.CheckPolicy(ctx, keys, 1)
+	require.NoError(t, err)
+	require.True(t, first.Allowed)
+	assert.Equal(t, "policy:inflight:42", first.Results[0].Resource)
+
+	// The request is in flight
+	resp, err := manager.CheckPolicy(ctx, map[string]string{"inflight": "42"}, 1)
+	require.NoError(t, err)
+	assert.False(t, resp.Allowed)
+
+	require.NoError(t, manager.CompletePolicy(ctx, first, 1, time.Millisecond, false))
+
+	// The daily quota rejects, the concurrency slot taken before is released
+	resp, err = manager.CheckPolicy(ctx, keys, 1)
+	require.NoError(t, err)
+	assert.False(t, resp.Allowed)
+	assert.Equal(t, "daily", resp.Rule)
+
+	resp, err = manager.CheckPolicy(ctx, map[string]string{"inflight": "42"}, 1)
+	require.NoError(t, err)
+	assert.True(t, resp.Allowed)
+}
+
+func TestPolicy_StopsAtFirstRejection(t *testing.T) {
+	manager := newPolicyManager(t,
+		PolicyRule{Name: "global", Key: "global", ResourceConfig: ResourceConfig{Rate: 1, Capacity: 1}},
+		PolicyRule{Name: "daily", Key: "api_key", ResourceConfig: ResourceConfig{Limit: 10, Period: PeriodDay}},
+	)
+
+	ctx := context.Background()
+	keys := map[string]string{"global": "*", "daily": "k1"}
+
+	resp, err := manager.CheckPolicy(ctx, keys, 1)
+	require.NoError(t, err)
+	require.True(t, resp.Allowed)
+
+	resp, err = manager.CheckPolicy(ctx, keys, 1)
+	require.NoError(t, err)
+	assert.False(t, resp.Allowed)
+	assert.Equal(t, "global", resp.Rule)
+	assert.Len(t, resp.Results, 1)
+
+	// The daily quota was not charged for the rejected request
+	metrics := manager.GetMetrics("policy:daily:k1")
+	require.NotNil(t, metrics)
+	assert.Equal(t, int64(1), metrics.Allowed)
+}
+
+func TestPolicy_SkipsRulesWithoutKey(t *testing.T) {
+	manager := newPolicyManager(t,
+		PolicyRule{Name: "ip", Key: "ip", ResourceConfig: ResourceConfig{Rate: 1, Capacity: 10}},
+		PolicyRule{Name: "user", Key: "user", ResourceConfig: ResourceConfig{Rate: 1, Capacity: 1}},
+	)
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		resp, err := manager.CheckPolicy(ctx, map[string]string{"ip": "10.0.0.1", "user": ""}, 1)
+		require.NoError(t, err)
+		assert.True(t, resp.Allowed)
+		assert.Equal(t, "ip", resp.Rule)
+		assert.Len(t, resp.Results, 1)
+	}
+}
+
+func TestPolicy_DailyQuota(t *testing.T) {
+	manager := newPolicyManager(t,
+		PolicyRule{Name: "daily", Key: "api_key", ResourceConfig: ResourceConfig{Limit: 2, Period: PeriodDay, Timezone: "UTC"}},
+	)
+
+	ctx := context.Background()
+	keys := map[string]string{"daily": "k1"}
+	for i := 0; i < 2; i++ {
+		resp, err := manager.CheckPolicy(ctx, keys, 1)
+		require.NoError(t, err)
+		require.True(t, resp.Allowed)
+	}
+
+	resp, err := manager.CheckPolicy(ctx, keys, 1)
+	require.NoError(t, err)
+	assert.False(t, resp.Allowed)
+
+	// The quota resets at the next midnight
+	now := time.Now().UTC()
+	midnight := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	assert.WithinDuration(t, midnight, resp.ResetAt, time.Second)
+}
+
+func TestFixedWindowEnd_Periods(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+
+	// Wednesday 2026-03-18 15:04:05 in Shanghai
+	now := time.Date(2026, 3, 18, 15, 4, 5, 0, shanghai)
+	cases := []struct {
+		period string
+		want   time.Time
+	}{
+		{PeriodHour, time.Date(2026, 3, 18, 16, 0, 0, 0, shanghai)},
+		{PeriodDay, time.Date(2026, 3, 19, 0, 0, 0, 0, shanghai)},
+		{PeriodWeek, time.Date(2026, 3, 23, 0, 0, 0, 0, shanghai)},
+		{PeriodMonth, time.Date(2026, 4, 1, 0, 0, 0, 0, shanghai)},
+	}
+	for _, tc := range cases {
+		cfg := ResourceConfig{Period: tc.period, Timezone: "Asia/Shanghai"}
+		assert.True(t, fixedWindowEnd(now, cfg).Equal(tc.want), "period %s", tc.period)
+	}
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	cfg := Config{Enabled: true, StoreType: "memory", Policy: []PolicyRule{
+		{Name: "user", Key: "user", ResourceConfig: ResourceConfig{Rate: 1, Capacity: 1}},
+		{Name: "user", Key: "ip", ResourceConfig: ResourceConfig{Rate: 1, Capacity: 1}},
+	}}
+	assert.Error(t, cfg.Validate())
+
+	cfg = Config{Enabled: true, StoreType: "memory", Policy: []PolicyRule{
+		{Name: "user", ResourceConfig: ResourceConfig{Rate: 1, Capacity: 1}},
+	}}
+	assert.Error(t, cfg.Validate())
+
+	cfg = Config{Enabled: true, StoreType: "memory", Policy: []PolicyRule{
+		{Name: "monthly", Key: "tenant", ResourceConfig: ResourceConfig{Limit: 100, Period: "year"}},
+	}}
+	assert.Error(t, cfg.Validate())
+
+	cfg = Config{Enabled: true, StoreType: "memory", Policy: []PolicyRule{
+		{Name: "monthly", Key: "tenant", ResourceConfig: ResourceConfig{Limit: 100, Period: PeriodMonth, Timezone: "Mars/Olympus"}},
+	}}
+	assert.Error(t, cfg.Validate())
+
+	// Algorithms that cannot give permits back must be the last rule
+	cfg = Config{Enabled: true, StoreType: "memory", Policy: []PolicyRule{
+		{Name: "window", Key: "ip", ResourceConfig: ResourceConfig{Algorithm: string(AlgorithmSlidingWindow), Limit: 10, WindowSize: time.Second}},
+		{Name: "user", Key: "user", ResourceConfig: ResourceConfig{Rate: 1, Capacity: 1}},
+	}}
+	assert.Error(t, cfg.Validate())
+	cfg.Policy[0], cfg.Policy[1] = cfg.Policy[1], cfg.Policy[0]
+	assert.NoError(t, cfg.Validate())
+
+	// Quota rules default to the fixed window, other rules to the token bucket
+	cfg = Config{Enabled: true, StoreType: "memory", Policy: []PolicyRule{
+		{Name: "user", Key: "user", ResourceConfig: ResourceConfig{Rate: 1, Capacity: 1}},
+		{Name: "monthly", Key: "tenant", ResourceConfig: ResourceConfig{Limit: 100, Period: PeriodMonth}},
+	}}
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, string(AlgorithmTokenBucket), cfg.Policy[0].Algorithm)
+	assert.Equal(t, string(AlgorithmFixedWindow), cfg.Policy[1].Algorithm)
+}
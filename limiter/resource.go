@@ -0,0 +1,224 @@
+package limiter
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Resource names in Config.Resources may be glob patterns:
+//   - * matches any characters except '/'
+//   - ** matches any characters including '/'
+//   - ? matches one character except '/'
+//
+// e.g. "GET:/users/*" matches "GET:/users/:id", "*:/admin/**" matches every method under /admin.
+// An exact resource name wins over patterns; among patterns the most specific one
+// (most literal characters, then the longest) wins.
+
+// isResourcePattern returns whether a resource name contains glob wildcards
+func isResourcePattern(name string) bool {
+	return strings.ContainsAny(name, "*?")
+}
+
+// matchResource reports whether the resource matches the glob pattern
+func matchResource(pattern, resource string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			if strings.HasPrefix(pattern, "**") {
+				rest := strings.TrimLeft(pattern, "*")
+				for i := 0; i <= len(resource); i++ {
+					if matchResource(rest, resource[i:]) {
+						return true
+					}
+				}
+				return false
+			}
+			rest := pattern[1:]
+			for i := 0; i <= len(resource); i++ {
+				if matchResource(rest, resource[i:]) {
+					return true
+				}
+				if i < len(resource) && resource[i] == '/' {
+					return false
+				}
+			}
+			return false
+		case '?':
+			if len(resource) == 0 || resource[0] == '/' {
+				return false
+			}
+		default:
+			if len(resource) == 0 || resource[0] != pattern[0] {
+				return false
+			}
+		}
+		pattern = pattern[1:]
+		resource = resource[1:]
+	}
+	return len(resource) == 0
+}
+
+// sortResourcePatterns returns the pattern names of the resources, most specific first
+func sortResourcePatterns(resources map[string]ResourceConfig) []string {
+	var patterns []string
+	for name := range resources {
+		if isResourcePattern(name) {
+			patterns = append(patterns, name)
+		}
+	}
+	literals := func(pattern string) int {
+		return len(pattern) - strings.Count(pattern, "*") - strings.Count(pattern, "?")
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		li, lj := literals(patterns[i]), literals(patterns[j])
+		if li != lj {
+			return li > lj
+		}
+		if len(patterns[i]) != len(patterns[j]) {
+			return len(patterns[i]) > len(patterns[j])
+		}
+		return patterns[i] < patterns[j]
+	})
+	return patterns
+}
+
+// resolveResourceLocked finds the configuration of a resource: exact name, then patterns, then default
+// Returns false when the resource is not rate limited (no match and no valid default). Caller holds m.mu.
+func (m *Manager) resolveResourceLocked(resource string) (ResourceConfig, bool) {
+	if cfg, ok := m.config.Resources[resource]; ok {
+		return cfg, true
+	}
+	for _, pattern := range m.patterns {
+		if matchResource(pattern, resource) {
+			return m.config.Resources[pattern], true
+		}
+	}
+	// Validate a copy, the default is shared by concurrent requests
+	def := m.config.Default
+	if err := def.Validate(); err != nil {
+		return ResourceConfig{}, false
+	}
+	return m.config.Default, true
+}
+
+// ResolveResource returns the configuration applied to a resource and whether it is rate limited
+func (m *Manager) ResolveResource(resource string) (ResourceConfig, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.resolveResourceLocked(resource)
+}
+
+// Resources returns the configured resources (exact names and patterns)
+func (m *Manager) Resources() map[string]ResourceConfig {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	resources := make(map[string]ResourceConfig, len(m.config.Resources))
+	for name, cfg := range m.config.Resources {
+		resources[name] = cfg
+	}
+	return resources
+}
+
+// UpdateResource adds or replaces the limits of a resource (exact name or pattern) at runtime
+// The configuration is merged with the default like configured resources. Existing limiters matching
+// the resource switch to the new limits atomically: requests in flight finish with the old limits and
+// the stored state (tokens, counters, metrics) is kept as long as the algorithm stays the same.
+func (m *Manager) UpdateResource(name string, cfg ResourceConfig) error {
+	return m.ReloadResources(map[string]ResourceConfig{name: cfg}, nil)
+}
+
+// RemoveResource removes the limits of a resource at runtime
+// Limiters of the resource fall back to the next matching pattern or the default, or stop being rate limited
+func (m *Manager) RemoveResource(name string) error {
+	return m.ReloadResources(nil, []string{name})
+}
+
+// ReloadResources applies resource updates and removals in one atomic step (e.g., on config reload)
+func (m *Manager) ReloadResources(updates map[string]ResourceConfig, removals []string) error {
+	if !m.config.Enabled {
+		return ErrConfigInvalid.WithMsg("限流器未启用")
+	}
+
+	merged := make(map[string]ResourceConfig, len(updates))
+	for name, cfg := range updates {
+		if name == "" {
+			return ErrConfigInvalid.WithMsg("资源名不能为空")
+		}
+		if !m.config.Default.isEmpty() {
+			cfg = m.config.Default.Merge(cfg)
+		}
+		if err := cfg.Validate(); err != nil {
+			return ErrConfigInvalid.Wrap(&ValidationError{Resource: name, Err: err})
+		}
+		merged[name] = cfg
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, name := range removals {
+		if _, ok := m.config.Resources[name]; !ok {
+			return ErrResourceNotConfigured.WithMsgf("限流资源未配置: %s", name)
+		}
+	}
+
+	// Copy on write, configurations returned by GetConfig stay unchanged
+	resources := make(map[string]ResourceConfig, len(m.config.Resources)+len(merged))
+	for name, cfg := range m.config.Resources {
+		resources[name] = cfg
+	}
+	for _, name := range removals {
+		delete(resources, name)
+	}
+	for name, cfg := range merged {
+		resources[name] = cfg
+	}
+	m.config.Resources = resources
+	m.patterns = sortResourcePatterns(resources)
+
+	m.refreshLimitersLocked()
+
+	if m.logger != nil {
+		m.logger.InfoCtx(context.Background(), "🔄 [LimiterManager] Resources reloaded",
+			zap.Int("updated", len(merged)),
+			zap.Int("removed", len(removals)))
+	}
+	return nil
+}
+
+// refreshLimitersLocked swaps existing limiters to the current resource configuration. Caller holds m.mu.
+func (m *Manager) refreshLimitersLocked() {
+	for resource, limiter := range m.limiters {
+		// Policy rule limiters are configured by their rule
+		if limiter.rule != "" {
+			continue
+		}
+
+		cfg, ok := m.resolveResourceLocked(resource)
+		if !ok {
+			delete(m.limiters, resource)
+			go m.returnLease(limiter.algorithm)
+			continue
+		}
+		if cfg == limiter.config {
+			continue
+		}
+
+		swapped := &rateLimiter{
+			resource:  resource,
+			config:    cfg,
+			algorithm: limiter.algorithm,
+			metrics:   limiter.metrics,
+		}
+		if cfg.Algorithm != limiter.config.Algorithm {
+			swapped.algorithm = m.newAlgorithm(cfg)
+			swapped.metrics = NewMetricsCollector(resource, cfg.Algorithm)
+			go m.returnLease(limiter.algorithm)
+		}
+		m.limiters[resource] = swapped
+	}
+}
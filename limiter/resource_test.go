@@ -0,0 +1,182 @@
+package limiter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchResource(t *testing.T) {
+	cases := []struct {
+		pattern  string
+		resource string
+		want     bool
+	}{
+		{"get:/users/*", "get:/users/:id", true},
+		{"get:/users/*", "get:/users/42", true},
+		{"get:/users/*", "get:/users/42/orders", false},
+		{"get:/users/**", "get:/users/42/orders", true},
+		{"*:/admin/**", "post:/admin/users/1", true},
+		{"*:/admin/**", "post:/api/admin", false},
+		{"get:/v?/users", "get:/v1/users", true},
+		{"get:/v?/users", "get:/v/users", false},
+		{"get:/users", "get:/users", true},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, matchResource(tc.pattern, tc.resource), "%s ~ %s", tc.pattern, tc.resource)
+	}
+}
+
+func TestManager_ResourcePatterns(t *testing.T) {
+	manager, err := NewManager(Config{
+		Enabled:   true,
+		StoreType: "memory",
+		Resources: map[string]ResourceConfig{
+			"get:/users/*":      {Algorithm: "token_bucket", Rate: 1, Capacity: 2},
+			"get:/users/:id":    {Algorithm: "token_bucket", Rate: 1, Capacity: 5},
+			"get:/**":           {Algorithm: "token_bucket", Rate: 1, Capacity: 10},
+			"get:/users/*/tags": {Algorithm: "token_bucket", Rate: 1, Capacity: 3},
+		},
+	})
+	require.NoError(t, err)
+	defer manager.Close()
+
+	// Exact name wins, then the most specific pattern
+	cfg, ok := manager.ResolveResource("get:/users/:id")
+	require.True(t, ok)
+	assert.Equal(t, int64(5), cfg.Capacity)
+
+	cfg, ok = manager.ResolveResource("get:/users/:id/tags")
+	require.True(t, ok)
+	assert.Equal(t, int64(3), cfg.Capacity)
+
+	cfg, ok = manager.ResolveResource("get:/users/me")
+	require.True(t, ok)
+	assert.Equal(t, int64(2), cfg.Capacity)
+
+	cfg, ok = manager.ResolveResource("get:/orders/1/items")
+	require.True(t, ok)
+	assert.Equal(t, int64(10), cfg.Capacity)
+
+	// Not configured and no default: not rate limited
+	_, ok = manager.ResolveResource("post:/orders")
+	assert.False(t, ok)
+	resp, err := manager.Check(context.Background(), "post:/orders", 1)
+	require.NoError(t, err)
+	assert.True(t, resp.Allowed)
+	assert.Equal(t, int64(0), resp.Limit)
+
+	resp, err = manager.Check(context.Background(), "get:/users/me", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), resp.Limit)
+	snapshot := manager.GetConfig()
+	assert.Equal(t, int64(10), snapshot.GetResourceConfig("get:/orders/1/items").Capacity)
+}
+
+func TestManager_UpdateResourceKeepsState(t *testing.T) {
+	manager, err := NewManager(Config{
+		Enabled:   true,
+		StoreType: "memory",
+		Resources: map[string]ResourceConfig{
+			"get:/users/*": {Algorithm: "fixed_window", Limit: 5, WindowSize: time.Hour},
+		},
+	})
+	require.NoError(t, err)
+	defer manager.Close()
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		allowed, err := manager.Allow(ctx, "get:/users/:id")
+		require.NoError(t, err)
+		require.True(t, allowed)
+	}
+
+	// Raise the limit: the 3 requests already counted are kept
+	require.NoError(t, manager.UpdateResource("get:/users/*", ResourceConfig{Algorithm: "fixed_window", Limit: 4, WindowSize: time.Hour}))
+	resp, err := manager.Check(ctx, "get:/users/:id", 1)
+	require.NoError(t, err)
+	assert.True(t, resp.Allowed)
+	assert.Equal(t, int64(4), resp.Limit)
+	assert.Equal(t, int64(0), resp.Remaining)
+
+	resp, err = manager.Check(ctx, "get:/users/:id", 1)
+	require.NoError(t, err)
+	assert.False(t, resp.Allowed)
+	assert.Equal(t, int64(4), manager.GetMetrics("get:/users/:id").Allowed)
+
+	// Snapshots taken before the update are not changed
+	before := manager.GetConfig()
+	require.NoError(t, manager.UpdateResource("get:/users/:id", ResourceConfig{Algorithm: "fixed_window", Limit: 100, WindowSize: time.Hour}))
+	assert.NotContains(t, before.Resources, "get:/users/:id")
+	assert.Contains(t, manager.Resources(), "get:/users/:id")
+
+	resp, err = manager.Check(ctx, "get:/users/:id", 1)
+	require.NoError(t, err)
+	assert.True(t, resp.Allowed)
+	assert.Equal(t, int64(100), resp.Limit)
+}
+
+func TestManager_RemoveResource(t *testing.T) {
+	manager, err := NewManager(Config{
+		Enabled:   true,
+		StoreType: "memory",
+		Resources: map[string]ResourceConfig{
+			"get:/api": {Algorithm: "token_bucket", Rate: 1, Capacity: 1},
+		},
+	})
+	require.NoError(t, err)
+	defer manager.Close()
+
+	ctx := context.Background()
+	allowed, _ := manager.Allow(ctx, "get:/api")
+	require.True(t, allowed)
+	allowed, _ = manager.Allow(ctx, "get:/api")
+	require.False(t, allowed)
+
+	require.NoError(t, manager.RemoveResource("get:/api"))
+	for i := 0; i < 3; i++ {
+		allowed, err := manager.Allow(ctx, "get:/api")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	}
+
+	assert.ErrorIs(t, manager.RemoveResource("get:/api"), ErrResourceNotConfigured)
+	assert.ErrorIs(t, manager.UpdateResource("get:/api", ResourceConfig{Algorithm: "token_bucket"}), ErrConfigInvalid)
+}
+
+func TestManager_UpdateResourceConcurrent(t *testing.T) {
+	manager, err := NewManager(Config{
+		Enabled:   true,
+		StoreType: "memory",
+		Default:   ResourceConfig{Algorithm: "token_bucket", Rate: 1000, Capacity: 1000},
+	})
+	require.NoError(t, err)
+	defer manager.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_, err := manager.Check(context.Background(), "get:/hot", 1)
+				assert.NoError(t, err)
+			}
+		}()
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, manager.UpdateResource("get:/*", ResourceConfig{Capacity: int64(100 + i)}))
+		}(i)
+	}
+	wg.Wait()
+
+	// Updates are merged with the default configuration
+	cfg, ok := manager.ResolveResource("get:/hot")
+	require.True(t, ok)
+	assert.Equal(t, "token_bucket", cfg.Algorithm)
+	assert.Equal(t, int64(1000), cfg.Rate)
+}
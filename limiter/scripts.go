@@ -0,0 +1,248 @@
+package limiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Lua scripts executing one algorithm step atomically on Redis
+// Each script mirrors the local implementation of its algorithm and returns an integer array
+
+// tokenBucketScript refills and takes tokens
+// KEYS: tokens, last_refill; ARGV: rate, capacity, init_tokens, now (ns), n
+// Returns: {allowed, tokens}
+const tokenBucketScript = `
+local rate = tonumber(ARGV[1])
+local capacity = tonumber(ARGV[2])
+local now = ARGV[4]
+local n = tonumber(ARGV[5])
+
+local tokens = tonumber(redis.call('GET', KEYS[1]))
+local last = redis.call('GET', KEYS[2])
+if tokens == nil or not last then
+	tokens = tonumber(ARGV[3])
+	redis.call('SET', KEYS[2], now)
+else
+	local added = math.floor((tonumber(now) - tonumber(last)) * rate / 1e9)
+	if added > 0 then
+		tokens = math.min(tokens + added, capacity)
+		redis.call('SET', KEYS[2], now)
+	end
+end
+
+local allowed = 0
+if tokens >= n then
+	tokens = tokens - n
+	allowed = 1
+end
+redis.call('SET', KEYS[1], tokens)
+return {allowed, tokens}
+`
+
+// tokenBucketRefundScript gives tokens back, capped at the capacity
+// KEYS: tokens; ARGV: n, capacity
+// Returns: {tokens}
+const tokenBucketRefundScript = `
+local tokens = tonumber(redis.call('GET', KEYS[1]))
+if tokens == nil then
+	return {0}
+end
+tokens = math.min(tokens + tonumber(ARGV[1]), tonumber(ARGV[2]))
+redis.call('SET', KEYS[1], tokens)
+return {tokens}
+`
+
+// slidingWindowScript drops expired entries, counts and records requests
+// KEYS: window; ARGV: window start score, now score, limit, n, member prefix, window (ms)
+// Returns: {allowed, count before this request}
+const slidingWindowScript = `
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', '(' .. ARGV[1])
+local count = redis.call('ZCARD', KEYS[1])
+local limit = tonumber(ARGV[3])
+local n = tonumber(ARGV[4])
+
+if count + n > limit then
+	return {0, count}
+end
+for i = 1, n do
+	redis.call('ZADD', KEYS[1], ARGV[2], ARGV[5] .. ':' .. i)
+end
+redis.call('PEXPIRE', KEYS[1], ARGV[6])
+return {1, count}
+`
+
+// concurrencyScript acquires concurrency slots
+// KEYS: count; ARGV: max concurrency, n
+// Returns: {allowed, current}
+const concurrencyScript = `
+local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+local n = tonumber(ARGV[2])
+
+if current + n > tonumber(ARGV[1]) then
+	return {0, current}
+end
+return {1, redis.call('INCRBY', KEYS[1], n)}
+`
+
+// runAtomic executes one algorithm step atomically
+// Stores supporting scripts (Redis) run script in a single round trip, AtomicStore stores run local under the
+// lock of lockKey, other stores run local without coordination. script and local must return the same values
+func runAtomic(ctx context.Context, store Store, lockKey, script string, keys []string, args []interface{}, local func() ([]int64, error)) ([]int64, error) {
+	if atomicStore, ok := store.(AtomicStore); ok {
+		var result []int64
+		err := atomicStore.Atomic(lockKey, func() error {
+			var err error
+			result, err = local()
+			return err
+		})
+		return result, err
+	}
+
+	reply, err := store.Eval(ctx, script, keys, args)
+	if errors.Is(err, ErrStoreNotSupported) {
+		return local()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return scriptInts(reply)
+}
+
+// scriptInts converts a script reply to an integer array
+func scriptInts(reply interface{}) ([]int64, error) {
+	values, ok := reply.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected script reply type %T", reply)
+	}
+
+	result := make([]int64, len(values))
+	for i, v := range values {
+		n, ok := v.(int64)
+		if !ok {
+			return nil, fmt.Errorf("unexpected script reply element type %T", v)
+		}
+		result[i] = n
+	}
+	return result, nil
+}
+
+// luaTimestamps helpers computing exact offsets between nanosecond timestamps
+// Absolute nanosecond timestamps exceed the exact integer range of Lua numbers (2^53), so timestamps stay
+// decimal strings and are split into whole seconds and nanoseconds, both exact; only offsets (small) are numbers.
+const luaTimestamps = `
+local function ts_split(t)
+	local len = string.len(t)
+	if len <= 9 then
+		return 0, tonumber(t)
+	end
+	return tonumber(string.sub(t, 1, len - 9)), tonumber(string.sub(t, len - 8))
+end
+
+local function ts_offset(a, b)
+	local a_sec, a_ns = ts_split(a)
+	local b_sec, b_ns = ts_split(b)
+	return (a_sec - b_sec) * 1e9 + (a_ns - b_ns)
+end
+
+local function ts_add(t, offset)
+	local sec, ns = ts_split(t)
+	ns = ns + offset
+	local carry = math.floor(ns / 1e9)
+	return string.format('%.0f%09.0f', sec + carry, ns - carry * 1e9)
+end
+`
+
+// gcraScript applies the generic cell rate algorithm on the theoretical arrival time (TAT)
+// KEYS: tat; ARGV: emission interval (ns), burst, now (ns), n
+// Returns: {allowed, remaining, retry after (ns), reset after (ns)}
+// Times are handled as exact offsets from now (see luaTimestamps)
+const gcraScript = luaTimestamps + `
+local interval = tonumber(ARGV[1])
+local burst_offset = tonumber(ARGV[2]) * interval
+local now = ARGV[3]
+local n = tonumber(ARGV[4])
+
+local tat = ts_offset(redis.call('GET', KEYS[1]) or '0', now)
+if tat < 0 then
+	tat = 0
+end
+
+local new_tat = tat + n * interval
+local allow_at = new_tat - burst_offset
+if allow_at > 0 then
+	return {0, math.max(math.floor((burst_offset - tat) / interval), 0), allow_at, tat}
+end
+
+redis.call('SET', KEYS[1], ts_add(now, new_tat), 'PX', math.ceil(new_tat / 1e6) + 1)
+return {1, math.floor((burst_offset - new_tat) / interval), 0, new_tat}
+`
+
+// gcraRefundScript moves the theoretical arrival time back by N emission intervals, not before now
+// KEYS: tat; ARGV: emission interval (ns), now (ns), n
+// Returns: {tat offset from now (ns)}
+const gcraRefundScript = luaTimestamps + `
+local now = ARGV[2]
+local tat = ts_offset(redis.call('GET', KEYS[1]) or '0', now)
+if tat <= 0 then
+	return {0}
+end
+
+tat = math.max(tat - tonumber(ARGV[3]) * tonumber(ARGV[1]), 0)
+redis.call('SET', KEYS[1], ts_add(now, tat), 'PX', math.ceil(tat / 1e6) + 1)
+return {tat}
+`
+
+// fixedWindowScript counts requests in the current window
+// KEYS: window counter; ARGV: limit, n, window (ms)
+// Returns: {allowed, count}
+const fixedWindowScript = `
+local current = tonumber(redis.call('GET', KEYS[1]) or '0')
+local n = tonumber(ARGV[2])
+
+if current + n > tonumber(ARGV[1]) then
+	return {0, current}
+end
+current = redis.call('INCRBY', KEYS[1], n)
+if current == n then
+	redis.call('PEXPIRE', KEYS[1], ARGV[3])
+end
+return {1, current}
+`
+
+// fixedWindowRefundScript decrements the window counter, not below zero, keeping its expiry
+// KEYS: window counter; ARGV: n
+// Returns: {count}
+const fixedWindowRefundScript = `
+local current = tonumber(redis.call('GET', KEYS[1]))
+if current == nil then
+	return {0}
+end
+local n = math.min(tonumber(ARGV[1]), current)
+return {redis.call('DECRBY', KEYS[1], n)}
+`
+
+// leakyBucketScript reserves leak slots in the queue
+// KEYS: next free slot time; ARGV: leak interval (ns), capacity, now (ns), n, max delay (ns)
+// Returns: {allowed, queued, delay (ns)}
+// Times are handled as exact offsets from now (see luaTimestamps)
+const leakyBucketScript = luaTimestamps + `
+local interval = tonumber(ARGV[1])
+local capacity = tonumber(ARGV[2])
+local now = ARGV[3]
+local n = tonumber(ARGV[4])
+
+local delay = ts_offset(redis.call('GET', KEYS[1]) or '0', now)
+if delay < 0 then
+	delay = 0
+end
+
+local queued = math.ceil(delay / interval)
+if queued + n > capacity or delay > tonumber(ARGV[5]) then
+	return {0, queued, delay}
+end
+
+local new_slot = delay + n * interval
+redis.call('SET', KEYS[1], ts_add(now, new_slot), 'PX', math.ceil(new_slot / 1e6) + 1)
+return {1, queued + n, delay}
+`
@@ -59,6 +59,12 @@ type Store interface {
 	Close() error
 }
 
+// AtomicStore optional interface for in-process stores that cannot run scripts
+// Atomic runs fn while holding the lock of key, so read-modify-write sequences on that key are serialized
+type AtomicStore interface {
+	Atomic(key string, fn func() error) error
+}
+
 // StoreType storage type
 type StoreType string
 
@@ -68,5 +74,8 @@ const (
 
 	// StoreTypeRedis Redis storage
 	StoreTypeRedis StoreType = "redis"
+
+	// StoreTypeHybrid Redis storage with local token leases (see HybridConfig)
+	StoreTypeHybrid StoreType = "hybrid"
 )
 
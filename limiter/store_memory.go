@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"strconv"
 	"sync"
 	"time"
@@ -15,8 +16,14 @@ type memoryStore struct {
 	data   map[string]*memoryValue
 	zsets  map[string]*memoryZSet
 	closed bool
+
+	// keyLocks striped locks serializing algorithm read-modify-write sequences (see Atomic)
+	keyLocks [memoryKeyLockStripes]sync.Mutex
 }
 
+// memoryKeyLockStripes number of striped key locks
+const memoryKeyLockStripes = 64
+
 // memory value
 type memoryValue struct {
 	data     string
@@ -327,6 +334,18 @@ func (s *memoryStore) Eval(ctx context.Context, script string, keys []string, ar
 	return nil, ErrStoreNotSupported
 }
 
+// Atomic runs fn while holding the striped lock of key (implements AtomicStore)
+// fn may call any store method, the store's own lock is independent from key locks
+func (s *memoryStore) Atomic(key string, fn func() error) error {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	lock := &s.keyLocks[h.Sum32()%memoryKeyLockStripes]
+
+	lock.Lock()
+	defer lock.Unlock()
+	return fn()
+}
+
 // Close connection
 func (s *memoryStore) Close() error {
 	s.mu.Lock()
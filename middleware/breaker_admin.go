@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"sort"
+
+	"github.com/KOMKZ/go-yogan-framework/breaker"
+	"github.com/KOMKZ/go-yogan-framework/httpx"
+	"github.com/gin-gonic/gin"
+)
+
+// BreakerAdminHandler circuit breaker administration HTTP handler
+// Exposes resource states and the operator controls (force open/closed, disable, reset).
+// Resource names may contain '/' and ':', so they are passed as the name query parameter.
+type BreakerAdminHandler struct {
+	manager *breaker.Manager
+}
+
+// NewBreakerAdminHandler creates a circuit breaker administration handler
+func NewBreakerAdminHandler(manager *breaker.Manager) *BreakerAdminHandler {
+	return &BreakerAdminHandler{
+		manager: manager,
+	}
+}
+
+// breakerStateView state of a resource
+type breakerStateView struct {
+	Resource string `json:"resource"`
+	State    string `json:"state"`
+	Forced   bool   `json:"forced"`
+}
+
+// HandleList returns the state of every resource with a breaker
+// GET /breaker/resources
+func (h *BreakerAdminHandler) HandleList() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		states := h.manager.States()
+		views := make([]breakerStateView, 0, len(states))
+		for resource, state := range states {
+			views = append(views, breakerStateView{Resource: resource, State: state.String(), Forced: state.IsForced()})
+		}
+		sort.Slice(views, func(i, j int) bool { return views[i].Resource < views[j].Resource })
+		httpx.OkJson(c, views)
+	}
+}
+
+// HandleInspect returns the state and metrics of a resource
+// GET /breaker/resources/inspect?name=order-service
+func (h *BreakerAdminHandler) HandleInspect() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Query("name")
+		if name == "" {
+			httpx.ErrorJson(c, "name is required")
+			return
+		}
+		state := h.manager.GetState(name)
+		result := gin.H{
+			"resource": name,
+			"state":    state.String(),
+			"forced":   state.IsForced(),
+			"metrics":  h.manager.GetMetrics(name),
+		}
+		if stats, ok := h.manager.GetBulkheadStats(name); ok {
+			result["bulkhead"] = stats
+		}
+		httpx.OkJson(c, result)
+	}
+}
+
+// HandleForce forces the state of a resource until it is cleared
+// PUT /breaker/resources/force?name=order-service&state=forced_open (forced_open, forced_closed, disabled)
+func (h *BreakerAdminHandler) HandleForce() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Query("name")
+		if name == "" {
+			httpx.ErrorJson(c, "name is required")
+			return
+		}
+		state, ok := breaker.ParseState(c.Query("state"))
+		if !ok || !state.IsForced() {
+			httpx.ErrorJson(c, breaker.ErrInvalidForcedState.Error())
+			return
+		}
+		if err := h.manager.ForceState(name, state); err != nil {
+			httpx.ErrorJson(c, err.Error())
+			return
+		}
+		httpx.OkJson(c, breakerStateView{Resource: name, State: state.String(), Forced: true})
+	}
+}
+
+// HandleClear clears the forced state of a resource (back to closed)
+// DELETE /breaker/resources/force?name=order-service
+func (h *BreakerAdminHandler) HandleClear() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Query("name")
+		if name == "" {
+			httpx.ErrorJson(c, "name is required")
+			return
+		}
+		if err := h.manager.ClearForcedState(name); err != nil {
+			httpx.ErrorJson(c, err.Error())
+			return
+		}
+		state := h.manager.GetState(name)
+		httpx.OkJson(c, breakerStateView{Resource: name, State: state.String(), Forced: state.IsForced()})
+	}
+}
+
+// HandleReset closes a resource and clears its metrics (on every instance with shared state)
+// POST /breaker/resources/reset?name=order-service
+func (h *BreakerAdminHandler) HandleReset() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Query("name")
+		if name == "" {
+			httpx.ErrorJson(c, "name is required")
+			return
+		}
+		if err := h.manager.Reset(name); err != nil {
+			httpx.HandleError(c, err)
+			return
+		}
+		state := h.manager.GetState(name)
+		httpx.OkJson(c, breakerStateView{Resource: name, State: state.String(), Forced: state.IsForced()})
+	}
+}
+
+// RegisterBreakerAdminRoutes registers circuit breaker administration routes
+// Mount on a protected group, e.g. RegisterBreakerAdminRoutes(engine.Group("/admin", auth), manager)
+func RegisterBreakerAdminRoutes(router gin.IRouter, manager *breaker.Manager) {
+	if manager == nil || !manager.IsEnabled() {
+		return
+	}
+
+	handler := NewBreakerAdminHandler(manager)
+
+	router.GET("/breaker/resources", handler.HandleList())
+	router.GET("/breaker/resources/inspect", handler.HandleInspect())
+	router.PUT("/breaker/resources/force", handler.HandleForce())
+	router.DELETE("/breaker/resources/force", handler.HandleClear())
+	router.POST("/breaker/resources/reset", handler.HandleReset())
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/KOMKZ/go-yogan-framework/breaker"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBreakerAdminTest(t *testing.T) (*gin.Engine, *breaker.Manager) {
+	gin.SetMode(gin.TestMode)
+
+	config := breaker.DefaultConfig()
+	config.Enabled = true
+	manager, err := breaker.NewManager(config)
+	require.NoError(t, err)
+	t.Cleanup(manager.Close)
+
+	router := gin.New()
+	RegisterBreakerAdminRoutes(router.Group("/admin"), manager)
+	return router, manager
+}
+
+func TestBreakerAdmin_ForceAndClear(t *testing.T) {
+	router, manager := setupBreakerAdminTest(t)
+
+	resp, body := serveAdmin(router, http.MethodPut, "/admin/breaker/resources/force?name=order-service&state=forced_open")
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "ForcedOpen", body["data"].(map[string]any)["state"])
+
+	_, err := manager.Execute(context.Background(), &breaker.Request{
+		Resource: "order-service",
+		Execute: func(ctx context.Context) (interface{}, error) {
+			return nil, nil
+		},
+	})
+	assert.ErrorIs(t, err, breaker.ErrForcedOpen)
+
+	resp, body = serveAdmin(router, http.MethodGet, "/admin/breaker/resources")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	views := body["data"].([]any)
+	require.Len(t, views, 1)
+	assert.Equal(t, true, views[0].(map[string]any)["forced"])
+
+	resp, body = serveAdmin(router, http.MethodGet, "/admin/breaker/resources/inspect?name=order-service")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "ForcedOpen", body["data"].(map[string]any)["state"])
+
+	resp, body = serveAdmin(router, http.MethodDelete, "/admin/breaker/resources/force?name=order-service")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "Closed", body["data"].(map[string]any)["state"])
+	assert.Equal(t, breaker.StateClosed, manager.GetState("order-service"))
+
+	resp, _ = serveAdmin(router, http.MethodPost, "/admin/breaker/resources/reset?name=order-service")
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestBreakerAdmin_InvalidRequests(t *testing.T) {
+	router, _ := setupBreakerAdminTest(t)
+
+	resp, _ := serveAdmin(router, http.MethodPut, "/admin/breaker/resources/force?name=order-service&state=open")
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp, _ = serveAdmin(router, http.MethodPut, "/admin/breaker/resources/force?state=disabled")
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp, _ = serveAdmin(router, http.MethodGet, "/admin/breaker/resources/inspect")
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"github.com/KOMKZ/go-yogan-framework/cache"
+	"github.com/KOMKZ/go-yogan-framework/httpx"
+	"github.com/gin-gonic/gin"
+)
+
+// CacheAdminHandler cache administration HTTP handler
+// Exposes statistics, key inspection and purge operations of the cache orchestrator
+type CacheAdminHandler struct {
+	orchestrator *cache.DefaultOrchestrator
+}
+
+// NewCacheAdminHandler creates a cache administration handler
+func NewCacheAdminHandler(orchestrator *cache.DefaultOrchestrator) *CacheAdminHandler {
+	return &CacheAdminHandler{
+		orchestrator: orchestrator,
+	}
+}
+
+// HandleStats returns cache statistics
+// GET /cache/stats
+func (h *CacheAdminHandler) HandleStats() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		httpx.OkJson(c, h.orchestrator.Stats())
+	}
+}
+
+// HandleInspect inspects a key of a cache item
+// GET /cache/cacheables/:name/key?key=user:1 (raw key) or ?arg=1&arg=2 (key pattern arguments)
+func (h *CacheAdminHandler) HandleInspect() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Param("name")
+		key := c.Query("key")
+		if key == "" {
+			args := c.QueryArray("arg")
+			if len(args) == 0 {
+				httpx.ErrorJson(c, "key or arg is required")
+				return
+			}
+			keyArgs := make([]any, len(args))
+			for i, arg := range args {
+				keyArgs[i] = arg
+			}
+			_, resolved, _, err := h.orchestrator.ResolveKey(name, keyArgs...)
+			if err != nil {
+				httpx.HandleError(c, err)
+				return
+			}
+			key = resolved
+		}
+
+		info, err := h.orchestrator.InspectKey(c.Request.Context(), name, key)
+		if err != nil {
+			httpx.HandleError(c, err)
+			return
+		}
+		httpx.OkJson(c, info)
+	}
+}
+
+// HandlePurgeCacheable purges every entry of a cache item
+// DELETE /cache/cacheables/:name
+func (h *CacheAdminHandler) HandlePurgeCacheable() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Param("name")
+		if err := h.orchestrator.PurgeCacheable(c.Request.Context(), name); err != nil {
+			httpx.HandleError(c, err)
+			return
+		}
+		httpx.OkJson(c, gin.H{"purged": []string{name}})
+	}
+}
+
+// HandlePurgeTag purges every cache item carrying a tag
+// DELETE /cache/tags/:tag
+func (h *CacheAdminHandler) HandlePurgeTag() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		names, err := h.orchestrator.PurgeTag(c.Request.Context(), c.Param("tag"))
+		if err != nil {
+			httpx.HandleError(c, err)
+			return
+		}
+		httpx.OkJson(c, gin.H{"purged": names})
+	}
+}
+
+// HandlePurgePrefix purges keys by prefix from a storage backend
+// DELETE /cache/stores/:store/keys?prefix=user:
+func (h *CacheAdminHandler) HandlePurgePrefix() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		prefix := c.Query("prefix")
+		if prefix == "" {
+			httpx.ErrorJson(c, "prefix is required")
+			return
+		}
+		if err := h.orchestrator.PurgePrefix(c.Request.Context(), c.Param("store"), prefix); err != nil {
+			httpx.HandleError(c, err)
+			return
+		}
+		httpx.OkJson(c, gin.H{"store": c.Param("store"), "prefix": prefix})
+	}
+}
+
+// RegisterCacheAdminRoutes registers cache administration routes
+// Mount on a protected group, e.g. RegisterCacheAdminRoutes(engine.Group("/admin", auth), orchestrator)
+func RegisterCacheAdminRoutes(router gin.IRouter, orchestrator *cache.DefaultOrchestrator) {
+	if orchestrator == nil {
+		return
+	}
+
+	handler := NewCacheAdminHandler(orchestrator)
+
+	router.GET("/cache/stats", handler.HandleStats())
+	router.GET("/cache/cacheables/:name/key", handler.HandleInspect())
+	router.DELETE("/cache/cacheables/:name", handler.HandlePurgeCacheable())
+	router.DELETE("/cache/tags/:tag", handler.HandlePurgeTag())
+	router.DELETE("/cache/stores/:store/keys", handler.HandlePurgePrefix())
+}
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/cache"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCacheAdminTest(t *testing.T) (*gin.Engine, *cache.DefaultOrchestrator) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &cache.Config{
+		Enabled:      true,
+		DefaultStore: "memory",
+		Cacheables: []cache.CacheableConfig{
+			{Name: "user:get", KeyPattern: "user:{0}", TTL: time.Minute, Store: "memory", Enabled: true, Tags: []string{"user"}},
+		},
+	}
+	o := cache.NewOrchestrator(cfg, nil, nil)
+	o.RegisterStore("memory", cache.NewMemoryStore("memory", 100))
+	o.RegisterLoader("user:get", func(ctx context.Context, args ...any) (any, error) {
+		return "value", nil
+	})
+	_, err := o.Call(context.Background(), "user:get", 1)
+	require.NoError(t, err)
+
+	router := gin.New()
+	RegisterCacheAdminRoutes(router.Group("/admin"), o)
+	return router, o
+}
+
+func serveAdmin(router *gin.Engine, method, path string) (*httptest.ResponseRecorder, map[string]any) {
+	req := httptest.NewRequest(method, path, nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	var body map[string]any
+	_ = json.Unmarshal(resp.Body.Bytes(), &body)
+	return resp, body
+}
+
+func TestCacheAdmin_Stats(t *testing.T) {
+	router, _ := setupCacheAdminTest(t)
+
+	resp, body := serveAdmin(router, http.MethodGet, "/admin/cache/stats")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	data := body["data"].(map[string]any)
+	assert.Equal(t, float64(1), data["misses"])
+	assert.Contains(t, data["by_cacheable"], "user:get")
+}
+
+func TestCacheAdmin_Inspect(t *testing.T) {
+	router, _ := setupCacheAdminTest(t)
+
+	resp, body := serveAdmin(router, http.MethodGet, "/admin/cache/cacheables/user:get/key?arg=1")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	data := body["data"].(map[string]any)
+	assert.Equal(t, "user:1", data["key"])
+	assert.Equal(t, true, data["exists"])
+
+	resp, body = serveAdmin(router, http.MethodGet, "/admin/cache/cacheables/user:get/key?key=user:2")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, false, body["data"].(map[string]any)["exists"])
+
+	resp, _ = serveAdmin(router, http.MethodGet, "/admin/cache/cacheables/user:get/key")
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp, _ = serveAdmin(router, http.MethodGet, "/admin/cache/cacheables/missing/key?key=x")
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+}
+
+func TestCacheAdmin_Purge(t *testing.T) {
+	router, o := setupCacheAdminTest(t)
+	store, _ := o.GetStore("memory")
+	ctx := context.Background()
+
+	resp, _ := serveAdmin(router, http.MethodDelete, "/admin/cache/cacheables/user:get")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.False(t, store.Exists(ctx, "user:1"))
+
+	_, _ = o.Call(ctx, "user:get", 1)
+	resp, body := serveAdmin(router, http.MethodDelete, "/admin/cache/tags/user")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, []any{"user:get"}, body["data"].(map[string]any)["purged"])
+	assert.False(t, store.Exists(ctx, "user:1"))
+
+	_, _ = o.Call(ctx, "user:get", 1)
+	resp, _ = serveAdmin(router, http.MethodDelete, "/admin/cache/stores/memory/keys?prefix=user:")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.False(t, store.Exists(ctx, "user:1"))
+
+	resp, _ = serveAdmin(router, http.MethodDelete, "/admin/cache/stores/memory/keys")
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestRegisterCacheAdminRoutes_NilOrchestrator(t *testing.T) {
+	router := gin.New()
+	RegisterCacheAdminRoutes(router, nil)
+	assert.Empty(t, router.Routes())
+}
@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"github.com/KOMKZ/go-yogan-framework/httpx"
+	"github.com/KOMKZ/go-yogan-framework/limiter"
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+)
+
+// LimiterAdminHandler rate limiter administration HTTP handler
+// Exposes the configured resources and runtime limit updates of the limiter manager.
+// Resource names contain '/' and ':', so they are passed as the name query parameter.
+type LimiterAdminHandler struct {
+	manager *limiter.Manager
+}
+
+// NewLimiterAdminHandler creates a rate limiter administration handler
+func NewLimiterAdminHandler(manager *limiter.Manager) *LimiterAdminHandler {
+	return &LimiterAdminHandler{
+		manager: manager,
+	}
+}
+
+// HandleList returns the configured resources (exact names and patterns)
+// GET /limiter/resources
+func (h *LimiterAdminHandler) HandleList() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		httpx.OkJson(c, h.manager.Resources())
+	}
+}
+
+// HandleResolve returns the configuration and metrics applied to a resource key
+// GET /limiter/resources/resolve?name=GET:/users/:id
+func (h *LimiterAdminHandler) HandleResolve() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Query("name")
+		if name == "" {
+			httpx.ErrorJson(c, "name is required")
+			return
+		}
+		cfg, limited := h.manager.ResolveResource(name)
+		httpx.OkJson(c, gin.H{
+			"resource": name,
+			"limited":  limited,
+			"config":   cfg,
+			"metrics":  h.manager.GetMetrics(name),
+		})
+	}
+}
+
+// HandleUpdate adds or replaces the limits of a resource
+// PUT /limiter/resources?name=GET:/users/* with a body like {"algorithm":"token_bucket","rate":10,"capacity":20}
+// Fields use the configuration file names, durations accept strings like "1m"
+func (h *LimiterAdminHandler) HandleUpdate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Query("name")
+		if name == "" {
+			httpx.ErrorJson(c, "name is required")
+			return
+		}
+
+		var body map[string]any
+		if err := c.ShouldBindJSON(&body); err != nil {
+			httpx.ErrorJson(c, "invalid body: "+err.Error())
+			return
+		}
+		cfg, err := decodeResourceConfig(body)
+		if err != nil {
+			httpx.HandleError(c, limiter.ErrConfigInvalid.Wrap(err))
+			return
+		}
+
+		if err := h.manager.UpdateResource(name, cfg); err != nil {
+			httpx.HandleError(c, err)
+			return
+		}
+		httpx.OkJson(c, gin.H{"updated": name})
+	}
+}
+
+// HandleRemove removes the limits of a resource
+// DELETE /limiter/resources?name=GET:/users/*
+func (h *LimiterAdminHandler) HandleRemove() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Query("name")
+		if name == "" {
+			httpx.ErrorJson(c, "name is required")
+			return
+		}
+		if err := h.manager.RemoveResource(name); err != nil {
+			httpx.HandleError(c, err)
+			return
+		}
+		httpx.OkJson(c, gin.H{"removed": name})
+	}
+}
+
+// decodeResourceConfig decodes a resource configuration like the configuration loader does
+func decodeResourceConfig(body map[string]any) (limiter.ResourceConfig, error) {
+	var cfg limiter.ResourceConfig
+	v := viper.New()
+	if err := v.MergeConfigMap(body); err != nil {
+		return cfg, err
+	}
+	err := v.Unmarshal(&cfg)
+	return cfg, err
+}
+
+// RegisterLimiterAdminRoutes registers rate limiter administration routes
+// Mount on a protected group, e.g. RegisterLimiterAdminRoutes(engine.Group("/admin", auth), manager)
+func RegisterLimiterAdminRoutes(router gin.IRouter, manager *limiter.Manager) {
+	if manager == nil {
+		return
+	}
+
+	handler := NewLimiterAdminHandler(manager)
+
+	router.GET("/limiter/resources", handler.HandleList())
+	router.GET("/limiter/resources/resolve", handler.HandleResolve())
+	router.PUT("/limiter/resources", handler.HandleUpdate())
+	router.DELETE("/limiter/resources", handler.HandleRemove())
+}
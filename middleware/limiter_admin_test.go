@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/KOMKZ/go-yogan-framework/limiter"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupLimiterAdminTest(t *testing.T) (*gin.Engine, *limiter.Manager) {
+	gin.SetMode(gin.TestMode)
+
+	manager, err := limiter.NewManager(limiter.Config{
+		Enabled:   true,
+		StoreType: "memory",
+		Resources: map[string]limiter.ResourceConfig{
+			"GET:/users/*": {Algorithm: "token_bucket", Rate: 10, Capacity: 20},
+		},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { manager.Close() })
+
+	router := gin.New()
+	RegisterLimiterAdminRoutes(router.Group("/admin"), manager)
+	return router, manager
+}
+
+func TestLimiterAdmin_ListAndResolve(t *testing.T) {
+	router, _ := setupLimiterAdminTest(t)
+
+	resp, body := serveAdmin(router, http.MethodGet, "/admin/limiter/resources")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, body["data"], "GET:/users/*")
+
+	resp, body = serveAdmin(router, http.MethodGet, "/admin/limiter/resources/resolve?name=GET:/users/:id")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	data := body["data"].(map[string]any)
+	assert.Equal(t, true, data["limited"])
+
+	resp, _ = serveAdmin(router, http.MethodGet, "/admin/limiter/resources/resolve")
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestLimiterAdmin_UpdateAndRemove(t *testing.T) {
+	router, manager := setupLimiterAdminTest(t)
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/limiter/resources?name=POST:/orders",
+		strings.NewReader(`{"algorithm":"sliding_window","limit":5,"window_size":"1m"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	cfg, ok := manager.ResolveResource("POST:/orders")
+	require.True(t, ok)
+	assert.Equal(t, "sliding_window", cfg.Algorithm)
+	assert.Equal(t, int64(5), cfg.Limit)
+
+	// Invalid configuration is rejected with 400
+	req = httptest.NewRequest(http.MethodPut, "/admin/limiter/resources?name=POST:/orders",
+		strings.NewReader(`{"algorithm":"token_bucket"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+
+	resp, _ = serveAdmin(router, http.MethodDelete, "/admin/limiter/resources?name=POST:/orders")
+	assert.Equal(t, http.StatusOK, resp.Code)
+	_, ok = manager.ResolveResource("POST:/orders")
+	assert.False(t, ok)
+
+	resp, _ = serveAdmin(router, http.MethodDelete, "/admin/limiter/resources?name=POST:/orders")
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
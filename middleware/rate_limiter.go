@@ -1,14 +1,40 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/KOMKZ/go-yogan-framework/httpx"
 	"github.com/KOMKZ/go-yogan-framework/limiter"
 	"github.com/gin-gonic/gin"
 )
 
+// RateLimitHeaderStyle rate limit response header style
+type RateLimitHeaderStyle string
+
+const (
+	// RateLimitHeadersIETF RateLimit-Limit/RateLimit-Remaining/RateLimit-Reset (IETF draft, reset in seconds from now)
+	RateLimitHeadersIETF RateLimitHeaderStyle = "ietf"
+
+	// RateLimitHeadersX X-RateLimit-Limit/X-RateLimit-Remaining/X-RateLimit-Reset (reset as Unix timestamp)
+	RateLimitHeadersX RateLimitHeaderStyle = "x"
+
+	// RateLimitHeadersNone no quota headers (Retry-After is still sent on 429)
+	RateLimitHeadersNone RateLimitHeaderStyle = "none"
+)
+
+// RateLimitResponseKey context key of the rate limiting decision (*limiter.Response) of the current request
+const RateLimitResponseKey = "rate_limit_response"
+
+// RateLimitRuleKey context key of the most restrictive policy rule name of the current request
+const RateLimitRuleKey = "rate_limit_rule"
+
 // RateLimiterConfig rate limiting middleware configuration
 type RateLimiterConfig struct {
 	// Manager Rate Limiter Manager (required)
@@ -20,9 +46,17 @@ type RateLimiterConfig struct {
 	// ErrorHandler custom error handling function (default: log errors but proceed)
 	ErrorHandler func(*gin.Context, error)
 
-	// RateLimitHandler custom rate limiting response function (default: return 429)
+	// RateLimitHandler custom rate limiting response function (default: 429 with limiter.ErrRateLimited via httpx)
+	// The decision is available through GetRateLimitResponse, headers are already set
 	RateLimitHandler func(*gin.Context)
 
+	// HeaderStyle rate limit response headers (default: RateLimitHeadersIETF)
+	HeaderStyle RateLimitHeaderStyle
+
+	// PolicyKeyFuncs custom key extractors of policy rules by extractor name (override built-in extractors)
+	// Used when the manager has policy rules, see RateLimiterPolicyKey for built-in extractors
+	PolicyKeyFuncs map[string]func(*gin.Context) string
+
 	// SkipFunc optional function to skip rate limiting conditions
 	SkipFunc func(*gin.Context) bool
 
@@ -34,23 +68,29 @@ type RateLimiterConfig struct {
 func DefaultRateLimiterConfig(manager *limiter.Manager) RateLimiterConfig {
 	return RateLimiterConfig{
 		Manager: manager,
-		KeyFunc: func(c *gin.Context) string {
-			return fmt.Sprintf("%s:%s", strings.ToLower(c.Request.Method), c.Request.URL.Path)
-		},
+		KeyFunc: RateLimiterKeyByRoute,
 		ErrorHandler: func(c *gin.Context, err error) {
 			// Default: Allow requests through when the rate limiter encounters an internal error (degradation strategy)
 			c.Next()
 		},
-		RateLimitHandler: func(c *gin.Context) {
-			c.JSON(http.StatusTooManyRequests, gin.H{
-				"error":   "Rate limit exceeded",
-				"message": "请求过于频繁，请稍后再试",
-			})
-			c.Abort()
-		},
-		SkipFunc:  nil,
-		SkipPaths: []string{},
+		RateLimitHandler: defaultRateLimitHandler,
+		HeaderStyle:      RateLimitHeadersIETF,
+		SkipFunc:         nil,
+		SkipPaths:        []string{},
+	}
+}
+
+// defaultRateLimitHandler responds 429 with limiter.ErrRateLimited, like other API errors
+func defaultRateLimitHandler(c *gin.Context) {
+	err := limiter.ErrRateLimited
+	if resp, ok := GetRateLimitResponse(c); ok {
+		err = err.WithData("retry_after", retryAfterSeconds(resp.RetryAfter))
+	}
+	if rule := c.GetString(RateLimitRuleKey); rule != "" {
+		err = err.WithData("rule", rule)
 	}
+	httpx.HandleError(c, err)
+	c.Abort()
 }
 
 // Create rate limiting middleware
@@ -85,9 +125,7 @@ func RateLimiterWithConfig(cfg RateLimiterConfig) gin.HandlerFunc {
 
 	// Apply default values
 	if cfg.KeyFunc == nil {
-		cfg.KeyFunc = func(c *gin.Context) string {
-			return fmt.Sprintf("%s:%s", c.Request.Method, c.Request.URL.Path)
-		}
+		cfg.KeyFunc = RateLimiterKeyByRoute
 	}
 
 	if cfg.ErrorHandler == nil {
@@ -97,13 +135,11 @@ func RateLimiterWithConfig(cfg RateLimiterConfig) gin.HandlerFunc {
 	}
 
 	if cfg.RateLimitHandler == nil {
-		cfg.RateLimitHandler = func(c *gin.Context) {
-			c.JSON(http.StatusTooManyRequests, gin.H{
-				"error":   "Rate limit exceeded",
-				"message": "请求过于频繁，请稍后再试",
-			})
-			c.Abort()
-		}
+		cfg.RateLimitHandler = defaultRateLimitHandler
+	}
+
+	if cfg.HeaderStyle == "" {
+		cfg.HeaderStyle = RateLimitHeadersIETF
 	}
 
 	// Build a map for skipping paths (improve lookup performance)
@@ -112,6 +148,12 @@ func RateLimiterWithConfig(cfg RateLimiterConfig) gin.HandlerFunc {
 		skipPathsMap[path] = true
 	}
 
+	// Policy rules replace the single KeyFunc resource
+	var policy []limiter.PolicyRule
+	if cfg.Manager.HasPolicy() {
+		policy = cfg.Manager.GetConfig().Policy
+	}
+
 	return func(c *gin.Context) {
 		// ===========================
 		// Check if the rate limiter is enabled
@@ -138,15 +180,21 @@ func RateLimiterWithConfig(cfg RateLimiterConfig) gin.HandlerFunc {
 		}
 
 		// ===========================
-		// 4. Generate resource keys
-		// ===========================
-		resource := cfg.KeyFunc(c)
-
-		// ===========================
-		// 5. Perform rate limiting check
+		// 4. Perform rate limiting check (single resource or policy rules)
 		// ===========================
-		ctx := c.Request.Context()
-		allowed, err := cfg.Manager.Allow(ctx, resource)
+		var resp *limiter.Response
+		var err error
+		var resource string
+		var policyResult *limiter.PolicyResponse
+		if len(policy) > 0 {
+			policyResult, err = checkPolicy(c, cfg, policy)
+			if policyResult != nil {
+				resp = &policyResult.Response
+			}
+		} else {
+			resource = cfg.KeyFunc(c)
+			resp, err = cfg.Manager.Check(c.Request.Context(), resource, 1)
+		}
 
 		if err != nil {
 			// Rate limiter internal error, execute error handling
@@ -154,19 +202,194 @@ func RateLimiterWithConfig(cfg RateLimiterConfig) gin.HandlerFunc {
 			return
 		}
 
-		if !allowed {
+		c.Set(RateLimitResponseKey, resp)
+		setRateLimitHeaders(c, cfg.HeaderStyle, resp)
+
+		if !resp.Allowed {
 			// Throttling limit reached, execute throttling response
+			c.Header("Retry-After", strconv.FormatInt(retryAfterSeconds(resp.RetryAfter), 10))
 			cfg.RateLimitHandler(c)
 			return
 		}
 
 		// ===========================
-		// 6. Allow passage
+		// 6. Allow passage, permits (concurrency, latency) are returned with the request latency
 		// ===========================
+		start := time.Now()
+		defer completeRequest(c, cfg.Manager, resource, policyResult, start)
 		c.Next()
 	}
 }
 
+// completeRequest returns the permits of a completed request to the limiter with its latency
+// (the resource, or every rule of the policy result when policy rules are used)
+// 503/504 responses and expired deadlines count as dropped (overload), canceled requests are not sampled
+func completeRequest(c *gin.Context, manager *limiter.Manager, resource string, policy *limiter.PolicyResponse, start time.Time) {
+	ctx := context.WithoutCancel(c.Request.Context())
+	if errors.Is(c.Request.Context().Err(), context.Canceled) {
+		if policy == nil {
+			_ = manager.Release(ctx, resource, 1)
+			return
+		}
+		for _, r := range policy.Results {
+			_ = manager.Release(ctx, r.Resource, 1)
+		}
+		return
+	}
+
+	status := c.Writer.Status()
+	dropped := status == http.StatusServiceUnavailable || status == http.StatusGatewayTimeout ||
+		errors.Is(c.Request.Context().Err(), context.DeadlineExceeded)
+	if policy != nil {
+		_ = manager.CompletePolicy(ctx, policy, 1, time.Since(start), dropped)
+		return
+	}
+	_ = manager.Complete(ctx, resource, 1, time.Since(start), dropped)
+}
+
+// checkPolicy extracts the keys of every policy rule and evaluates the policy in one call
+func checkPolicy(c *gin.Context, cfg RateLimiterConfig, policy []limiter.PolicyRule) (*limiter.PolicyResponse, error) {
+	keys := make(map[string]string, len(policy))
+	for _, rule := range policy {
+		if keyFunc, ok := cfg.PolicyKeyFuncs[rule.Key]; ok {
+			keys[rule.Name] = keyFunc(c)
+			continue
+		}
+		keys[rule.Name] = RateLimiterPolicyKey(c, rule.Key)
+	}
+
+	result, err := cfg.Manager.CheckPolicy(c.Request.Context(), keys, 1)
+	if err != nil {
+		return nil, err
+	}
+	if result.Rule != "" {
+		c.Set(RateLimitRuleKey, result.Rule)
+	}
+	return result, nil
+}
+
+// RateLimiterPolicyKey extracts the key of a policy rule with a built-in extractor
+// An empty key skips the rule for the request (e.g., anonymous user)
+//
+// Extractors:
+//   - global: one key shared by every request
+//   - path: method and route pattern (e.g., GET:/api/users/:id)
+//   - ip: client IP
+//   - user: user ID set by the JWT middleware
+//   - tenant: tenant ID of the JWT claims
+//   - api_key: X-API-Key header or api_key query parameter
+//   - header:<name>, query:<name>, context:<key>: value of a request header, query parameter or context key
+func RateLimiterPolicyKey(c *gin.Context, extractor string) string {
+	switch extractor {
+	case "global":
+		return "*"
+	case "path":
+		return RateLimiterKeyByRoute(c)
+	case "ip":
+		return c.ClientIP()
+	case "user":
+		return contextValue(c, "user_id")
+	case "tenant":
+		if claims, ok := GetClaims(c); ok {
+			return claims.TenantID
+		}
+		return ""
+	case "api_key":
+		if apiKey := c.GetHeader("X-API-Key"); apiKey != "" {
+			return apiKey
+		}
+		return c.Query("api_key")
+	}
+
+	source, name, _ := strings.Cut(extractor, ":")
+	switch source {
+	case "header":
+		return c.GetHeader(name)
+	case "query":
+		return c.Query(name)
+	case "context":
+		return contextValue(c, name)
+	}
+	return ""
+}
+
+// contextValue returns a context value as a string (empty if missing)
+func contextValue(c *gin.Context, key string) string {
+	value, exists := c.Get(key)
+	if !exists || value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// GetRateLimitResponse returns the rate limiting decision of the current request
+func GetRateLimitResponse(c *gin.Context) (*limiter.Response, bool) {
+	value, exists := c.Get(RateLimitResponseKey)
+	if !exists {
+		return nil, false
+	}
+	resp, ok := value.(*limiter.Response)
+	return resp, ok
+}
+
+// GetRateLimitRule returns the most restrictive policy rule name of the current request (empty without policy)
+func GetRateLimitRule(c *gin.Context) string {
+	return c.GetString(RateLimitRuleKey)
+}
+
+// setRateLimitHeaders writes quota headers, requests that are not rate limited (zero Limit) get none
+func setRateLimitHeaders(c *gin.Context, style RateLimitHeaderStyle, resp *limiter.Response) {
+	if style == RateLimitHeadersNone || resp.Limit <= 0 {
+		return
+	}
+
+	prefix := "RateLimit-"
+	if style == RateLimitHeadersX {
+		prefix = "X-RateLimit-"
+	}
+
+	c.Header(prefix+"Limit", strconv.FormatInt(resp.Limit, 10))
+	c.Header(prefix+"Remaining", strconv.FormatInt(max(resp.Remaining, 0), 10))
+
+	// Concurrency limits have no reset time
+	if resp.ResetAt.IsZero() {
+		return
+	}
+	if style == RateLimitHeadersX {
+		c.Header(prefix+"Reset", strconv.FormatInt(resp.ResetAt.Unix(), 10))
+		return
+	}
+	c.Header(prefix+"Reset", strconv.FormatInt(ceilSeconds(time.Until(resp.ResetAt)), 10))
+}
+
+// retryAfterSeconds converts a retry delay to whole seconds for Retry-After (at least 1)
+func retryAfterSeconds(d time.Duration) int64 {
+	return max(ceilSeconds(d), 1)
+}
+
+// ceilSeconds rounds a duration up to whole seconds (negative durations give 0)
+func ceilSeconds(d time.Duration) int64 {
+	if d <= 0 {
+		return 0
+	}
+	return int64(math.Ceil(d.Seconds()))
+}
+
+// RateLimiterKeyByRoute generates resource keys from the method and the route pattern (default)
+// e.g., GET /users/42 on route /users/:id gives "GET:/users/:id", so one limiter covers the route.
+// Requests without a matched route (404) use the request path.
+func RateLimiterKeyByRoute(c *gin.Context) string {
+	return fmt.Sprintf("%s:%s", c.Request.Method, routePath(c))
+}
+
+// routePath returns the matched route pattern, or the request path when no route matched
+func routePath(c *gin.Context) string {
+	if fullPath := c.FullPath(); fullPath != "" {
+		return fullPath
+	}
+	return c.Request.URL.Path
+}
+
 // RateLimiterKeyByIP generates resource keys based on client IP
 // Used for IP rate limiting
 func RateLimiterKeyByIP(c *gin.Context) string {
@@ -192,7 +415,7 @@ func RateLimiterKeyByUser(userIDKey string) func(*gin.Context) string {
 // RateLimiterKeyByPathAndIP generates a resource key based on path and IP
 // For rate limiting by path+IP combination
 func RateLimiterKeyByPathAndIP(c *gin.Context) string {
-	return fmt.Sprintf("%s:%s:%s", c.Request.Method, c.Request.URL.Path, c.ClientIP())
+	return fmt.Sprintf("%s:%s:%s", c.Request.Method, routePath(c), c.ClientIP())
 }
 
 // RateLimiterKeyByAPIKey generates resource keys based on API key
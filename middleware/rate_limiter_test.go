@@ -28,7 +28,7 @@ func setupRateLimiterTest() (*gin.Engine, *limiter.Manager) {
 			Capacity:  10,
 		},
 		Resources: map[string]limiter.ResourceConfig{
-			"get:/api/limited": {
+			"GET:/api/limited": {
 				Algorithm:  "token_bucket",
 				Rate:       2, // 2 req/s
 				Capacity:   2, // Up to 2 requests
@@ -95,7 +95,7 @@ func TestRateLimiter_RateLimited(t *testing.T) {
 	resp := httptest.NewRecorder()
 	router.ServeHTTP(resp, req)
 	assert.Equal(t, http.StatusTooManyRequests, resp.Code)
-	assert.Contains(t, resp.Body.String(), "Rate limit exceeded")
+	assert.Contains(t, resp.Body.String(), `"code":710001`)
 }
 
 func TestRateLimiter_Disabled(t *testing.T) {
@@ -359,3 +359,264 @@ func TestRateLimiter_PanicOnNilManager(t *testing.T) {
 	})
 }
 
+
+func TestRateLimiter_IETFHeaders(t *testing.T) {
+	router, manager := setupRateLimiterTest()
+	defer manager.Close()
+
+	router.Use(RateLimiter(manager))
+	router.GET("/api/limited", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	req := httptest.NewRequest("GET", "/api/limited", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "2", resp.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", resp.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "1", resp.Header().Get("RateLimit-Reset"))
+	assert.Empty(t, resp.Header().Get("Retry-After"))
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/limited", nil))
+
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest("GET", "/api/limited", nil))
+	require.Equal(t, http.StatusTooManyRequests, resp.Code)
+	assert.Equal(t, "0", resp.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "1", resp.Header().Get("Retry-After"))
+	assert.Contains(t, resp.Body.String(), `"retry_after":1`)
+}
+
+func TestRateLimiter_XHeaders(t *testing.T) {
+	router, manager := setupRateLimiterTest()
+	defer manager.Close()
+
+	cfg := DefaultRateLimiterConfig(manager)
+	cfg.HeaderStyle = RateLimitHeadersX
+	router.Use(RateLimiterWithConfig(cfg))
+	router.GET("/api/limited", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	req := httptest.NewRequest("GET", "/api/limited", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "2", resp.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", resp.Header().Get("X-RateLimit-Remaining"))
+	assert.NotEmpty(t, resp.Header().Get("X-RateLimit-Reset"))
+	assert.Empty(t, resp.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimiter_NoHeadersWhenNotLimited(t *testing.T) {
+	router := gin.New()
+	manager, err := limiter.NewManager(limiter.Config{Enabled: true, StoreType: "memory"})
+	require.NoError(t, err)
+	defer manager.Close()
+
+	router.Use(RateLimiter(manager))
+	router.GET("/api/free", func(c *gin.Context) {
+		rateLimit, ok := GetRateLimitResponse(c)
+		assert.True(t, ok)
+		assert.True(t, rateLimit.Allowed)
+		c.Status(http.StatusOK)
+	})
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest("GET", "/api/free", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, resp.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimiter_Policy(t *testing.T) {
+	router := gin.New()
+	manager, err := limiter.NewManager(limiter.Config{
+		Enabled:   true,
+		StoreType: "memory",
+		Policy: []limiter.PolicyRule{
+			{Name: "route", Key: "path", ResourceConfig: limiter.ResourceConfig{Rate: 1, Capacity: 100}},
+			{Name: "api_key_daily", Key: "api_key", ResourceConfig: limiter.ResourceConfig{Limit: 2, Period: limiter.PeriodDay}},
+		},
+	})
+	require.NoError(t, err)
+	defer manager.Close()
+
+	router.Use(RateLimiter(manager))
+	router.GET("/api/data", func(c *gin.Context) {
+		c.String(http.StatusOK, GetRateLimitRule(c))
+	})
+
+	request := func(apiKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/data", nil)
+		if apiKey != "" {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	// The daily quota is the most restrictive rule
+	resp := request("k1")
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "api_key_daily", resp.Body.String())
+	assert.Equal(t, "2", resp.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", resp.Header().Get("RateLimit-Remaining"))
+
+	require.Equal(t, http.StatusOK, request("k1").Code)
+
+	resp = request("k1")
+	require.Equal(t, http.StatusTooManyRequests, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"rule":"api_key_daily"`)
+	assert.NotEmpty(t, resp.Header().Get("Retry-After"))
+
+	// Requests without an API key only hit the route rule
+	resp = request("")
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "route", resp.Body.String())
+}
+
+func TestRateLimiter_PolicyReleasesPermits(t *testing.T) {
+	router := gin.New()
+	manager, err := limiter.NewManager(limiter.Config{
+		Enabled:   true,
+		StoreType: "memory",
+		Policy: []limiter.PolicyRule{
+			{Name: "inflight", Key: "global", ResourceConfig: limiter.ResourceConfig{Algorithm: "concurrency", MaxConcurrency: 1}},
+		},
+	})
+	require.NoError(t, err)
+	defer manager.Close()
+
+	var inner int
+	router.Use(RateLimiter(manager))
+	router.GET("/api/data", func(c *gin.Context) {
+		if c.Query("nested") == "" {
+			// The permit is held while the request runs
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, httptest.NewRequest("GET", "/api/data?nested=1", nil))
+			inner = resp.Code
+		}
+		c.Status(http.StatusOK)
+	})
+
+	// The permit is returned when the request completes
+	for i := 0; i < 3; i++ {
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, httptest.NewRequest("GET", "/api/data", nil))
+		require.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, http.StatusTooManyRequests, inner)
+	}
+}
+
+func TestRateLimiter_PolicyKeyExtractors(t *testing.T) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("POST", "/api/orders?tenant=t1&api_key=q1", nil)
+	c.Request.Header.Set("X-Region", "eu")
+	c.Set("user_id", int64(42))
+
+	assert.Equal(t, "*", RateLimiterPolicyKey(c, "global"))
+	assert.Equal(t, "POST:/api/orders", RateLimiterPolicyKey(c, "path"))
+	assert.Equal(t, "42", RateLimiterPolicyKey(c, "user"))
+	assert.Equal(t, "q1", RateLimiterPolicyKey(c, "api_key"))
+	assert.Equal(t, "eu", RateLimiterPolicyKey(c, "header:X-Region"))
+	assert.Equal(t, "t1", RateLimiterPolicyKey(c, "query:tenant"))
+	assert.Equal(t, "42", RateLimiterPolicyKey(c, "context:user_id"))
+	assert.Empty(t, RateLimiterPolicyKey(c, "tenant"))
+	assert.Empty(t, RateLimiterPolicyKey(c, "unknown"))
+}
+
+func TestRateLimiter_KeyByRoutePattern(t *testing.T) {
+	router := gin.New()
+	manager, err := limiter.NewManager(limiter.Config{
+		Enabled:   true,
+		StoreType: "memory",
+		Resources: map[string]limiter.ResourceConfig{
+			"GET:/users/*": {Algorithm: "fixed_window", Limit: 2, WindowSize: time.Hour},
+		},
+	})
+	require.NoError(t, err)
+	defer manager.Close()
+
+	router.Use(RateLimiter(manager))
+	router.GET("/users/:id", func(c *gin.Context) {
+		c.String(http.StatusOK, RateLimiterKeyByRoute(c))
+	})
+
+	// Different IDs share the limiter of the route pattern
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest("GET", "/users/1", nil))
+	require.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "GET:/users/:id", resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest("GET", "/users/2", nil))
+	require.Equal(t, http.StatusOK, resp.Code)
+
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest("GET", "/users/3", nil))
+	assert.Equal(t, http.StatusTooManyRequests, resp.Code)
+
+	// Runtime update raises the limit of the route without dropping its state
+	require.NoError(t, manager.UpdateResource("GET:/users/*", limiter.ResourceConfig{Algorithm: "fixed_window", Limit: 3, WindowSize: time.Hour}))
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest("GET", "/users/4", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "3", resp.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimiter_LatencyAlgorithm(t *testing.T) {
+	router := gin.New()
+	manager, err := limiter.NewManager(limiter.Config{
+		Enabled:   true,
+		StoreType: "memory",
+		Resources: map[string]limiter.ResourceConfig{
+			"GET:/slow": {Algorithm: "latency", MinLimit: 1, InitLimit: 2, SampleWindow: time.Nanosecond},
+		},
+	})
+	require.NoError(t, err)
+	defer manager.Close()
+
+	router.Use(RateLimiter(manager))
+	inflight := make(chan struct{})
+	router.GET("/slow", func(c *gin.Context) {
+		if c.Query("block") != "" {
+			<-inflight
+		}
+		if c.Query("fail") != "" {
+			c.Status(http.StatusServiceUnavailable)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	// Permits are held while requests are in flight
+	done := make(chan int, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, httptest.NewRequest("GET", "/slow?block=1", nil))
+			done <- resp.Code
+		}()
+	}
+	require.Eventually(t, func() bool {
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, httptest.NewRequest("GET", "/slow", nil))
+		return resp.Code == http.StatusTooManyRequests
+	}, time.Second, 5*time.Millisecond)
+
+	// Completed requests return their permits
+	close(inflight)
+	assert.Equal(t, http.StatusOK, <-done)
+	assert.Equal(t, http.StatusOK, <-done)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest("GET", "/slow", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+
+	// 503 responses count as dropped and shrink the limit
+	for i := 0; i < 10; i++ {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/slow?fail=1", nil))
+	}
+	assert.Equal(t, map[string]int64{"GET:/slow": 1}, manager.CurrentLimits())
+}
@@ -0,0 +1,379 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/cache"
+	"github.com/gin-gonic/gin"
+)
+
+// ResponseCacheConfig HTTP response caching middleware configuration
+type ResponseCacheConfig struct {
+	// Orchestrator cache orchestration center (required)
+	Orchestrator *cache.DefaultOrchestrator
+
+	// Name cache item name (required), its key_pattern/ttl/store apply and its invalidation rules drop cached pages
+	// Example: cacheable {name: "http:article", key_pattern: "http:article:{0}"} with route /articles/:id
+	Name string
+
+	// ArgsFunc extracts key pattern arguments from the request (default: route parameter values in order)
+	// Must produce the same values as the CacheArgs of invalidation events
+	ArgsFunc func(*gin.Context) []any
+
+	// VaryByQuery whether the query string is part of the key (default true)
+	VaryByQuery bool
+
+	// VaryByHeaders request headers that are part of the key, also sent as the Vary response header
+	VaryByHeaders []string
+
+	// VaryByUser context key of the user ID, responses are cached per user when set (e.g., "user_id")
+	// When empty, requests carrying an Authorization header bypass the cache; "private" responses are
+	// only stored for requests with a resolved user
+	VaryByUser string
+
+	// StatusCodes cacheable response status codes (default [200])
+	StatusCodes []int
+
+	// MaxBodySize maximum cached body size in bytes (default 1MB)
+	MaxBodySize int
+
+	// SkipFunc optional function to skip caching
+	SkipFunc func(*gin.Context) bool
+}
+
+// DefaultResponseCacheConfig default response caching configuration
+func DefaultResponseCacheConfig(orchestrator *cache.DefaultOrchestrator, name string) ResponseCacheConfig {
+	return ResponseCacheConfig{
+		Orchestrator: orchestrator,
+		Name:         name,
+		ArgsFunc:     ResponseCacheArgsByParams,
+		VaryByQuery:  true,
+		StatusCodes:  []int{http.StatusOK},
+		MaxBodySize:  1 << 20,
+	}
+}
+
+// cachedResponse cached HTTP response
+type cachedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+	ETag   string      `json:"etag"`
+}
+
+// ResponseCache creates HTTP response caching middleware
+//
+// Function:
+// - Cache whole GET responses in the store of a configured cache item
+// - Generate ETag and answer If-None-Match with 304
+// - Honor request/response Cache-Control and response Vary
+// - Cached pages are dropped by the cache item's invalidation rules
+//
+// Usage:
+//
+//	articles.GET("/:id", middleware.ResponseCache(orchestrator, "http:article"), handler)
+//
+// Note: not suitable for streaming responses (the body is buffered)
+func ResponseCache(orchestrator *cache.DefaultOrchestrator, name string) gin.HandlerFunc {
+	return ResponseCacheWithConfig(DefaultResponseCacheConfig(orchestrator, name))
+}
+
+// ResponseCacheWithConfig creates HTTP response caching middleware with custom configuration
+func ResponseCacheWithConfig(cfg ResponseCacheConfig) gin.HandlerFunc {
+	if cfg.Orchestrator == nil {
+		panic("ResponseCacheConfig.Orchestrator cannot be nil")
+	}
+	if cfg.Name == "" {
+		panic("ResponseCacheConfig.Name cannot be empty")
+	}
+	// Entries vary by request, invalidation must drop every variant
+	if err := cfg.Orchestrator.EnableVariants(cfg.Name); err != nil {
+		panic(fmt.Sprintf("ResponseCacheConfig.Name: %v", err))
+	}
+
+	// Apply default values
+	if cfg.ArgsFunc == nil {
+		cfg.ArgsFunc = ResponseCacheArgsByParams
+	}
+	if len(cfg.StatusCodes) == 0 {
+		cfg.StatusCodes = []int{http.StatusOK}
+	}
+	if cfg.MaxBodySize <= 0 {
+		cfg.MaxBodySize = 1 << 20
+	}
+
+	statusSet := make(map[int]bool, len(cfg.StatusCodes))
+	for _, code := range cfg.StatusCodes {
+		statusSet[code] = true
+	}
+	varyHeaders := make(map[string]bool, len(cfg.VaryByHeaders))
+	for _, h := range cfg.VaryByHeaders {
+		varyHeaders[http.CanonicalHeaderKey(h)] = true
+	}
+	varyValue := strings.Join(cfg.VaryByHeaders, ", ")
+
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+		if cfg.SkipFunc != nil && cfg.SkipFunc(c) {
+			c.Next()
+			return
+		}
+		// Authenticated responses must not be shared between users
+		if cfg.VaryByUser == "" && c.GetHeader("Authorization") != "" {
+			c.Next()
+			return
+		}
+
+		store, baseKey, ttl, err := cfg.Orchestrator.ResolveKey(cfg.Name, cfg.ArgsFunc(c)...)
+		if err != nil {
+			// Cache disabled or unavailable, serve directly
+			c.Next()
+			return
+		}
+		key := baseKey + ":" + responseCacheVariant(c, cfg)
+
+		reqDirectives := parseCacheControl(c.GetHeader("Cache-Control"))
+		_, noStore := reqDirectives["no-store"]
+		_, noCache := reqDirectives["no-cache"]
+
+		// ===========================
+		// 1. Serve from cache
+		// ===========================
+		if !noStore && !noCache {
+			if data, err := store.Get(c.Request.Context(), key); err == nil {
+				var entry cachedResponse
+				if json.Unmarshal(data, &entry) == nil {
+					writeCachedResponse(c, &entry, "HIT")
+					return
+				}
+			}
+		}
+
+		// ===========================
+		// 2. Buffer the handler response
+		// ===========================
+		original := c.Writer
+		writer := &responseCacheWriter{ResponseWriter: original}
+		c.Writer = writer
+		c.Next()
+		c.Writer = original
+
+		status := writer.Status()
+		entry := &cachedResponse{
+			Status: status,
+			Header: original.Header().Clone(),
+			Body:   writer.body.Bytes(),
+			ETag:   original.Header().Get("ETag"),
+		}
+		if entry.ETag == "" && status == http.StatusOK {
+			entry.ETag = generateETag(entry.Body)
+			entry.Header.Set("ETag", entry.ETag)
+		}
+
+		// ===========================
+		// 3. Store when cacheable
+		// ===========================
+		storeTTL, cacheable := responseCacheTTL(entry, ttl, statusSet, varyHeaders, cfg, noStore, responseCacheUser(c, cfg) != nil)
+		if varyValue != "" && entry.Header.Get("Vary") == "" {
+			entry.Header.Set("Vary", varyValue)
+		}
+		if cacheable {
+			delete(entry.Header, "Date")
+			if data, err := json.Marshal(entry); err == nil {
+				_ = store.Set(c.Request.Context(), key, data, storeTTL)
+			}
+		}
+
+		writeCachedResponse(c, entry, "MISS")
+	}
+}
+
+// responseCacheTTL decides whether the response can be stored and for how long
+// perUser reports whether the key holds a resolved user, the only case where "private" responses are stored
+func responseCacheTTL(entry *cachedResponse, ttl time.Duration, statusSet map[int]bool, varyHeaders map[string]bool, cfg ResponseCacheConfig, noStore, perUser bool) (time.Duration, bool) {
+	if noStore || !statusSet[entry.Status] || len(entry.Body) > cfg.MaxBodySize {
+		return 0, false
+	}
+	if entry.Header.Get("Set-Cookie") != "" {
+		return 0, false
+	}
+
+	directives := parseCacheControl(entry.Header.Get("Cache-Control"))
+	if _, ok := directives["no-store"]; ok {
+		return 0, false
+	}
+	if _, ok := directives["private"]; ok && !perUser {
+		return 0, false
+	}
+	// Shared cache lifetime: s-maxage takes precedence over max-age, both can only shorten the TTL
+	for _, name := range []string{"s-maxage", "max-age"} {
+		if v, ok := directives[name]; ok {
+			seconds, err := strconv.Atoi(v)
+			if err != nil || seconds <= 0 {
+				return 0, false
+			}
+			if d := time.Duration(seconds) * time.Second; d < ttl {
+				ttl = d
+			}
+			break
+		}
+	}
+
+	// Vary on headers that are not part of the key would mix variants
+	for _, v := range entry.Header.Values("Vary") {
+		for _, h := range strings.Split(v, ",") {
+			h = http.CanonicalHeaderKey(strings.TrimSpace(h))
+			if h == "" {
+				continue
+			}
+			if h == "*" || !varyHeaders[h] {
+				return 0, false
+			}
+		}
+	}
+	return ttl, true
+}
+
+// writeCachedResponse writes a response, answering If-None-Match with 304
+func writeCachedResponse(c *gin.Context, entry *cachedResponse, cacheStatus string) {
+	header := c.Writer.Header()
+	for k, v := range entry.Header {
+		header[k] = v
+	}
+	header.Set("X-Cache", cacheStatus)
+
+	if entry.ETag != "" && etagMatch(c.GetHeader("If-None-Match"), entry.ETag) {
+		header.Del("Content-Length")
+		header.Del("Content-Type")
+		c.Status(http.StatusNotModified)
+		c.Writer.WriteHeaderNow()
+		c.Abort()
+		return
+	}
+
+	c.Status(entry.Status)
+	c.Writer.WriteHeaderNow()
+	if len(entry.Body) > 0 {
+		_, _ = c.Writer.Write(entry.Body)
+	}
+	c.Abort()
+}
+
+// responseCacheVariant builds the variant part of the key from path, query, headers and user
+func responseCacheVariant(c *gin.Context, cfg ResponseCacheConfig) string {
+	h := sha256.New()
+	h.Write([]byte(c.Request.URL.Path))
+	if cfg.VaryByQuery {
+		// Encode sorts by key, so parameter order does not create new variants
+		h.Write([]byte("?" + c.Request.URL.Query().Encode()))
+	}
+	for _, name := range cfg.VaryByHeaders {
+		h.Write([]byte("\n" + name + ":" + c.GetHeader(name)))
+	}
+	if userID := responseCacheUser(c, cfg); userID != nil {
+		h.Write([]byte(fmt.Sprintf("\nuser:%v", userID)))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:32]
+}
+
+// responseCacheUser returns the user ID of the request, nil when VaryByUser is not set or the user is not resolved
+func responseCacheUser(c *gin.Context, cfg ResponseCacheConfig) any {
+	if cfg.VaryByUser == "" {
+		return nil
+	}
+	userID, _ := c.Get(cfg.VaryByUser)
+	return userID
+}
+
+// ResponseCacheArgsByParams uses route parameter values (in route declaration order) as key arguments
+func ResponseCacheArgsByParams(c *gin.Context) []any {
+	args := make([]any, 0, len(c.Params))
+	for _, p := range c.Params {
+		args = append(args, p.Value)
+	}
+	return args
+}
+
+// generateETag generates a strong ETag from the body
+func generateETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatch checks If-None-Match against the ETag (weak comparison, RFC 9110)
+func etagMatch(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	target := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == target {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCacheControl parses Cache-Control directives into lower-case names and values
+func parseCacheControl(value string) map[string]string {
+	directives := make(map[string]string)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, val, _ := strings.Cut(part, "=")
+		directives[strings.ToLower(strings.TrimSpace(name))] = strings.Trim(strings.TrimSpace(val), `"`)
+	}
+	return directives
+}
+
+// responseCacheWriter buffers the response so it can be stored before being sent
+type responseCacheWriter struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *responseCacheWriter) WriteHeader(code int) {
+	if code > 0 {
+		w.status = code
+	}
+}
+
+func (w *responseCacheWriter) WriteHeaderNow() {}
+
+func (w *responseCacheWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *responseCacheWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *responseCacheWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+func (w *responseCacheWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *responseCacheWriter) Written() bool {
+	return w.status != 0 || w.body.Len() > 0
+}
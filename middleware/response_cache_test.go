@@ -0,0 +1,259 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/cache"
+	"github.com/KOMKZ/go-yogan-framework/event"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type articleUpdatedEvent struct {
+	id int
+}
+
+func (e *articleUpdatedEvent) Name() string     { return "article.updated" }
+func (e *articleUpdatedEvent) CacheArgs() []any { return []any{e.id} }
+
+func setupResponseCacheTest(t *testing.T, dispatcher event.Dispatcher) (*cache.DefaultOrchestrator, *int32, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &cache.Config{
+		Enabled:      true,
+		DefaultStore: "memory",
+		Cacheables: []cache.CacheableConfig{
+			{Name: "http:article", KeyPattern: "http:article:{0}", TTL: time.Minute, Store: "memory", Enabled: true},
+		},
+		InvalidationRules: []cache.InvalidationRule{
+			{Event: "article.updated", Invalidate: []string{"http:article"}},
+		},
+	}
+	o := cache.NewOrchestrator(cfg, dispatcher, nil)
+	o.RegisterStore("memory", cache.NewMemoryStore("memory", 100))
+
+	var calls int32
+	router := gin.New()
+	router.GET("/articles/:id", ResponseCache(o, "http:article"), func(c *gin.Context) {
+		atomic.AddInt32(&calls, 1)
+		c.JSON(http.StatusOK, gin.H{"id": c.Param("id"), "q": c.Query("q")})
+	})
+	return o, &calls, router
+}
+
+func doGet(router *gin.Engine, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	return resp
+}
+
+func TestResponseCache_HitAndMiss(t *testing.T) {
+	_, calls, router := setupResponseCacheTest(t, nil)
+
+	first := doGet(router, "/articles/1", nil)
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "MISS", first.Header().Get("X-Cache"))
+	assert.NotEmpty(t, first.Header().Get("ETag"))
+
+	second := doGet(router, "/articles/1", nil)
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, "HIT", second.Header().Get("X-Cache"))
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, "application/json; charset=utf-8", second.Header().Get("Content-Type"))
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+
+	// Query is part of the key
+	doGet(router, "/articles/1?q=x", nil)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestResponseCache_IfNoneMatch(t *testing.T) {
+	_, _, router := setupResponseCacheTest(t, nil)
+
+	first := doGet(router, "/articles/1", nil)
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	resp := doGet(router, "/articles/1", map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusNotModified, resp.Code)
+	assert.Empty(t, resp.Body.String())
+
+	resp = doGet(router, "/articles/1", map[string]string{"If-None-Match": `W/"other"`})
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestResponseCache_CacheControl(t *testing.T) {
+	_, calls, router := setupResponseCacheTest(t, nil)
+
+	doGet(router, "/articles/1", map[string]string{"Cache-Control": "no-store"})
+	doGet(router, "/articles/1", nil)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls), "no-store request must not be cached")
+
+	resp := doGet(router, "/articles/1", map[string]string{"Cache-Control": "no-cache"})
+	assert.Equal(t, "MISS", resp.Header().Get("X-Cache"))
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+}
+
+func TestResponseCache_ResponseNotCacheable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &cache.Config{
+		Enabled: true,
+		Cacheables: []cache.CacheableConfig{
+			{Name: "http:page", KeyPattern: "http:page", Store: "memory", Enabled: true},
+		},
+	}
+	o := cache.NewOrchestrator(cfg, nil, nil)
+	o.RegisterStore("memory", cache.NewMemoryStore("memory", 100))
+
+	var calls int32
+	router := gin.New()
+	router.GET("/private", ResponseCache(o, "http:page"), func(c *gin.Context) {
+		atomic.AddInt32(&calls, 1)
+		c.Header("Cache-Control", "private")
+		c.String(http.StatusOK, "secret")
+	})
+	router.GET("/vary", ResponseCache(o, "http:page"), func(c *gin.Context) {
+		atomic.AddInt32(&calls, 1)
+		c.Header("Vary", "Accept-Language")
+		c.String(http.StatusOK, "hello")
+	})
+	router.GET("/missing", ResponseCache(o, "http:page"), func(c *gin.Context) {
+		atomic.AddInt32(&calls, 1)
+		c.String(http.StatusNotFound, "not found")
+	})
+
+	for _, path := range []string{"/private", "/vary", "/missing"} {
+		doGet(router, path, nil)
+		resp := doGet(router, path, nil)
+		assert.Equal(t, "MISS", resp.Header().Get("X-Cache"), path)
+	}
+	assert.Equal(t, int32(6), atomic.LoadInt32(&calls))
+}
+
+func TestResponseCache_VaryByHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &cache.Config{
+		Enabled: true,
+		Cacheables: []cache.CacheableConfig{
+			{Name: "http:page", KeyPattern: "http:page", Store: "memory", Enabled: true},
+		},
+	}
+	o := cache.NewOrchestrator(cfg, nil, nil)
+	o.RegisterStore("memory", cache.NewMemoryStore("memory", 100))
+
+	rcCfg := DefaultResponseCacheConfig(o, "http:page")
+	rcCfg.VaryByHeaders = []string{"Accept-Language"}
+
+	var calls int32
+	router := gin.New()
+	router.GET("/page", ResponseCacheWithConfig(rcCfg), func(c *gin.Context) {
+		atomic.AddInt32(&calls, 1)
+		c.String(http.StatusOK, c.GetHeader("Accept-Language"))
+	})
+
+	en := doGet(router, "/page", map[string]string{"Accept-Language": "en"})
+	zh := doGet(router, "/page", map[string]string{"Accept-Language": "zh"})
+	enAgain := doGet(router, "/page", map[string]string{"Accept-Language": "en"})
+
+	assert.Equal(t, "en", en.Body.String())
+	assert.Equal(t, "zh", zh.Body.String())
+	assert.Equal(t, "en", enAgain.Body.String())
+	assert.Equal(t, "HIT", enAgain.Header().Get("X-Cache"))
+	assert.Equal(t, "Accept-Language", enAgain.Header().Get("Vary"))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestResponseCache_EventInvalidation(t *testing.T) {
+	dispatcher := event.NewDispatcher()
+	defer dispatcher.Close()
+
+	_, calls, router := setupResponseCacheTest(t, dispatcher)
+
+	doGet(router, "/articles/1", nil)
+	doGet(router, "/articles/1?q=x", nil)
+	doGet(router, "/articles/2", nil)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+
+	require.NoError(t, dispatcher.Dispatch(context.Background(), &articleUpdatedEvent{id: 1}))
+
+	// Every variant of article 1 is dropped, article 2 stays cached
+	assert.Equal(t, "MISS", doGet(router, "/articles/1", nil).Header().Get("X-Cache"))
+	assert.Equal(t, "MISS", doGet(router, "/articles/1?q=x", nil).Header().Get("X-Cache"))
+	assert.Equal(t, "HIT", doGet(router, "/articles/2", nil).Header().Get("X-Cache"))
+}
+
+func TestResponseCache_NonGetPassThrough(t *testing.T) {
+	_, calls, router := setupResponseCacheTest(t, nil)
+	router.POST("/articles/:id", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/articles/1", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	assert.Equal(t, int32(0), atomic.LoadInt32(calls))
+}
+
+func TestResponseCache_PanicsOnUnknownCacheable(t *testing.T) {
+	o := cache.NewOrchestrator(&cache.Config{Enabled: true}, nil, nil)
+	assert.Panics(t, func() { ResponseCache(o, "missing") })
+	assert.Panics(t, func() { ResponseCache(nil, "missing") })
+}
+
+func TestResponseCache_AuthenticatedRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := &cache.Config{
+		Enabled: true,
+		Cacheables: []cache.CacheableConfig{
+			{Name: "http:page", KeyPattern: "http:page", Store: "memory", Enabled: true},
+		},
+	}
+	o := cache.NewOrchestrator(cfg, nil, nil)
+	o.RegisterStore("memory", cache.NewMemoryStore("memory", 100))
+
+	perUser := DefaultResponseCacheConfig(o, "http:page")
+	perUser.VaryByUser = "user_id"
+
+	var calls int32
+	router := gin.New()
+	router.GET("/shared", ResponseCache(o, "http:page"), func(c *gin.Context) {
+		atomic.AddInt32(&calls, 1)
+		c.String(http.StatusOK, c.GetHeader("Authorization"))
+	})
+	router.GET("/me", func(c *gin.Context) {
+		if user := c.GetHeader("X-User"); user != "" {
+			c.Set("user_id", user)
+		}
+	}, ResponseCacheWithConfig(perUser), func(c *gin.Context) {
+		atomic.AddInt32(&calls, 1)
+		c.Header("Cache-Control", "private")
+		c.String(http.StatusOK, c.GetHeader("X-User"))
+	})
+
+	// Without VaryByUser, authenticated requests bypass the shared cache
+	doGet(router, "/shared", map[string]string{"Authorization": "Bearer alice"})
+	resp := doGet(router, "/shared", map[string]string{"Authorization": "Bearer bob"})
+	assert.Equal(t, "Bearer bob", resp.Body.String())
+	assert.Empty(t, resp.Header().Get("X-Cache"))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	// Private responses are cached per resolved user only
+	doGet(router, "/me", map[string]string{"X-User": "alice"})
+	resp = doGet(router, "/me", map[string]string{"X-User": "alice"})
+	assert.Equal(t, "HIT", resp.Header().Get("X-Cache"))
+	doGet(router, "/me", nil)
+	resp = doGet(router, "/me", nil)
+	assert.Equal(t, "MISS", resp.Header().Get("X-Cache"), "private responses without a user are not shared")
+	assert.Equal(t, int32(5), atomic.LoadInt32(&calls))
+}
@@ -71,6 +71,31 @@ func (b *BudgetManager) Record(success bool) {
 	}
 }
 
+// RecordRequest counts an original request without an outcome (hedged calls)
+func (b *BudgetManager) RecordRequest() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	
+	b.maybeResetWindow()
+	b.requests++
+}
+
+// Acquire spends budget on an extra attempt (a hedge) when some is left
+// return true to indicate the attempt may start, false to indicate budget depleted
+func (b *BudgetManager) Acquire() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	
+	b.maybeResetWindow()
+	
+	maxRetries := int64(float64(b.requests) * b.ratio)
+	if b.retries >= maxRetries {
+		return false
+	}
+	b.retries++
+	return true
+}
+
 // GetStats Retrieve budget statistics
 func (b *BudgetManager) GetStats() BudgetStats {
 	b.mu.Lock()
@@ -178,13 +178,19 @@ func (c *retryOnHTTPStatus) ShouldRetry(err error, attempt int) bool {
 	}
 	
 	// Try to convert to HTTPError
-	httpErr, ok := err.(HTTPError)
-	if !ok {
-		return false
+	if httpErr, ok := err.(HTTPError); ok {
+		_, shouldRetry := c.statuses[httpErr.StatusCode()]
+		return shouldRetry
 	}
 	
-	_, shouldRetry := c.statuses[httpErr.StatusCode()]
-	return shouldRetry
+	// Errors exposing the status as HTTPStatus (httpclient.StatusError)
+	var withStatus interface{ HTTPStatus() int }
+	if errors.As(err, &withStatus) {
+		_, shouldRetry := c.statuses[withStatus.HTTPStatus()]
+		return shouldRetry
+	}
+	
+	return false
 }
 
 // ============================================================
@@ -71,5 +71,8 @@ var (
 	
 	// ErrBudgetExhausted retry budget exhausted
 	ErrBudgetExhausted = errors.New("retry: budget exhausted")
+	
+	// ErrPolicyNotFound retry policy not configured
+	ErrPolicyNotFound = errors.New("retry: policy not found")
 )
 
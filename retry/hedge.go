@@ -0,0 +1,288 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// HedgePolicy hedged requests policy
+//
+// When an attempt has not finished after the hedge delay, another attempt is started in parallel.
+// The first success wins and the other attempts are cancelled. The delay is fixed, or taken from a
+// percentile of the observed latencies (e.g., p95) once enough calls have been observed.
+// A policy keeps its latency statistics, so it should be created once and shared by the calls of an operation.
+// Only hedge idempotent operations.
+type HedgePolicy struct {
+	maxAttempts int            // Maximum number of parallel attempts, including the first one (default 2)
+	delay       time.Duration  // Fixed delay, or the delay until enough latencies are observed (default 100ms)
+	percentile  float64        // Latency percentile used as the delay (0 = fixed delay)
+	minSamples  int            // Latencies required before the percentile is used (default 20)
+	condition   RetryCondition // Failures that start the next attempt immediately (others end the call)
+	budget      *BudgetManager // Budget shared with retries (optional)
+	latencies   *latencyTracker
+}
+
+// HedgeOption hedge policy configuration function
+type HedgeOption func(*HedgePolicy)
+
+// NewHedgePolicy creates a hedge policy
+func NewHedgePolicy(opts ...HedgeOption) *HedgePolicy {
+	p := &HedgePolicy{
+		maxAttempts: 2,
+		delay:       100 * time.Millisecond,
+		minSamples:  20,
+		condition:   AlwaysRetry(),
+		latencies:   newLatencyTracker(512),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// HedgeMaxAttempts sets the maximum number of parallel attempts (including the first one)
+func HedgeMaxAttempts(n int) HedgeOption {
+	return func(p *HedgePolicy) {
+		if n > 0 {
+			p.maxAttempts = n
+		}
+	}
+}
+
+// HedgeDelay sets the fixed hedge delay
+func HedgeDelay(d time.Duration) HedgeOption {
+	return func(p *HedgePolicy) {
+		if d > 0 {
+			p.delay = d
+		}
+	}
+}
+
+// HedgePercentile uses a latency percentile (0 - 1, e.g., 0.95) as the hedge delay
+// The fixed delay is used until minSamples latencies are observed
+func HedgePercentile(percentile float64, minSamples int) HedgeOption {
+	return func(p *HedgePolicy) {
+		if percentile > 0 && percentile < 1 {
+			p.percentile = percentile
+		}
+		if minSamples > 0 {
+			p.minSamples = minSamples
+		}
+	}
+}
+
+// HedgeCondition sets the failures that start the next attempt without waiting for the delay
+// A failure not matching the condition is returned at once and cancels the other attempts
+func HedgeCondition(cond RetryCondition) HedgeOption {
+	return func(p *HedgePolicy) {
+		if cond != nil {
+			p.condition = cond
+		}
+	}
+}
+
+// HedgeBudget sets the budget spent by hedges
+// Share the BudgetManager of the retries so that hedges and retries together stay within the ratio.
+func HedgeBudget(b *BudgetManager) HedgeOption {
+	return func(p *HedgePolicy) {
+		p.budget = b
+	}
+}
+
+// Delay returns the current hedge delay
+func (p *HedgePolicy) Delay() time.Duration {
+	if p.percentile > 0 {
+		if d, ok := p.latencies.percentile(p.percentile, p.minSamples); ok {
+			return d
+		}
+	}
+	return p.delay
+}
+
+// Hedge performs a hedged operation
+// The operation receives a context cancelled when another attempt wins.
+func Hedge(ctx context.Context, policy *HedgePolicy, operation func(ctx context.Context) error) error {
+	_, err := HedgeWithData(ctx, policy, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, operation(ctx)
+	})
+	return err
+}
+
+// hedgeResult result of an attempt
+type hedgeResult[T any] struct {
+	data    T
+	err     error
+	latency time.Duration
+}
+
+// HedgeWithData performs a hedged operation and returns the data of the first successful attempt
+// Failed attempts are returned as a MultiError, like DoWithData.
+func HedgeWithData[T any](ctx context.Context, policy *HedgePolicy, operation func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	if policy == nil {
+		return operation(ctx)
+	}
+
+	// Cancels the attempts still running when the call returns
+	attemptCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan hedgeResult[T], policy.maxAttempts)
+	launched := 0
+	launch := func() {
+		launched++
+		go func() {
+			start := time.Now()
+			data, err := operation(attemptCtx)
+			results <- hedgeResult[T]{data: data, err: err, latency: time.Since(start)}
+		}()
+	}
+	// tryHedge starts another attempt when the budget allows it
+	tryHedge := func() bool {
+		if launched >= policy.maxAttempts {
+			return false
+		}
+		if policy.budget != nil && !policy.budget.Acquire() {
+			return false
+		}
+		launch()
+		return true
+	}
+
+	if policy.budget != nil {
+		policy.budget.RecordRequest()
+	}
+	launch()
+
+	timer := time.NewTimer(policy.Delay())
+	defer timer.Stop()
+
+	var errs []error
+	budgetExhausted := false
+	for {
+		select {
+		case <-ctx.Done():
+			return zero, ctx.Err()
+
+		case <-timer.C:
+			if tryHedge() {
+				timer.Reset(policy.Delay())
+			} else if launched < policy.maxAttempts {
+				budgetExhausted = true
+			}
+
+		case r := <-results:
+			if r.err == nil {
+				policy.latencies.record(r.latency)
+				return r.data, nil
+			}
+			errs = append(errs, r.err)
+
+			// Fatal failure or pushback, the other attempts cannot do better
+			if hint, ok := HintOf(r.err); (ok && hint.Stop) || !policy.condition.ShouldRetry(r.err, len(errs)) {
+				return zero, &MultiError{Errors: errs, Attempts: launched}
+			}
+			if tryHedge() {
+				continue
+			}
+			if launched < policy.maxAttempts {
+				budgetExhausted = true
+			}
+			if len(errs) < launched {
+				continue // Wait for the attempts still running
+			}
+			if budgetExhausted {
+				errs = append(errs, ErrBudgetExhausted)
+			}
+			return zero, &MultiError{Errors: errs, Attempts: launched}
+		}
+	}
+}
+
+// LastAttemptError returns the last error of the attempts, skipping ErrBudgetExhausted
+// Used by adapters that must return the error of the downstream (e.g., a gRPC status).
+func LastAttemptError(err error) error {
+	var multiErr *MultiError
+	if !errors.As(err, &multiErr) {
+		return err
+	}
+	for i := len(multiErr.Errors) - 1; i >= 0; i-- {
+		if !errors.Is(multiErr.Errors[i], ErrBudgetExhausted) {
+			return multiErr.Errors[i]
+		}
+	}
+	return err
+}
+
+// latencyTracker latencies of the last successful attempts
+type latencyTracker struct {
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+	filled  bool
+
+	// cached percentile, recomputed every percentileRefresh samples
+	cached      time.Duration
+	cachedFor   float64
+	sinceCached int
+}
+
+// percentileRefresh samples recorded before the percentile is recomputed
+const percentileRefresh = 16
+
+// newLatencyTracker creates a tracker of the last size latencies
+func newLatencyTracker(size int) *latencyTracker {
+	return &latencyTracker{samples: make([]time.Duration, size)}
+}
+
+// record adds a latency, replacing the oldest one when full
+func (t *latencyTracker) record(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.samples[t.next] = d
+	t.next = (t.next + 1) % len(t.samples)
+	if t.next == 0 {
+		t.filled = true
+	}
+	t.sinceCached++
+}
+
+// count number of latencies recorded (lock must be held)
+func (t *latencyTracker) count() int {
+	if t.filled {
+		return len(t.samples)
+	}
+	return t.next
+}
+
+// percentile returns the p percentile, false until minSamples latencies are recorded
+func (t *latencyTracker) percentile(p float64, minSamples int) (time.Duration, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n := t.count()
+	if n == 0 || n < minSamples {
+		return 0, false
+	}
+	if t.cached > 0 && t.cachedFor == p && t.sinceCached < percentileRefresh {
+		return t.cached, true
+	}
+
+	sorted := make([]time.Duration, n)
+	copy(sorted, t.samples[:n])
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	index := int(float64(n)*p+0.5) - 1
+	if index < 0 {
+		index = 0
+	}
+	if index >= n {
+		index = n - 1
+	}
+
+	t.cached, t.cachedFor, t.sinceCached = sorted[index], p, 0
+	return t.cached, true
+}
@@ -0,0 +1,208 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHedge_FirstAttemptFast(t *testing.T) {
+	policy := NewHedgePolicy(HedgeDelay(50 * time.Millisecond))
+	var calls int32
+
+	err := Hedge(context.Background(), policy, func(ctx context.Context) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestHedge_SlowAttemptIsHedgedAndCancelled(t *testing.T) {
+	policy := NewHedgePolicy(HedgeDelay(10*time.Millisecond), HedgeMaxAttempts(2))
+	var calls int32
+	cancelled := make(chan struct{})
+
+	start := time.Now()
+	result, err := HedgeWithData(context.Background(), policy, func(ctx context.Context) (int, error) {
+		attempt := atomic.AddInt32(&calls, 1)
+		if attempt == 1 {
+			// The first attempt hangs until the hedge wins
+			<-ctx.Done()
+			close(cancelled)
+			return 0, ctx.Err()
+		}
+		return 2, nil
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result != 2 {
+		t.Errorf("expected the hedge result, got %d", result)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the hedge to win quickly, took %v", elapsed)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("expected the losing attempt to be cancelled")
+	}
+}
+
+func TestHedge_FailureStartsNextAttempt(t *testing.T) {
+	policy := NewHedgePolicy(HedgeDelay(time.Hour), HedgeMaxAttempts(3))
+	var calls int32
+
+	err := Hedge(context.Background(), policy, func(ctx context.Context) error {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			return errors.New("unavailable")
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if atomic.LoadInt32(&calls) != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestHedge_FatalErrorStops(t *testing.T) {
+	fatal := errors.New("invalid argument")
+	policy := NewHedgePolicy(HedgeDelay(time.Hour), HedgeMaxAttempts(3), HedgeCondition(NeverRetry()))
+	var calls int32
+
+	err := Hedge(context.Background(), policy, func(ctx context.Context) error {
+		atomic.AddInt32(&calls, 1)
+		return fatal
+	})
+
+	if !errors.Is(err, fatal) {
+		t.Errorf("expected the fatal error, got %v", err)
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestHedge_AllAttemptsFail(t *testing.T) {
+	policy := NewHedgePolicy(HedgeDelay(time.Millisecond), HedgeMaxAttempts(3))
+
+	err := Hedge(context.Background(), policy, func(ctx context.Context) error {
+		return errors.New("unavailable")
+	})
+
+	var multiErr *MultiError
+	if !errors.As(err, &multiErr) {
+		t.Fatalf("expected MultiError, got %v", err)
+	}
+	if multiErr.Attempts != 3 || len(multiErr.Errors) != 3 {
+		t.Errorf("expected 3 attempts and errors, got %d attempts, %d errors", multiErr.Attempts, len(multiErr.Errors))
+	}
+}
+
+func TestHedge_BudgetLimitsHedges(t *testing.T) {
+	// No budget yet: the first call cannot hedge
+	budget := NewBudgetManager(0.5, time.Minute)
+	policy := NewHedgePolicy(HedgeDelay(time.Millisecond), HedgeMaxAttempts(2), HedgeBudget(budget))
+	var calls int32
+
+	_, err := HedgeWithData(context.Background(), policy, func(ctx context.Context) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(20 * time.Millisecond)
+		return 1, nil
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf("expected no hedge without budget, got %d calls", calls)
+	}
+
+	// Two requests give one hedge
+	atomic.StoreInt32(&calls, 0)
+	_, _ = HedgeWithData(context.Background(), policy, func(ctx context.Context) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(20 * time.Millisecond)
+		return 1, nil
+	})
+	if atomic.LoadInt32(&calls) != 2 {
+		t.Errorf("expected 1 hedge, got %d calls", calls)
+	}
+
+	stats := budget.GetStats()
+	if stats.Requests != 2 || stats.Retries != 1 {
+		t.Errorf("expected 2 requests and 1 retry, got %d and %d", stats.Requests, stats.Retries)
+	}
+}
+
+func TestHedge_BudgetExhaustedAfterFailure(t *testing.T) {
+	policy := NewHedgePolicy(HedgeMaxAttempts(2), HedgeBudget(NewBudgetManager(0.1, time.Minute)))
+
+	err := Hedge(context.Background(), policy, func(ctx context.Context) error {
+		return errors.New("unavailable")
+	})
+
+	if !errors.Is(err, ErrBudgetExhausted) {
+		t.Errorf("expected ErrBudgetExhausted, got %v", err)
+	}
+	if last := LastAttemptError(err); last == nil || last.Error() != "unavailable" {
+		t.Errorf("expected the attempt error, got %v", last)
+	}
+}
+
+func TestHedge_ContextCancelled(t *testing.T) {
+	policy := NewHedgePolicy(HedgeDelay(time.Hour))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := Hedge(ctx, policy, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestHedgePolicy_PercentileDelay(t *testing.T) {
+	policy := NewHedgePolicy(HedgeDelay(time.Second), HedgePercentile(0.9, 10))
+
+	if d := policy.Delay(); d != time.Second {
+		t.Errorf("expected the fixed delay before enough samples, got %v", d)
+	}
+
+	for i := 1; i <= 10; i++ {
+		policy.latencies.record(time.Duration(i) * time.Millisecond)
+	}
+	if d := policy.Delay(); d != 9*time.Millisecond {
+		t.Errorf("expected p90 of 9ms, got %v", d)
+	}
+}
+
+func TestBudgetManager_Acquire(t *testing.T) {
+	budget := NewBudgetManager(0.5, time.Minute)
+
+	if budget.Acquire() {
+		t.Error("expected no budget without requests")
+	}
+	budget.RecordRequest()
+	budget.RecordRequest()
+	if !budget.Acquire() {
+		t.Error("expected budget for one extra attempt")
+	}
+	if budget.Acquire() {
+		t.Error("expected budget exhausted")
+	}
+}
@@ -18,14 +18,14 @@ var (
 			codes.DeadlineExceeded,
 			codes.ResourceExhausted,
 		)),
-		Backoff(ExponentialBackoff(1 * 1000000000)), // 1s (time.Second)
+		Backoff(RetryAfterBackoff(ExponentialBackoff(1*1000000000), 30*1000000000)), // 1s, pushback capped at 30s
 	}
 	
 	// HTTP default retry configuration
 	HTTPDefaults = []Option{
 		MaxAttempts(3),
 		Condition(RetryOnHTTPStatus(429, 502, 503, 504)),
-		Backoff(ExponentialBackoff(1 * 1000000000)), // 1s
+		Backoff(RetryAfterBackoff(ExponentialBackoff(1*1000000000), 30*1000000000)), // 1s, Retry-After capped at 30s
 	}
 	
 	// DatabaseDefaults database default retry configuration
@@ -0,0 +1,74 @@
+package retry
+
+import (
+	"errors"
+	"time"
+)
+
+// RetryHint retry hint sent by a server with a failure
+// e.g., HTTP Retry-After on 429/503, gRPC grpc-retry-pushback-ms trailer
+type RetryHint struct {
+	Delay time.Duration // Delay asked by the server before the next attempt
+	Stop  bool          // The server asked not to retry (e.g., negative gRPC pushback)
+}
+
+// RetryHinter errors carrying a server retry hint (httpclient.StatusError, grpc pushback errors)
+type RetryHinter interface {
+	RetryHint() (RetryHint, bool)
+}
+
+// HintOf returns the retry hint of an error chain
+func HintOf(err error) (RetryHint, bool) {
+	var hinter RetryHinter
+	if err == nil || !errors.As(err, &hinter) {
+		return RetryHint{}, false
+	}
+	return hinter.RetryHint()
+}
+
+// ErrorAwareBackoff backoff strategies computing the delay from the failed attempt
+// DoWithData uses NextWithError instead of Next when the strategy implements it.
+type ErrorAwareBackoff interface {
+	BackoffStrategy
+	NextWithError(attempt int, err error) time.Duration
+}
+
+// nextDelay delay before the next attempt
+func nextDelay(backoff BackoffStrategy, attempt int, err error) time.Duration {
+	if aware, ok := backoff.(ErrorAwareBackoff); ok {
+		return aware.NextWithError(attempt, err)
+	}
+	return backoff.Next(attempt)
+}
+
+// retryAfterBackoff backoff honoring server retry hints
+type retryAfterBackoff struct {
+	base     BackoffStrategy
+	maxDelay time.Duration
+}
+
+// RetryAfterBackoff uses the delay asked by the server (Retry-After, gRPC pushback) when the error carries one,
+// capped at maxDelay, and the base strategy otherwise
+func RetryAfterBackoff(base BackoffStrategy, maxDelay time.Duration) BackoffStrategy {
+	if maxDelay <= 0 {
+		maxDelay = 30 * time.Second
+	}
+	return &retryAfterBackoff{base: base, maxDelay: maxDelay}
+}
+
+// Next implement the BackoffStrategy interface
+func (b *retryAfterBackoff) Next(attempt int) time.Duration {
+	return b.base.Next(attempt)
+}
+
+// NextWithError implements the ErrorAwareBackoff interface
+func (b *retryAfterBackoff) NextWithError(attempt int, err error) time.Duration {
+	hint, ok := HintOf(err)
+	if !ok || hint.Stop {
+		return nextDelay(b.base, attempt, err)
+	}
+	if hint.Delay > b.maxDelay {
+		return b.maxDelay
+	}
+	return hint.Delay
+}
@@ -0,0 +1,104 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// hintError test error carrying a retry hint
+type hintError struct {
+	hint RetryHint
+}
+
+func (e *hintError) Error() string                { return "server hint" }
+func (e *hintError) RetryHint() (RetryHint, bool) { return e.hint, true }
+
+func TestHintOf(t *testing.T) {
+	err := fmt.Errorf("call failed: %w", &hintError{hint: RetryHint{Delay: time.Second}})
+
+	hint, ok := HintOf(err)
+	if !ok || hint.Delay != time.Second {
+		t.Errorf("expected a 1s hint, got %v, %v", hint, ok)
+	}
+	if _, ok := HintOf(errors.New("plain")); ok {
+		t.Error("expected no hint")
+	}
+	if _, ok := HintOf(nil); ok {
+		t.Error("expected no hint for nil")
+	}
+}
+
+func TestRetryAfterBackoff(t *testing.T) {
+	backoff := RetryAfterBackoff(ConstantBackoff(time.Second, WithJitter(0)), 5*time.Second)
+	aware := backoff.(ErrorAwareBackoff)
+
+	if d := aware.NextWithError(1, &hintError{hint: RetryHint{Delay: 2 * time.Second}}); d != 2*time.Second {
+		t.Errorf("expected the hinted 2s, got %v", d)
+	}
+	if d := aware.NextWithError(1, &hintError{hint: RetryHint{Delay: time.Minute}}); d != 5*time.Second {
+		t.Errorf("expected the 5s cap, got %v", d)
+	}
+	if d := aware.NextWithError(1, errors.New("plain")); d != time.Second {
+		t.Errorf("expected the base 1s, got %v", d)
+	}
+	if d := backoff.Next(1); d != time.Second {
+		t.Errorf("expected the base 1s, got %v", d)
+	}
+}
+
+func TestDo_HonorsRetryHint(t *testing.T) {
+	called := 0
+	start := time.Now()
+
+	err := Do(context.Background(), func() error {
+		called++
+		if called == 1 {
+			return &hintError{hint: RetryHint{Delay: 10 * time.Millisecond}}
+		}
+		return nil
+	}, MaxAttempts(2), Backoff(RetryAfterBackoff(ConstantBackoff(time.Hour), time.Second)))
+
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected the hinted delay, took %v", elapsed)
+	}
+}
+
+func TestDo_StopHint(t *testing.T) {
+	called := 0
+
+	err := Do(context.Background(), func() error {
+		called++
+		return &hintError{hint: RetryHint{Stop: true}}
+	}, MaxAttempts(5), Backoff(ConstantBackoff(time.Millisecond)))
+
+	if called != 1 {
+		t.Errorf("expected 1 call, got %d", called)
+	}
+	var multiErr *MultiError
+	if !errors.As(err, &multiErr) || multiErr.Attempts != 1 {
+		t.Errorf("expected MultiError after 1 attempt, got %v", err)
+	}
+}
+
+func TestRetryOnHTTPStatus_HTTPStatusMethod(t *testing.T) {
+	cond := RetryOnHTTPStatus(503)
+
+	if !cond.ShouldRetry(fmt.Errorf("wrapped: %w", statusOnly(503)), 1) {
+		t.Error("expected retry for an error exposing HTTPStatus 503")
+	}
+	if cond.ShouldRetry(statusOnly(400), 1) {
+		t.Error("expected no retry for 400")
+	}
+}
+
+// statusOnly test error exposing HTTPStatus (like httpclient.StatusError)
+type statusOnly int
+
+func (e statusOnly) Error() string   { return fmt.Sprintf("HTTP %d", int(e)) }
+func (e statusOnly) HTTPStatus() int { return int(e) }
@@ -0,0 +1,292 @@
+package retry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Give-up reasons of a retried call
+const (
+	GiveUpMaxAttempts  = "max_attempts"  // All attempts failed
+	GiveUpNonRetryable = "non_retryable" // The retry condition rejected the error
+	GiveUpStopped      = "stopped"       // The server asked not to retry
+	GiveUpBudget       = "budget"        // The retry budget is exhausted
+	GiveUpDeadline     = "deadline"      // Not enough time left for the backoff
+	GiveUpContext      = "context"       // The context was cancelled
+)
+
+// OTelMetrics implements component.MetricsProvider for OpenTelemetry integration.
+type OTelMetrics struct {
+	config     MetricsConfig
+	meter      metric.Meter
+	registered bool
+	mu         sync.RWMutex
+
+	// Metrics instruments
+	attemptsTotal        metric.Int64Counter           // Attempts by result
+	retriesTotal         metric.Int64Counter           // Retries by error class
+	giveUpsTotal         metric.Int64Counter           // Failed calls by reason
+	budgetExhaustedTotal metric.Int64Counter           // Retries refused by the budget
+	callAttempts         metric.Int64Histogram         // Attempts per call
+	delay                metric.Float64Histogram       // Backoff delay before a retry
+	budgetRequests       metric.Int64ObservableGauge   // Original requests in the budget window
+	budgetRetries        metric.Int64ObservableGauge   // Retries in the budget window
+	budgetRemaining      metric.Int64ObservableGauge   // Retries left in the budget window
+	budgetUsage          metric.Float64ObservableGauge // Budget usage ratio
+
+	// Budgets exported as gauges
+	budgets  map[string]*BudgetManager
+	budgetMu sync.RWMutex
+}
+
+// MetricsConfig holds configuration for retry metrics
+type MetricsConfig struct {
+	Enabled      bool
+	RecordBudget bool
+}
+
+// NewOTelMetrics creates a new OTel metrics provider for retry
+func NewOTelMetrics(cfg MetricsConfig) *OTelMetrics {
+	return &OTelMetrics{
+		config:  cfg,
+		budgets: make(map[string]*BudgetManager),
+	}
+}
+
+// MetricsName returns the metrics group name
+func (m *OTelMetrics) MetricsName() string {
+	return "retry"
+}
+
+// IsMetricsEnabled returns whether metrics collection is enabled
+func (m *OTelMetrics) IsMetricsEnabled() bool {
+	return m.config.Enabled
+}
+
+// RegisterMetrics registers all retry metrics with the provided Meter
+func (m *OTelMetrics) RegisterMetrics(meter metric.Meter) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.registered {
+		return nil
+	}
+
+	m.meter = meter
+	var err error
+
+	// Counter: attempts
+	m.attemptsTotal, err = meter.Int64Counter(
+		"retry_attempts_total",
+		metric.WithDescription("Total number of attempts of retried calls"),
+		metric.WithUnit("{attempt}"),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Counter: retries
+	m.retriesTotal, err = meter.Int64Counter(
+		"retry_retries_total",
+		metric.WithDescription("Total number of retries"),
+		metric.WithUnit("{retry}"),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Counter: give-ups
+	m.giveUpsTotal, err = meter.Int64Counter(
+		"retry_give_ups_total",
+		metric.WithDescription("Total number of calls failed after retries"),
+		metric.WithUnit("{call}"),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Counter: exhausted budgets
+	m.budgetExhaustedTotal, err = meter.Int64Counter(
+		"retry_budget_exhausted_total",
+		metric.WithDescription("Total number of retries refused by the retry budget"),
+		metric.WithUnit("{retry}"),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Histogram: attempts per call
+	m.callAttempts, err = meter.Int64Histogram(
+		"retry_call_attempts",
+		metric.WithDescription("Number of attempts per call"),
+		metric.WithUnit("{attempt}"),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Histogram: backoff delay
+	m.delay, err = meter.Float64Histogram(
+		"retry_delay_seconds",
+		metric.WithDescription("Backoff delay before a retry"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+
+	// Optional: budget gauges
+	if m.config.RecordBudget {
+		if err := m.registerBudgetGauges(meter); err != nil {
+			return err
+		}
+	}
+
+	m.registered = true
+	return nil
+}
+
+// registerBudgetGauges registers the BudgetStats gauges
+func (m *OTelMetrics) registerBudgetGauges(meter metric.Meter) error {
+	var err error
+
+	m.budgetRequests, err = meter.Int64ObservableGauge(
+		"retry_budget_requests",
+		metric.WithDescription("Original requests in the current budget window"),
+		metric.WithUnit("{request}"),
+		metric.WithInt64Callback(m.collectBudgets(func(s BudgetStats) int64 { return s.Requests })),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.budgetRetries, err = meter.Int64ObservableGauge(
+		"retry_budget_retries",
+		metric.WithDescription("Retries in the current budget window"),
+		metric.WithUnit("{retry}"),
+		metric.WithInt64Callback(m.collectBudgets(func(s BudgetStats) int64 { return s.Retries })),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.budgetRemaining, err = meter.Int64ObservableGauge(
+		"retry_budget_remaining",
+		metric.WithDescription("Retries left in the current budget window"),
+		metric.WithUnit("{retry}"),
+		metric.WithInt64Callback(m.collectBudgets(func(s BudgetStats) int64 { return s.Remaining })),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.budgetUsage, err = meter.Float64ObservableGauge(
+		"retry_budget_usage_ratio",
+		metric.WithDescription("Retry budget usage ratio (1 = exhausted)"),
+		metric.WithFloat64Callback(func(_ context.Context, observer metric.Float64Observer) error {
+			m.budgetMu.RLock()
+			defer m.budgetMu.RUnlock()
+
+			for name, budget := range m.budgets {
+				observer.Observe(budget.GetStats().UsageRatio,
+					metric.WithAttributes(attribute.String("budget", name)),
+				)
+			}
+			return nil
+		}),
+	)
+	return err
+}
+
+// collectBudgets returns the callback of an integer budget gauge
+func (m *OTelMetrics) collectBudgets(value func(BudgetStats) int64) metric.Int64Callback {
+	return func(_ context.Context, observer metric.Int64Observer) error {
+		m.budgetMu.RLock()
+		defer m.budgetMu.RUnlock()
+
+		for name, budget := range m.budgets {
+			observer.Observe(value(budget.GetStats()),
+				metric.WithAttributes(attribute.String("budget", name)),
+			)
+		}
+		return nil
+	}
+}
+
+// RegisterBudget exports the statistics of a budget under a name
+func (m *OTelMetrics) RegisterBudget(name string, budget *BudgetManager) {
+	m.budgetMu.Lock()
+	defer m.budgetMu.Unlock()
+	m.budgets[name] = budget
+}
+
+// UnregisterBudget removes a budget from the gauges
+func (m *OTelMetrics) UnregisterBudget(name string) {
+	m.budgetMu.Lock()
+	defer m.budgetMu.Unlock()
+	delete(m.budgets, name)
+}
+
+// RecordAttempt records the result of an attempt
+func (m *OTelMetrics) RecordAttempt(ctx context.Context, operation string, err error) {
+	if !m.registered {
+		return
+	}
+
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	m.attemptsTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("result", result),
+		attribute.String("error_class", ErrorClass(err)),
+	))
+}
+
+// RecordRetry records a retry and its backoff delay
+func (m *OTelMetrics) RecordRetry(ctx context.Context, operation string, err error, delay time.Duration) {
+	if !m.registered {
+		return
+	}
+
+	m.retriesTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("error_class", ErrorClass(err)),
+	))
+	m.delay.Record(ctx, delay.Seconds(), metric.WithAttributes(attribute.String("operation", operation)))
+}
+
+// RecordCall records the number of attempts of a finished call, and its give-up reason when it failed
+func (m *OTelMetrics) RecordCall(ctx context.Context, operation string, attempts int, reason string) {
+	if !m.registered {
+		return
+	}
+
+	result := "success"
+	if reason != "" {
+		result = "failure"
+		m.giveUpsTotal.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("operation", operation),
+			attribute.String("reason", reason),
+		))
+		if reason == GiveUpBudget {
+			m.budgetExhaustedTotal.Add(ctx, 1, metric.WithAttributes(attribute.String("operation", operation)))
+		}
+	}
+	m.callAttempts.Record(ctx, int64(attempts), metric.WithAttributes(
+		attribute.String("operation", operation),
+		attribute.String("result", result),
+	))
+}
+
+// IsRegistered returns whether metrics have been registered
+func (m *OTelMetrics) IsRegistered() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.registered
+}
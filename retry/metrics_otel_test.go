@@ -0,0 +1,166 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newTestMetrics creates registered metrics and their reader
+func newTestMetrics(t *testing.T) (*OTelMetrics, *sdkmetric.ManualReader) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	m := NewOTelMetrics(MetricsConfig{Enabled: true, RecordBudget: true})
+	if err := m.RegisterMetrics(provider.Meter("test")); err != nil {
+		t.Fatalf("RegisterMetrics failed: %v", err)
+	}
+	return m, reader
+}
+
+// collect returns the data points of the collected metrics by name
+func collect(t *testing.T, reader *sdkmetric.ManualReader) map[string]metricdata.Aggregation {
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+	result := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			result[m.Name] = m.Data
+		}
+	}
+	return result
+}
+
+// sumOf returns the total of a counter
+func sumOf(data metricdata.Aggregation) int64 {
+	sum, ok := data.(metricdata.Sum[int64])
+	if !ok {
+		return 0
+	}
+	var total int64
+	for _, point := range sum.DataPoints {
+		total += point.Value
+	}
+	return total
+}
+
+func TestDo_Metrics(t *testing.T) {
+	m, reader := newTestMetrics(t)
+
+	called := 0
+	_ = Do(context.Background(), func() error {
+		called++
+		if called < 3 {
+			return errors.New("temporary error")
+		}
+		return nil
+	}, MaxAttempts(3), Backoff(ConstantBackoff(time.Millisecond)), Operation("load-user"), Metrics(m))
+
+	_ = Do(context.Background(), func() error {
+		return status.Error(codes.InvalidArgument, "bad request")
+	}, MaxAttempts(3), Condition(RetryOnGRPCCodes(codes.Unavailable)), Operation("load-user"), Metrics(m))
+
+	data := collect(t, reader)
+	if got := sumOf(data["retry_attempts_total"]); got != 4 {
+		t.Errorf("expected 4 attempts, got %d", got)
+	}
+	if got := sumOf(data["retry_retries_total"]); got != 2 {
+		t.Errorf("expected 2 retries, got %d", got)
+	}
+	if got := sumOf(data["retry_give_ups_total"]); got != 1 {
+		t.Errorf("expected 1 give-up, got %d", got)
+	}
+	if _, ok := data["retry_delay_seconds"]; !ok {
+		t.Error("expected the delay histogram")
+	}
+	if hist, ok := data["retry_call_attempts"].(metricdata.Histogram[int64]); !ok || len(hist.DataPoints) != 2 {
+		t.Errorf("expected the attempts histogram by result, got %+v", data["retry_call_attempts"])
+	}
+}
+
+func TestDo_MetricsBudgetExhausted(t *testing.T) {
+	m, reader := newTestMetrics(t)
+	budget := NewBudgetManager(0.1, time.Minute)
+	m.RegisterBudget("orders", budget)
+
+	_ = Do(context.Background(), func() error {
+		return errors.New("temporary error")
+	}, MaxAttempts(3), Backoff(NoBackoff()), Budget(budget), Metrics(m))
+
+	data := collect(t, reader)
+	if got := sumOf(data["retry_budget_exhausted_total"]); got != 1 {
+		t.Errorf("expected 1 exhausted budget, got %d", got)
+	}
+	gauge, ok := data["retry_budget_requests"].(metricdata.Gauge[int64])
+	if !ok || len(gauge.DataPoints) != 1 || gauge.DataPoints[0].Value != 1 {
+		t.Errorf("expected the budget requests gauge, got %+v", data["retry_budget_requests"])
+	}
+
+	m.UnregisterBudget("orders")
+	data = collect(t, reader)
+	if gauge, ok := data["retry_budget_requests"].(metricdata.Gauge[int64]); ok && len(gauge.DataPoints) != 0 {
+		t.Errorf("expected no budget after unregister, got %+v", gauge)
+	}
+}
+
+func TestDo_SpanEvents(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, span := provider.Tracer("test").Start(context.Background(), "call")
+
+	_ = Do(ctx, func() error {
+		return status.Error(codes.Unavailable, "down")
+	}, MaxAttempts(2), Backoff(ConstantBackoff(time.Millisecond, WithJitter(0))), Operation("get-order"))
+	span.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	events := spans[0].Events()
+	if len(events) != 3 {
+		t.Fatalf("expected 2 attempts and a give-up, got %d events", len(events))
+	}
+	attrs := make(map[string]string)
+	for _, attr := range events[0].Attributes {
+		attrs[string(attr.Key)] = attr.Value.Emit()
+	}
+	if events[0].Name != "retry.attempt" || attrs["retry.attempt"] != "1" ||
+		attrs["retry.error_class"] != "grpc_unavailable" || attrs["retry.delay_ms"] != "1" {
+		t.Errorf("unexpected first event %s %v", events[0].Name, attrs)
+	}
+	if events[2].Name != "retry.give_up" {
+		t.Errorf("expected a give-up event, got %s", events[2].Name)
+	}
+}
+
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, "none"},
+		{context.DeadlineExceeded, "timeout"},
+		{fmt.Errorf("wrapped: %w", context.Canceled), "canceled"},
+		{ErrBudgetExhausted, "budget_exhausted"},
+		{statusOnly(503), "http_503"},
+		{status.Error(codes.ResourceExhausted, "slow down"), "grpc_resourceexhausted"},
+		{errors.New("boom"), "error"},
+	}
+	for _, tt := range tests {
+		if got := ErrorClass(tt.err); got != tt.want {
+			t.Errorf("ErrorClass(%v) = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/status"
+)
+
+// defaultOperation operation label of calls without Operation
+const defaultOperation = "default"
+
+// ErrorClass returns a low-cardinality class of an error, used in span events and metric labels
+// none, timeout, canceled, budget_exhausted, http_<status>, grpc_<code> or error.
+func ErrorClass(err error) string {
+	if err == nil {
+		return "none"
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+	if errors.Is(err, context.Canceled) {
+		return "canceled"
+	}
+	if errors.Is(err, ErrBudgetExhausted) {
+		return "budget_exhausted"
+	}
+
+	var statusErr interface{ HTTPStatus() int }
+	if errors.As(err, &statusErr) {
+		return "http_" + strconv.Itoa(statusErr.HTTPStatus())
+	}
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcErr) {
+		return "grpc_" + strings.ToLower(grpcErr.GRPCStatus().Code().String())
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return "timeout"
+	}
+	return "error"
+}
+
+// observer records the attempts of a call as span events of the current span and as metrics
+type observer struct {
+	ctx       context.Context
+	operation string
+	span      trace.Span
+	metrics   *OTelMetrics
+}
+
+// newObserver creates the observer of a call
+func newObserver(ctx context.Context, cfg *Config) *observer {
+	operation := cfg.operation
+	if operation == "" {
+		operation = defaultOperation
+	}
+	return &observer{
+		ctx:       ctx,
+		operation: operation,
+		span:      trace.SpanFromContext(ctx),
+		metrics:   cfg.metrics,
+	}
+}
+
+// attempt records the result of an attempt, delay is the backoff before the next one (0 when none)
+func (o *observer) attempt(attempt int, err error, delay time.Duration) {
+	if o.metrics != nil {
+		o.metrics.RecordAttempt(o.ctx, o.operation, err)
+		if delay > 0 {
+			o.metrics.RecordRetry(o.ctx, o.operation, err, delay)
+		}
+	}
+	if err == nil || !o.span.IsRecording() {
+		return
+	}
+	o.span.AddEvent("retry.attempt", trace.WithAttributes(
+		attribute.String("retry.operation", o.operation),
+		attribute.Int("retry.attempt", attempt),
+		attribute.String("retry.error_class", ErrorClass(err)),
+		attribute.String("retry.error", err.Error()),
+		attribute.Int64("retry.delay_ms", delay.Milliseconds()),
+	))
+}
+
+// done records the end of a call, reason is empty when it succeeded
+func (o *observer) done(attempts int, reason string) {
+	if o.metrics != nil {
+		o.metrics.RecordCall(o.ctx, o.operation, attempts, reason)
+	}
+	if reason == "" || !o.span.IsRecording() {
+		return
+	}
+	o.span.AddEvent("retry.give_up", trace.WithAttributes(
+		attribute.String("retry.operation", o.operation),
+		attribute.Int("retry.attempts", attempts),
+		attribute.String("retry.reason", reason),
+	))
+}
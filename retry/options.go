@@ -12,6 +12,8 @@ type Config struct {
 	onRetry     func(attempt int, err error) // retry callback
 	timeout     time.Duration    // Timeout for single operation (0 indicates no limit)
 	budget      *BudgetManager   // Retry budget (optional)
+	operation   string           // Operation name of the span events and metrics
+	metrics     *OTelMetrics     // Metrics provider (optional)
 }
 
 // default configuration
@@ -72,6 +74,20 @@ func Timeout(d time.Duration) Option {
 	}
 }
 
+// Operation names the call in span events and metric labels (keep the cardinality low)
+func Operation(name string) Option {
+	return func(c *Config) {
+		c.operation = name
+	}
+}
+
+// Metrics records the attempts of the call in the metrics provider
+func Metrics(m *OTelMetrics) Option {
+	return func(c *Config) {
+		c.metrics = m
+	}
+}
+
 // Budget sets retry budget
 func Budget(b *BudgetManager) Option {
 	return func(c *Config) {
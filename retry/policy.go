@@ -0,0 +1,312 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/KOMKZ/go-yogan-framework/errcode"
+	"google.golang.org/grpc/codes"
+)
+
+// Backoff types of a policy
+const (
+	BackoffExponential = "exponential"
+	BackoffLinear      = "linear"
+	BackoffConstant    = "constant"
+	BackoffNone        = "none"
+)
+
+// RegistryConfig retry component configuration (retry section)
+//
+//	retry:
+//	  policies:
+//	    idempotent-read:
+//	      max_attempts: 3
+//	      backoff: exponential
+//	      base_delay: 100ms
+//	      http_statuses: [429, 502, 503, 504]
+type RegistryConfig struct {
+	Policies map[string]PolicyConfig `mapstructure:"policies"`
+}
+
+// PolicyConfig named retry policy
+type PolicyConfig struct {
+	MaxAttempts  int           `mapstructure:"max_attempts"`  // Maximum number of attempts (default 3)
+	Backoff      string        `mapstructure:"backoff"`       // exponential | linear | constant | none (default exponential)
+	BaseDelay    time.Duration `mapstructure:"base_delay"`    // Base delay (default 100ms)
+	Multiplier   float64       `mapstructure:"multiplier"`    // Exponential multiplier (default 2.0)
+	MaxDelay     time.Duration `mapstructure:"max_delay"`     // Maximum delay, also caps Retry-After and pushback (default 30s)
+	Jitter       *float64      `mapstructure:"jitter"`        // Jitter ratio 0 - 1 (nil = 0.2)
+	HTTPStatuses []int         `mapstructure:"http_statuses"` // Retryable HTTP statuses
+	GRPCCodes    []string      `mapstructure:"grpc_codes"`    // Retryable gRPC codes (e.g., Unavailable, DEADLINE_EXCEEDED)
+	Timeout      time.Duration `mapstructure:"timeout"`       // Timeout of each attempt (0 = no limit)
+	BudgetRatio  float64       `mapstructure:"budget_ratio"`  // Retry budget ratio (0 = no budget)
+}
+
+// ApplyDefaults applies default values
+func (c *PolicyConfig) ApplyDefaults() {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 3
+	}
+	if c.Backoff == "" {
+		c.Backoff = BackoffExponential
+	}
+	if c.BaseDelay <= 0 {
+		c.BaseDelay = 100 * time.Millisecond
+	}
+	if c.Multiplier <= 0 {
+		c.Multiplier = 2.0
+	}
+	if c.MaxDelay <= 0 {
+		c.MaxDelay = 30 * time.Second
+	}
+}
+
+// Validate policy configuration
+func (c *PolicyConfig) Validate() error {
+	switch c.Backoff {
+	case "", BackoffExponential, BackoffLinear, BackoffConstant, BackoffNone:
+	default:
+		return fmt.Errorf("backoff must be exponential, linear, constant or none, got %q", c.Backoff)
+	}
+	if c.Jitter != nil && (*c.Jitter < 0 || *c.Jitter > 1) {
+		return fmt.Errorf("jitter must be between 0 and 1")
+	}
+	if c.BudgetRatio < 0 || c.BudgetRatio > 1 {
+		return fmt.Errorf("budget_ratio must be between 0 and 1")
+	}
+	for _, status := range c.HTTPStatuses {
+		if status < 100 || status > 599 {
+			return fmt.Errorf("invalid HTTP status %d", status)
+		}
+	}
+	for _, name := range c.GRPCCodes {
+		if _, ok := errcode.ParseGRPCCode(name); !ok {
+			return fmt.Errorf("unknown gRPC code %q", name)
+		}
+	}
+	return nil
+}
+
+// Validate retry configuration
+func (c *RegistryConfig) Validate() error {
+	for name, policy := range c.Policies {
+		if err := policy.Validate(); err != nil {
+			return fmt.Errorf("retry policy %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Policy compiled named retry policy
+type Policy struct {
+	name    string
+	config  PolicyConfig
+	budget  *BudgetManager
+	options []Option
+}
+
+// newPolicy compiles a policy configuration (defaults applied, validated)
+func newPolicy(name string, cfg PolicyConfig, budget *BudgetManager, metrics *OTelMetrics) *Policy {
+	jitter := 0.2
+	if cfg.Jitter != nil {
+		jitter = *cfg.Jitter
+	}
+	backoffOpts := []BackoffOption{WithMultiplier(cfg.Multiplier), WithMaxDelay(cfg.MaxDelay), WithJitter(jitter)}
+
+	var backoff BackoffStrategy
+	switch cfg.Backoff {
+	case BackoffLinear:
+		backoff = LinearBackoff(cfg.BaseDelay, backoffOpts...)
+	case BackoffConstant:
+		backoff = ConstantBackoff(cfg.BaseDelay, backoffOpts...)
+	case BackoffNone:
+		backoff = NoBackoff()
+	default:
+		backoff = ExponentialBackoff(cfg.BaseDelay, backoffOpts...)
+	}
+
+	// Retry on any listed status or code, on any error when nothing is listed
+	var conditions []RetryCondition
+	if len(cfg.HTTPStatuses) > 0 {
+		conditions = append(conditions, RetryOnHTTPStatus(cfg.HTTPStatuses...))
+	}
+	if len(cfg.GRPCCodes) > 0 {
+		grpcCodes := make([]codes.Code, 0, len(cfg.GRPCCodes))
+		for _, name := range cfg.GRPCCodes {
+			code, _ := errcode.ParseGRPCCode(name)
+			grpcCodes = append(grpcCodes, code)
+		}
+		conditions = append(conditions, RetryOnGRPCCodes(grpcCodes...))
+	}
+	condition := AlwaysRetry()
+	if len(conditions) > 0 {
+		condition = Or(conditions...)
+	}
+
+	options := []Option{
+		MaxAttempts(cfg.MaxAttempts),
+		Backoff(RetryAfterBackoff(backoff, cfg.MaxDelay)),
+		Condition(condition),
+		Operation(name),
+	}
+	if budget != nil {
+		options = append(options, Budget(budget))
+	}
+	if metrics != nil {
+		options = append(options, Metrics(metrics))
+	}
+
+	return &Policy{name: name, config: cfg, budget: budget, options: options}
+}
+
+// Name returns the policy name
+func (p *Policy) Name() string {
+	return p.name
+}
+
+// Config returns the policy configuration (defaults applied)
+func (p *Policy) Config() PolicyConfig {
+	return p.config
+}
+
+// Budget returns the retry budget of the policy (nil without budget_ratio)
+// The budget is shared by every user of the policy, and can be passed to HedgeBudget.
+func (p *Policy) Budget() *BudgetManager {
+	return p.budget
+}
+
+// Options returns the retry options of the policy
+func (p *Policy) Options() []Option {
+	return append(p.OptionsWithoutTimeout(), Timeout(p.config.Timeout))
+}
+
+// OptionsWithoutTimeout returns the retry options of the policy without the attempt timeout
+// For callers that bound each attempt with their own context (the operation stops with it,
+// instead of being left running in the background by the Timeout option).
+func (p *Policy) OptionsWithoutTimeout() []Option {
+	options := make([]Option, len(p.options), len(p.options)+1)
+	copy(options, p.options)
+	return options
+}
+
+// Registry named retry policies
+// Policies are resolved by name at call time, so a Reload applies to the next calls of every user.
+type Registry struct {
+	mu       sync.RWMutex
+	policies map[string]*Policy
+	metrics  *OTelMetrics
+}
+
+// NewRegistry creates a registry of the configured policies
+func NewRegistry(cfg RegistryConfig) (*Registry, error) {
+	r := &Registry{policies: make(map[string]*Policy)}
+	if err := r.Reload(cfg); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload replaces the policies atomically (e.g., on config reload)
+// Budgets are kept for policies whose budget ratio does not change.
+func (r *Registry) Reload(cfg RegistryConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	policies := make(map[string]*Policy, len(cfg.Policies))
+	for name, policyCfg := range cfg.Policies {
+		policyCfg.ApplyDefaults()
+
+		var budget *BudgetManager
+		if policyCfg.BudgetRatio > 0 {
+			if old, ok := r.policies[name]; ok && old.budget != nil && old.config.BudgetRatio == policyCfg.BudgetRatio {
+				budget = old.budget
+			} else {
+				budget = NewBudgetManager(policyCfg.BudgetRatio, time.Minute)
+			}
+		}
+		policies[name] = newPolicy(name, policyCfg, budget, r.metrics)
+	}
+
+	if r.metrics != nil {
+		for name := range r.policies {
+			r.metrics.UnregisterBudget(name)
+		}
+		registerBudgets(r.metrics, policies)
+	}
+	r.policies = policies
+	return nil
+}
+
+// SetMetrics records the calls of every policy in the metrics provider (operation = policy name)
+// and exports the policy budgets as gauges.
+func (r *Registry) SetMetrics(metrics *OTelMetrics) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.metrics = metrics
+	policies := make(map[string]*Policy, len(r.policies))
+	for name, policy := range r.policies {
+		policies[name] = newPolicy(name, policy.config, policy.budget, metrics)
+	}
+	if metrics != nil {
+		registerBudgets(metrics, policies)
+	}
+	r.policies = policies
+}
+
+// registerBudgets exports the budgets of the policies
+func registerBudgets(metrics *OTelMetrics, policies map[string]*Policy) {
+	for name, policy := range policies {
+		if policy.budget != nil {
+			metrics.RegisterBudget(name, policy.budget)
+		}
+	}
+}
+
+// Get returns a policy
+func (r *Registry) Get(name string) (*Policy, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	policy, ok := r.policies[name]
+	return policy, ok
+}
+
+// Options returns the retry options of a policy, ErrPolicyNotFound when it is not configured
+func (r *Registry) Options(name string) ([]Option, error) {
+	policy, ok := r.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrPolicyNotFound, name)
+	}
+	return policy.Options(), nil
+}
+
+// Names returns the configured policy names (sorted)
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.policies))
+	for name := range r.policies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Do performs the operation with a named policy (extra options override the policy)
+func (r *Registry) Do(ctx context.Context, name string, operation func() error, extra ...Option) error {
+	opts, err := r.Options(name)
+	if err != nil {
+		return err
+	}
+	return Do(ctx, operation, append(opts, extra...)...)
+}
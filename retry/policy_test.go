@@ -0,0 +1,164 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRegistry_Do(t *testing.T) {
+	noJitter := 0.0
+	registry, err := NewRegistry(RegistryConfig{Policies: map[string]PolicyConfig{
+		"idempotent-read": {MaxAttempts: 3, Backoff: BackoffConstant, BaseDelay: time.Millisecond, Jitter: &noJitter},
+	}})
+	if err != nil {
+		t.Fatalf("NewRegistry failed: %v", err)
+	}
+
+	called := 0
+	err = registry.Do(context.Background(), "idempotent-read", func() error {
+		called++
+		return errors.New("temporary error")
+	})
+	if GetAttempts(err) != 3 || called != 3 {
+		t.Errorf("expected 3 attempts, got %d (%d calls)", GetAttempts(err), called)
+	}
+
+	// Extra options override the policy
+	called = 0
+	_ = registry.Do(context.Background(), "idempotent-read", func() error {
+		called++
+		return errors.New("temporary error")
+	}, MaxAttempts(1))
+	if called != 1 {
+		t.Errorf("expected 1 call, got %d", called)
+	}
+
+	if err := registry.Do(context.Background(), "missing", func() error { return nil }); !errors.Is(err, ErrPolicyNotFound) {
+		t.Errorf("expected ErrPolicyNotFound, got %v", err)
+	}
+}
+
+func TestRegistry_RetryableCodes(t *testing.T) {
+	registry, err := NewRegistry(RegistryConfig{Policies: map[string]PolicyConfig{
+		"grpc": {Backoff: BackoffNone, GRPCCodes: []string{"UNAVAILABLE"}, HTTPStatuses: []int{503}},
+	}})
+	if err != nil {
+		t.Fatalf("NewRegistry failed: %v", err)
+	}
+
+	called := 0
+	_ = registry.Do(context.Background(), "grpc", func() error {
+		called++
+		return status.Error(codes.InvalidArgument, "bad request")
+	})
+	if called != 1 {
+		t.Errorf("expected no retry for InvalidArgument, got %d calls", called)
+	}
+
+	called = 0
+	_ = registry.Do(context.Background(), "grpc", func() error {
+		called++
+		return status.Error(codes.Unavailable, "down")
+	})
+	if called != 3 {
+		t.Errorf("expected 3 calls for Unavailable, got %d", called)
+	}
+}
+
+func TestRegistry_Reload(t *testing.T) {
+	registry, err := NewRegistry(RegistryConfig{Policies: map[string]PolicyConfig{
+		"read":  {MaxAttempts: 2, BudgetRatio: 0.5},
+		"write": {MaxAttempts: 1},
+	}})
+	if err != nil {
+		t.Fatalf("NewRegistry failed: %v", err)
+	}
+	before, _ := registry.Get("read")
+
+	err = registry.Reload(RegistryConfig{Policies: map[string]PolicyConfig{
+		"read": {MaxAttempts: 5, BudgetRatio: 0.5},
+	}})
+	if err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	after, ok := registry.Get("read")
+	if !ok || after.Config().MaxAttempts != 5 {
+		t.Errorf("expected the reloaded policy, got %+v", after.Config())
+	}
+	if after.Budget() != before.Budget() {
+		t.Error("expected the budget to be kept when the ratio does not change")
+	}
+	if _, ok := registry.Get("write"); ok {
+		t.Error("expected the removed policy to be gone")
+	}
+	if names := registry.Names(); len(names) != 1 || names[0] != "read" {
+		t.Errorf("unexpected names %v", names)
+	}
+
+	// An invalid configuration keeps the current policies
+	if err := registry.Reload(RegistryConfig{Policies: map[string]PolicyConfig{"read": {Backoff: "fibonacci"}}}); err == nil {
+		t.Error("expected a validation error")
+	}
+	if policy, _ := registry.Get("read"); policy.Config().MaxAttempts != 5 {
+		t.Error("expected the policies to be unchanged after a failed reload")
+	}
+}
+
+func TestPolicyConfig_Validate(t *testing.T) {
+	tooMuch := 2.0
+	invalid := []PolicyConfig{
+		{Backoff: "fibonacci"},
+		{Jitter: &tooMuch},
+		{BudgetRatio: 1.5},
+		{HTTPStatuses: []int{700}},
+		{GRPCCodes: []string{"NoSuchCode"}},
+	}
+	for _, cfg := range invalid {
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("expected a validation error for %+v", cfg)
+		}
+	}
+
+	cfg := PolicyConfig{}
+	cfg.ApplyDefaults()
+	if cfg.MaxAttempts != 3 || cfg.Backoff != BackoffExponential || cfg.MaxDelay != 30*time.Second {
+		t.Errorf("unexpected defaults %+v", cfg)
+	}
+}
+
+func TestRegistry_SetMetrics(t *testing.T) {
+	m, reader := newTestMetrics(t)
+	registry, err := NewRegistry(RegistryConfig{Policies: map[string]PolicyConfig{
+		"read": {MaxAttempts: 2, Backoff: BackoffNone, BudgetRatio: 0.5},
+	}})
+	if err != nil {
+		t.Fatalf("NewRegistry failed: %v", err)
+	}
+	registry.SetMetrics(m)
+
+	_ = registry.Do(context.Background(), "read", func() error { return errors.New("temporary error") })
+
+	data := collect(t, reader)
+	if got := sumOf(data["retry_attempts_total"]); got != 1 {
+		t.Errorf("expected 1 attempt (budget exhausted), got %d", got)
+	}
+	if gauge, ok := data["retry_budget_requests"].(metricdata.Gauge[int64]); !ok || len(gauge.DataPoints) != 1 {
+		t.Errorf("expected the policy budget gauge, got %+v", data["retry_budget_requests"])
+	}
+
+	// Removed policies are no longer exported
+	if err := registry.Reload(RegistryConfig{Policies: map[string]PolicyConfig{"write": {}}}); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	data = collect(t, reader)
+	if gauge, ok := data["retry_budget_requests"].(metricdata.Gauge[int64]); ok && len(gauge.DataPoints) != 0 {
+		t.Errorf("expected no budget gauge, got %+v", gauge)
+	}
+}
@@ -27,11 +27,13 @@ func DoWithData[T any](ctx context.Context, operation func() (T, error), opts ..
 	
 	var result T
 	var errs []error
+	obs := newObserver(ctx, cfg)
 	
 	for attempt := 1; attempt <= cfg.maxAttempts; attempt++ {
 		// Check if the Context has been cancelled or timed out
 		select {
 		case <-ctx.Done():
+			obs.done(attempt-1, GiveUpContext)
 			return result, ctx.Err()
 		default:
 		}
@@ -43,6 +45,7 @@ func DoWithData[T any](ctx context.Context, operation func() (T, error), opts ..
 				Errors:   append(errs, ErrBudgetExhausted),
 				Attempts: attempt - 1,
 			}
+			obs.done(attempt-1, GiveUpBudget)
 			return result, multiErr
 		}
 		
@@ -64,6 +67,8 @@ func DoWithData[T any](ctx context.Context, operation func() (T, error), opts ..
 			if cfg.budget != nil {
 				cfg.budget.Record(true)
 			}
+			obs.attempt(attempt, nil, 0)
+			obs.done(attempt, "")
 			return result, nil
 		}
 		
@@ -75,13 +80,19 @@ func DoWithData[T any](ctx context.Context, operation func() (T, error), opts ..
 			cfg.budget.Record(false)
 		}
 		
-		// Determine if a retry should be attempted
-		if !cfg.condition.ShouldRetry(err, attempt) {
+		// Determine if a retry should be attempted (the server may ask not to retry)
+		if hint, ok := HintOf(err); (ok && hint.Stop) || !cfg.condition.ShouldRetry(err, attempt) {
 			// Should not retry, return directly
 			multiErr := &MultiError{
 				Errors:   errs,
 				Attempts: attempt,
 			}
+			obs.attempt(attempt, err, 0)
+			if ok && hint.Stop {
+				obs.done(attempt, GiveUpStopped)
+			} else {
+				obs.done(attempt, GiveUpNonRetryable)
+			}
 			return result, multiErr
 		}
 		
@@ -91,6 +102,8 @@ func DoWithData[T any](ctx context.Context, operation func() (T, error), opts ..
 				Errors:   errs,
 				Attempts: attempt,
 			}
+			obs.attempt(attempt, err, 0)
+			obs.done(attempt, GiveUpMaxAttempts)
 			return result, multiErr
 		}
 		
@@ -99,8 +112,8 @@ func DoWithData[T any](ctx context.Context, operation func() (T, error), opts ..
 			cfg.onRetry(attempt, err)
 		}
 		
-		// Calculate backoff time
-		backoff := cfg.backoff.Next(attempt)
+		// Calculate backoff time (from the error when the strategy honors server hints)
+		backoff := nextDelay(cfg.backoff, attempt, err)
 		
 		// Check if the remaining time is sufficient (if Context Deadline exists)
 		if deadline, ok := ctx.Deadline(); ok {
@@ -111,15 +124,19 @@ func DoWithData[T any](ctx context.Context, operation func() (T, error), opts ..
 					Errors:   append(errs, context.DeadlineExceeded),
 					Attempts: attempt,
 				}
+				obs.attempt(attempt, err, 0)
+				obs.done(attempt, GiveUpDeadline)
 				return result, multiErr
 			}
 		}
+		obs.attempt(attempt, err, backoff)
 		
 		// wait for backoff time (can be canceled by Context)
 		select {
 		case <-time.After(backoff):
 			// Continue retrying
 		case <-ctx.Done():
+			obs.done(attempt, GiveUpContext)
 			return result, ctx.Err()
 		}
 	}
@@ -65,9 +65,11 @@ type MetricsConfig struct {
 	Kafka          KafkaMetrics      `mapstructure:"kafka"`           // Kafka metric configuration
 	Limiter        LimiterMetrics    `mapstructure:"limiter"`         // Rate limiting configuration indicators
 	Breaker        BreakerMetrics    `mapstructure:"breaker"`         // circuit breaker metric configuration
+	Retry          RetryMetrics      `mapstructure:"retry"`           // Retry metric configuration
 	JWT            JWTMetrics        `mapstructure:"jwt"`             // JWT metric configuration
 	Auth           AuthMetrics       `mapstructure:"auth"`            // Authentication metric configuration
 	Event          EventMetrics      `mapstructure:"event"`           // Event metric configuration
+	Cache          CacheLayerMetrics `mapstructure:"cache"`           // Cache metric configuration
 }
 
 // HTTP Metrics HTTP layer metric configuration
@@ -121,6 +123,12 @@ type BreakerMetrics struct {
 	RecordSuccessRate bool `mapstructure:"record_success_rate"` // Whether to log success rate
 }
 
+// RetryMetrics retry metric configuration
+type RetryMetrics struct {
+	Enabled      bool `mapstructure:"enabled"`       // Whether to enable
+	RecordBudget bool `mapstructure:"record_budget"` // Whether to export retry budget statistics
+}
+
 // JWT Metrics JWT metric configuration
 type JWTMetrics struct {
 	Enabled bool `mapstructure:"enabled"` // Whether to enable
@@ -137,6 +145,12 @@ type EventMetrics struct {
 	RecordQueueSize bool `mapstructure:"record_queue_size"` // Whether to record queue size
 }
 
+// CacheLayerMetrics cache layer metric configuration
+type CacheLayerMetrics struct {
+	Enabled        bool `mapstructure:"enabled"`          // Is enabled
+	RecordHitRatio bool `mapstructure:"record_hit_ratio"` // Whether to record the per-cacheable hit ratio
+}
+
 // Return default configuration
 func DefaultConfig() Config {
 	return Config{
@@ -218,6 +232,10 @@ func DefaultConfig() Config {
 				RecordState:       true,
 				RecordSuccessRate: true,
 			},
+			Retry: RetryMetrics{
+				Enabled:      false,
+				RecordBudget: true,
+			},
 			JWT: JWTMetrics{
 				Enabled: false,
 			},
@@ -228,6 +246,10 @@ func DefaultConfig() Config {
 				Enabled:         false,
 				RecordQueueSize: true,
 			},
+			Cache: CacheLayerMetrics{
+				Enabled:        false,
+				RecordHitRatio: true,
+			},
 		},
 	}
 }